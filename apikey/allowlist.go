@@ -0,0 +1,57 @@
+package apikey
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// ParseAllowlist parse daftar IP / CIDR (contoh "203.0.113.7", "10.0.0.0/8"), IP tunggal menjadi /32 atau /128
+func ParseAllowlist(entries []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q: %w", entry, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP %q: %w", entry, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// IPAllowed cek ip client terhadap allowlist. Allowlist kosong berarti semua IP boleh.
+// Entry yang tidak valid diabaikan (sudah divalidasi saat key disimpan).
+func IPAllowed(allowlist []string, ip string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, entry := range allowlist {
+		prefixes, err := ParseAllowlist([]string{entry})
+		if err != nil {
+			continue
+		}
+		if prefixes[0].Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package apikey
+
+import "testing"
+
+func TestIPAllowed(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist []string
+		ip        string
+		want      bool
+	}{
+		{"empty allowlist", nil, "203.0.113.7", true},
+		{"exact ip", []string{"203.0.113.7"}, "203.0.113.7", true},
+		{"different ip", []string{"203.0.113.7"}, "203.0.113.8", false},
+		{"inside cidr", []string{"10.0.0.0/8"}, "10.20.30.40", true},
+		{"outside cidr", []string{"10.0.0.0/8"}, "11.0.0.1", false},
+		{"unmasked cidr", []string{"192.168.1.10/24"}, "192.168.1.200", true},
+		{"ipv4-mapped ipv6 client", []string{"203.0.113.7"}, "::ffff:203.0.113.7", true},
+		{"ipv4-mapped ipv6 entry", []string{"::ffff:203.0.113.7"}, "203.0.113.7", true},
+		{"ipv6 cidr", []string{"2001:db8::/32"}, "2001:db8:1::1", true},
+		{"ipv6 outside cidr", []string{"2001:db8::/32"}, "2001:db9::1", false},
+		{"entry with spaces", []string{" 203.0.113.7 "}, "203.0.113.7", true},
+		{"invalid entry skipped", []string{"not-an-ip", "203.0.113.7"}, "203.0.113.7", true},
+		{"only invalid entries", []string{"not-an-ip"}, "203.0.113.7", false},
+		{"invalid client ip", []string{"10.0.0.0/8"}, "unknown", false},
+		{"empty client ip", []string{"10.0.0.0/8"}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IPAllowed(tt.allowlist, tt.ip); got != tt.want {
+				t.Errorf("IPAllowed(%v, %q) = %v, want %v", tt.allowlist, tt.ip, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+// Prefix awalan semua API key, membedakannya dari JWT di header Authorization
+const Prefix = "lnv_"
+
+// Format key: lnv_<id 8 hex>_<secret 64 hex>. Bagian "lnv_<id>" disimpan apa adanya
+// untuk mencari key dan ditampilkan di dashboard, seluruh key hanya disimpan sebagai hash.
+const (
+	idBytes     = 4
+	secretBytes = 32
+)
+
+// Generate buat key baru. key hanya ditampilkan sekali ke admin, yang disimpan prefix & Hash(key)
+func Generate() (key string, prefix string, err error) {
+	random := make([]byte, idBytes+secretBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", err
+	}
+
+	prefix = Prefix + hex.EncodeToString(random[:idBytes])
+	key = prefix + "_" + hex.EncodeToString(random[idBytes:])
+	return key, prefix, nil
+}
+
+// IsKey cek apakah token berbentuk API key (bukan JWT)
+func IsKey(token string) bool {
+	return strings.HasPrefix(token, Prefix)
+}
+
+// ParsePrefix ambil bagian prefix dari key, false jika format tidak valid
+func ParsePrefix(key string) (string, bool) {
+	if !IsKey(key) {
+		return "", false
+	}
+
+	prefix, secret, found := strings.Cut(key[len(Prefix):], "_")
+	if !found || len(prefix) != idBytes*2 || len(secret) != secretBytes*2 {
+		return "", false
+	}
+	return Prefix + prefix, true
+}
+
+// Hash SHA-256 hex dari key. Key berisi 256 bit acak sehingga tidak perlu hash lambat (bcrypt)
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify bandingkan key dengan hash tersimpan dalam waktu konstan
+func Verify(key, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(key)), []byte(hash)) == 1
+}
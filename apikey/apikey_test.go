@@ -0,0 +1,54 @@
+package apikey
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePrefix(t *testing.T) {
+	secret := strings.Repeat("ab", secretBytes)
+
+	tests := []struct {
+		name   string
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{"valid", "lnv_0a1b2c3d_" + secret, "lnv_0a1b2c3d", true},
+		{"jwt", "eyJhbGciOiJIUzI1NiJ9.e30.sig", "", false},
+		{"empty", "", "", false},
+		{"prefix only", Prefix, "", false},
+		{"missing secret", "lnv_0a1b2c3d", "", false},
+		{"short id", "lnv_0a1b2c_" + secret, "", false},
+		{"long id", "lnv_0a1b2c3d4e_" + secret, "", false},
+		{"short secret", "lnv_0a1b2c3d_" + secret[2:], "", false},
+		{"long secret", "lnv_0a1b2c3d_" + secret + "ab", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParsePrefix(tt.key)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ParsePrefix(%q) = (%q, %v), want (%q, %v)", tt.key, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	key, prefix, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	got, ok := ParsePrefix(key)
+	if !ok || got != prefix {
+		t.Errorf("ParsePrefix(Generate()) = (%q, %v), want (%q, true)", got, ok, prefix)
+	}
+	if !Verify(key, Hash(key)) {
+		t.Error("Verify(key, Hash(key)) = false, want true")
+	}
+	if Verify(key+"x", Hash(key)) {
+		t.Error("Verify(other, Hash(key)) = true, want false")
+	}
+}
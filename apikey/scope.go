@@ -0,0 +1,109 @@
+package apikey
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Aksi scope. Write juga memberi akses read ke resource yang sama
+const (
+	ActionRead  = "read"
+	ActionWrite = "write"
+)
+
+// Wildcard scope "*" memberi akses penuh ke semua Resources
+const Wildcard = "*"
+
+// APIPrefix prefix route API, segmen pertama setelahnya adalah nama resource
+const APIPrefix = "/api/v1/"
+
+// Resources route group yang boleh diakses API key. Akun, API key sendiri dan webhook
+// (auth, profile, users, api-keys, webhooks) hanya bisa diakses lewat login admin.
+var Resources = []string{
+	"contacts",
+	"features",
+	"flyer-galleries",
+	"galleries",
+	"gallery-albums",
+	"heros",
+	"instructors",
+	"jobs",
+	"portfolios",
+	"programs",
+	"registrations",
+	"services",
+	"translations",
+	"video-galleries",
+}
+
+// Scopes daftar scope yang bisa dipilih: "<resource>:read", "<resource>:write" untuk tiap resource
+func Scopes() []string {
+	scopes := make([]string, 0, len(Resources)*2)
+	for _, resource := range Resources {
+		scopes = append(scopes, resource+":"+ActionRead, resource+":"+ActionWrite)
+	}
+	return scopes
+}
+
+// NormalizeScope bentuk scope yang disimpan: tanpa spasi dan huruf kecil
+func NormalizeScope(scope string) string {
+	return strings.ToLower(strings.TrimSpace(scope))
+}
+
+// ValidScope scope harus "*", "<resource>:<read|write|*>" atau "*:<read|write>"
+func ValidScope(scope string) bool {
+	if scope == Wildcard {
+		return true
+	}
+
+	resource, action, found := strings.Cut(scope, ":")
+	if !found {
+		return false
+	}
+	if resource == Wildcard {
+		return action == ActionRead || action == ActionWrite
+	}
+	return slices.Contains(Resources, resource) &&
+		(action == ActionRead || action == ActionWrite || action == Wildcard)
+}
+
+// Required scope yang dibutuhkan untuk request ke route (pola route gin, contoh /api/v1/programs/:id).
+// false jika route tidak bisa diakses dengan API key.
+func Required(method, route string) (string, bool) {
+	path, found := strings.CutPrefix(route, APIPrefix)
+	if !found {
+		return "", false
+	}
+
+	resource, _, _ := strings.Cut(path, "/")
+	if !slices.Contains(Resources, resource) {
+		return "", false
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return resource + ":" + ActionRead, true
+	}
+	return resource + ":" + ActionWrite, true
+}
+
+// Allows cek apakah salah satu scopes memenuhi scope required ("<resource>:<action>")
+func Allows(scopes []string, required string) bool {
+	resource, action, _ := strings.Cut(required, ":")
+
+	for _, scope := range scopes {
+		granted, grantedAction, _ := strings.Cut(scope, ":")
+		if scope == Wildcard {
+			return true
+		}
+		if granted != resource && granted != Wildcard {
+			continue
+		}
+		if grantedAction == Wildcard || grantedAction == action ||
+			(grantedAction == ActionWrite && action == ActionRead) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package apikey
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRequired(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		route  string
+		want   string
+		wantOK bool
+	}{
+		{"get collection", http.MethodGet, "/api/v1/programs", "programs:read", true},
+		{"get item", http.MethodGet, "/api/v1/programs/:id", "programs:read", true},
+		{"head", http.MethodHead, "/api/v1/heros", "heros:read", true},
+		{"options", http.MethodOptions, "/api/v1/heros", "heros:read", true},
+		{"post", http.MethodPost, "/api/v1/gallery-albums", "gallery-albums:write", true},
+		{"put", http.MethodPut, "/api/v1/programs/:id", "programs:write", true},
+		{"patch", http.MethodPatch, "/api/v1/registrations/:id/status", "registrations:write", true},
+		{"delete", http.MethodDelete, "/api/v1/services/:id", "services:write", true},
+		{"account route", http.MethodGet, "/api/v1/users", "", false},
+		{"api key route", http.MethodPost, "/api/v1/api-keys", "", false},
+		{"webhook route", http.MethodGet, "/api/v1/webhooks", "", false},
+		{"auth route", http.MethodPost, "/api/v1/auth/login", "", false},
+		{"resource name prefix", http.MethodGet, "/api/v1/programs-archive", "", false},
+		{"outside api prefix", http.MethodGet, "/healthz", "", false},
+		{"api prefix only", http.MethodGet, "/api/v1/", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Required(tt.method, tt.route)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Required(%q, %q) = (%q, %v), want (%q, %v)", tt.method, tt.route, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	tests := []struct {
+		name     string
+		scopes   []string
+		required string
+		want     bool
+	}{
+		{"no scopes", nil, "programs:read", false},
+		{"exact read", []string{"programs:read"}, "programs:read", true},
+		{"exact write", []string{"programs:write"}, "programs:write", true},
+		{"write implies read", []string{"programs:write"}, "programs:read", true},
+		{"read does not imply write", []string{"programs:read"}, "programs:write", false},
+		{"other resource", []string{"heros:write"}, "programs:read", false},
+		{"resource wildcard action", []string{"programs:*"}, "programs:write", true},
+		{"wildcard resource read", []string{"*:read"}, "services:read", true},
+		{"wildcard resource read denies write", []string{"*:read"}, "services:write", false},
+		{"wildcard resource write", []string{"*:write"}, "services:read", true},
+		{"full wildcard", []string{Wildcard}, "registrations:write", true},
+		{"any matching scope", []string{"heros:read", "programs:write"}, "programs:write", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Allows(tt.scopes, tt.required); got != tt.want {
+				t.Errorf("Allows(%v, %q) = %v, want %v", tt.scopes, tt.required, got, tt.want)
+			}
+		})
+	}
+}
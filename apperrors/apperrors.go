@@ -0,0 +1,129 @@
+package apperrors
+
+import (
+	"errors"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+// Kind kategori error domain, menentukan status HTTP
+type Kind string
+
+const (
+	KindNotFound     Kind = "not_found"
+	KindConflict     Kind = "conflict"
+	KindValidation   Kind = "validation_failed"
+	KindUnauthorized Kind = "unauthorized"
+	KindForbidden    Kind = "forbidden"
+	KindInternal     Kind = "internal_error"
+)
+
+// Error error domain dengan kode yang bisa dibaca mesin.
+// Message aman ditampilkan ke client, Err (penyebab asli) hanya untuk log.
+type Error struct {
+	Kind Kind
+	// Code kode spesifik, contoh "registration_exists". Kosong berarti sama dengan Kind
+	Code    string
+	Message string
+	// Fields detail error validasi per field
+	Fields []FieldError
+	Err    error
+}
+
+// FieldError satu kesalahan validasi pada field request
+type FieldError struct {
+	// Field path field sesuai nama di JSON/form, contoh "attendees[0].email"
+	Field string `json:"field"`
+	// Rule aturan yang gagal, contoh "required", "min", "email"
+	Rule string `json:"rule"`
+	// Param parameter aturan, contoh "3" untuk min=3
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// PublicCode kode yang dikirim ke client
+func (e *Error) PublicCode() string {
+	if e.Code != "" {
+		return e.Code
+	}
+	return string(e.Kind)
+}
+
+func NotFound(code, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+// Validation error input, fields berisi kesalahan per field (boleh nil)
+func Validation(code, message string, fields []FieldError) *Error {
+	return &Error{Kind: KindValidation, Code: code, Message: message, Fields: fields}
+}
+
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+// Internal bungkus error tak terduga (DB, I/O, ...), detailnya tidak pernah dikirim ke client
+func Internal(err error) *Error {
+	return &Error{Kind: KindInternal, Message: "internal server error", Err: err}
+}
+
+// From ambil *Error dari rantai err. gorm.ErrRecordNotFound yang belum dibungkus repository
+// menjadi NotFound umum, error lain yang bukan error domain dianggap Internal.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &Error{Kind: KindNotFound, Code: "not_found", Message: "resource not found", Err: err}
+	}
+	return Internal(err)
+}
+
+// KindOf kategori err, KindInternal untuk error yang bukan error domain
+func KindOf(err error) Kind {
+	return From(err).Kind
+}
+
+// IsNotFound singkatan untuk KindOf(err) == KindNotFound
+func IsNotFound(err error) bool {
+	return err != nil && KindOf(err) == KindNotFound
+}
+
+// HTTPStatus status HTTP untuk kategori error
+func HTTPStatus(kind Kind) int {
+	switch kind {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindValidation:
+		return http.StatusUnprocessableEntity
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
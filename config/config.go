@@ -0,0 +1,715 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/tech-azim/be-learnova/cors"
+	"github.com/tech-azim/be-learnova/ratelimit"
+	"gopkg.in/yaml.v3"
+)
+
+// MinJWTSecretLength panjang minimal JWT_SECRET (256 bit untuk HS256)
+const MinJWTSecretLength = 32
+
+// Config seluruh konfigurasi aplikasi. Dibaca sekali saat start lewat Load
+// lalu diteruskan ke komponen yang membutuhkan.
+type Config struct {
+	App       AppConfig       `yaml:"app"`
+	Server    ServerConfig    `yaml:"server"`
+	Log       LogConfig       `yaml:"log"`
+	Database  DatabaseConfig  `yaml:"database"`
+	JWT       JWTConfig       `yaml:"jwt"`
+	Mail      MailConfig      `yaml:"mail"`
+	Jobs      JobsConfig      `yaml:"jobs"`
+	RateLimit RateLimitConfig `yaml:"rateLimit"`
+	Spam      SpamConfig      `yaml:"spam"`
+	Metrics   MetricsConfig   `yaml:"metrics"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	CORS      CORSConfig      `yaml:"cors"`
+}
+
+type AppConfig struct {
+	Port     string `yaml:"port"`
+	SiteURL  string `yaml:"siteUrl"`
+	SiteName string `yaml:"siteName"`
+	AdminURL string `yaml:"adminUrl"`
+}
+
+// ServerConfig timeout http.Server. ReadTimeout/WriteTimeout dibuat cukup longgar untuk upload file.
+type ServerConfig struct {
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout"`
+	ReadTimeout       time.Duration `yaml:"readTimeout"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout"`
+	// ShutdownTimeout batas waktu menunggu request yang sedang berjalan saat SIGTERM
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	// DrainPeriod jeda antara /readyz gagal dan server berhenti menerima koneksi,
+	// supaya load balancer sempat mengeluarkan instance dari rotasi. 0 berarti tanpa jeda
+	DrainPeriod time.Duration `yaml:"drainPeriod"`
+	// RequestTimeout batas waktu context request (query DB, panggilan keluar)
+	RequestTimeout time.Duration `yaml:"requestTimeout"`
+	// MaxBodySize batas body request (byte) untuk JSON / form biasa
+	MaxBodySize int64 `yaml:"maxBodySize"`
+	// MaxUploadSize batas body request multipart (byte), beberapa route upload punya batas sendiri
+	MaxUploadSize int64 `yaml:"maxUploadSize"`
+	// HSTSMaxAge max-age header Strict-Transport-Security untuk request HTTPS, 0 mematikan HSTS
+	HSTSMaxAge time.Duration `yaml:"hstsMaxAge"`
+	// TrustedProxies IP/CIDR reverse proxy yang boleh mengirim X-Forwarded-For / X-Real-IP.
+	// Kosong berarti header tersebut diabaikan dan IP client diambil dari koneksi.
+	// IP client dipakai untuk rate limit, allowlist API key dan log.
+	TrustedProxies []string `yaml:"trustedProxies"`
+}
+
+type LogConfig struct {
+	// Level debug|info|warn|error
+	Level string `yaml:"level"`
+	// Format json|text
+	Format string `yaml:"format"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+	SSLMode  string `yaml:"sslMode"`
+	// AutoMigrate jalankan migrasi saat start
+	AutoMigrate bool `yaml:"autoMigrate"`
+
+	// Connection pool
+	MaxOpenConns    int           `yaml:"maxOpenConns"`
+	MaxIdleConns    int           `yaml:"maxIdleConns"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"connMaxIdleTime"`
+}
+
+// DSN connection string untuk driver postgres
+func (c DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		c.Host, c.User, c.Password, c.Name, c.Port, c.SSLMode)
+}
+
+type JWTConfig struct {
+	Secret string `yaml:"secret"`
+	// TTL masa berlaku token login
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type MailConfig struct {
+	Driver      string   `yaml:"driver"`
+	From        string   `yaml:"from"`
+	AdminEmails []string `yaml:"adminEmails"`
+	OutboxDir   string   `yaml:"outboxDir"`
+	SMTP        struct {
+		Host     string `yaml:"host"`
+		Port     string `yaml:"port"`
+		Username string `yaml:"username"`
+		Password string `yaml:"password"`
+	} `yaml:"smtp"`
+}
+
+// ReminderMaxDays batas hari di REMINDER_DAYS dan template reminder per program,
+// juga rentang registrasi yang dicek scheduler reminder
+const ReminderMaxDays = 60
+
+type JobsConfig struct {
+	// Workers jumlah worker di proses ini, 0 mematikan worker dan scheduler reminder
+	Workers          int           `yaml:"workers"`
+	ReminderDays     []int         `yaml:"reminderDays"`
+	ReminderInterval time.Duration `yaml:"reminderInterval"`
+}
+
+type RateLimitConfig struct {
+	// Store memory|redis
+	Store    string `yaml:"store"`
+	RedisURL string `yaml:"redisUrl"`
+	// Policies per route group, group yang tidak ada di map tidak dibatasi
+	Policies map[string]ratelimit.Policy `yaml:"policies"`
+}
+
+type SpamConfig struct {
+	CaptchaProvider        string          `yaml:"captchaProvider"`
+	CaptchaSecret          string          `yaml:"captchaSecret"`
+	DisposableDomains      []string        `yaml:"disposableDomains"`
+	RegistrationIPLimit    ratelimit.Limit `yaml:"registrationIpLimit"`
+	RegistrationEmailLimit ratelimit.Limit `yaml:"registrationEmailLimit"`
+}
+
+type MetricsConfig struct {
+	// Enabled daftarkan endpoint /metrics (Prometheus)
+	Enabled bool `yaml:"enabled"`
+	// Token opsional, jika diisi scraper wajib mengirim Authorization: Bearer <token>
+	Token string `yaml:"token"`
+}
+
+type TracingConfig struct {
+	// Exporter none|stdout|otlp
+	Exporter string `yaml:"exporter"`
+	// Endpoint URL collector OTLP/HTTP, kosong berarti memakai OTEL_EXPORTER_OTLP_ENDPOINT
+	Endpoint    string  `yaml:"endpoint"`
+	ServiceName string  `yaml:"serviceName"`
+	SampleRatio float64 `yaml:"sampleRatio"`
+}
+
+type CORSConfig struct {
+	// Policies per kelompok route, policy dipilih dari prefix path terpanjang (lihat cors.Router)
+	Policies map[string]cors.Policy `yaml:"policies"`
+}
+
+// adminPaths route yang hanya dipakai dashboard admin
+var adminPaths = []string{
+	"/api/v1/auth",
+	"/api/v1/profile",
+	"/api/v1/users",
+	"/api/v1/dashboard",
+	"/api/v1/contacts",
+	"/api/v1/jobs",
+	"/api/v1/webhooks",
+	"/api/v1/api-keys",
+	"/api/v1/translations",
+}
+
+// Default nilai bawaan sebelum file YAML dan environment dibaca
+func Default() Config {
+	cfg := Config{
+		App: AppConfig{
+			Port:     "8080",
+			SiteName: "Learnova",
+		},
+		Server: ServerConfig{
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       2 * time.Minute,
+			WriteTimeout:      2 * time.Minute,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   30 * time.Second,
+			DrainPeriod:       5 * time.Second,
+			RequestTimeout:    30 * time.Second,
+			MaxBodySize:       1 << 20,
+			MaxUploadSize:     10 << 20,
+			HSTSMaxAge:        180 * 24 * time.Hour,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            "5432",
+			SSLMode:         "disable",
+			AutoMigrate:     true,
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		JWT: JWTConfig{
+			TTL: 24 * time.Hour,
+		},
+		Mail: MailConfig{
+			Driver:    "outbox",
+			OutboxDir: "storage/outbox",
+		},
+		Jobs: JobsConfig{
+			Workers:          2,
+			ReminderDays:     []int{7, 1},
+			ReminderInterval: time.Hour,
+		},
+		RateLimit: RateLimitConfig{
+			Store: ratelimit.StoreMemory,
+			Policies: map[string]ratelimit.Policy{
+				"api": {
+					Anonymous:     ratelimit.Limit{Requests: 120, Period: time.Minute},
+					Authenticated: ratelimit.Limit{Requests: 600, Period: time.Minute},
+				},
+				"login": {
+					Anonymous:     ratelimit.Limit{Requests: 10, Period: time.Minute},
+					Authenticated: ratelimit.Limit{Requests: 10, Period: time.Minute},
+				},
+			},
+		},
+		Spam: SpamConfig{
+			RegistrationIPLimit:    ratelimit.Limit{Requests: 5, Period: 10 * time.Minute},
+			RegistrationEmailLimit: ratelimit.Limit{Requests: 3, Period: time.Hour},
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "be-learnova",
+			SampleRatio: 1,
+		},
+		CORS: CORSConfig{
+			Policies: map[string]cors.Policy{
+				cors.PolicyPublic: {
+					AllowedOrigins: []string{cors.AnyOrigin},
+					MaxAge:         10 * time.Minute,
+				},
+				// AllowedOrigins kosong diisi dari ADMIN_URL saat Load
+				cors.PolicyAdmin: {
+					AllowCredentials: true,
+					MaxAge:           10 * time.Minute,
+					Paths:            adminPaths,
+				},
+			},
+		},
+	}
+	cfg.Mail.SMTP.Port = "587"
+	return cfg
+}
+
+// Load baca konfigurasi dengan urutan prioritas naik: default, file YAML
+// (CONFIG_FILE, default config.yaml jika ada), file .env, lalu environment variable.
+// File .env bersifat opsional supaya deploy container cukup memakai environment.
+func Load() (Config, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Config{}, fmt.Errorf("load .env: %w", err)
+	}
+
+	cfg := Default()
+
+	path, required := os.LookupEnv("CONFIG_FILE")
+	if !required {
+		path = "config.yaml"
+	}
+	if err := cfg.loadYAML(path, required); err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return Config{}, err
+	}
+	cfg.defaultAdminOrigins()
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// defaultAdminOrigins policy admin tanpa allowlist memakai origin dari ADMIN_URL,
+// atau semua origin (tanpa credentials) jika ADMIN_URL juga kosong
+func (c *Config) defaultAdminOrigins() {
+	policy, ok := c.CORS.Policies[cors.PolicyAdmin]
+	if !ok || len(policy.AllowedOrigins) > 0 {
+		return
+	}
+
+	policy.AllowedOrigins = []string{cors.AnyOrigin}
+	if parsed, err := url.Parse(c.App.AdminURL); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		policy.AllowedOrigins = []string{parsed.Scheme + "://" + parsed.Host}
+	}
+	c.CORS.Policies[cors.PolicyAdmin] = policy
+}
+
+func (c *Config) loadYAML(path string, required bool) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadEnv timpa nilai dengan environment variable yang di-set
+func (c *Config) loadEnv() error {
+	env := &envReader{}
+
+	env.string(&c.App.Port, "PORT")
+	env.string(&c.App.SiteURL, "SITE_URL")
+	env.string(&c.App.SiteName, "SITE_NAME")
+	env.string(&c.App.AdminURL, "ADMIN_URL")
+
+	env.duration(&c.Server.ReadHeaderTimeout, "SERVER_READ_HEADER_TIMEOUT")
+	env.duration(&c.Server.ReadTimeout, "SERVER_READ_TIMEOUT")
+	env.duration(&c.Server.WriteTimeout, "SERVER_WRITE_TIMEOUT")
+	env.duration(&c.Server.IdleTimeout, "SERVER_IDLE_TIMEOUT")
+	env.duration(&c.Server.ShutdownTimeout, "SERVER_SHUTDOWN_TIMEOUT")
+	env.duration(&c.Server.DrainPeriod, "SERVER_DRAIN_PERIOD")
+	env.duration(&c.Server.RequestTimeout, "SERVER_REQUEST_TIMEOUT")
+	env.int64(&c.Server.MaxBodySize, "SERVER_MAX_BODY_SIZE")
+	env.int64(&c.Server.MaxUploadSize, "SERVER_MAX_UPLOAD_SIZE")
+	env.duration(&c.Server.HSTSMaxAge, "SERVER_HSTS_MAX_AGE")
+	env.list(&c.Server.TrustedProxies, "TRUSTED_PROXIES")
+
+	env.string(&c.Log.Level, "LOG_LEVEL")
+	env.string(&c.Log.Format, "LOG_FORMAT")
+
+	env.string(&c.Database.Host, "DB_HOST")
+	env.string(&c.Database.Port, "DB_PORT")
+	env.string(&c.Database.User, "DB_USER")
+	env.string(&c.Database.Password, "DB_PASSWORD")
+	env.string(&c.Database.Name, "DB_NAME")
+	env.string(&c.Database.SSLMode, "DB_SSLMODE")
+	env.bool(&c.Database.AutoMigrate, "DB_AUTO_MIGRATE")
+	env.int(&c.Database.MaxOpenConns, "DB_MAX_OPEN_CONNS")
+	env.int(&c.Database.MaxIdleConns, "DB_MAX_IDLE_CONNS")
+	env.duration(&c.Database.ConnMaxLifetime, "DB_CONN_MAX_LIFETIME")
+	env.duration(&c.Database.ConnMaxIdleTime, "DB_CONN_MAX_IDLE_TIME")
+
+	env.string(&c.JWT.Secret, "JWT_SECRET")
+	env.duration(&c.JWT.TTL, "JWT_TTL")
+
+	env.string(&c.Mail.Driver, "MAIL_DRIVER")
+	env.string(&c.Mail.From, "MAIL_FROM")
+	env.list(&c.Mail.AdminEmails, "MAIL_ADMIN_EMAILS")
+	env.string(&c.Mail.OutboxDir, "MAIL_OUTBOX_DIR")
+	env.string(&c.Mail.SMTP.Host, "SMTP_HOST")
+	env.string(&c.Mail.SMTP.Port, "SMTP_PORT")
+	env.string(&c.Mail.SMTP.Username, "SMTP_USERNAME")
+	env.string(&c.Mail.SMTP.Password, "SMTP_PASSWORD")
+
+	env.int(&c.Jobs.Workers, "JOB_WORKERS")
+	env.ints(&c.Jobs.ReminderDays, "REMINDER_DAYS")
+	env.duration(&c.Jobs.ReminderInterval, "REMINDER_INTERVAL")
+
+	env.string(&c.RateLimit.Store, "RATE_LIMIT_STORE")
+	env.string(&c.RateLimit.RedisURL, "REDIS_URL")
+	if c.RateLimit.Policies == nil {
+		c.RateLimit.Policies = make(map[string]ratelimit.Policy)
+	}
+	// RATE_LIMIT_<GROUP>, contoh RATE_LIMIT_API="120/1m,600/1m"
+	for _, item := range os.Environ() {
+		key, _, _ := strings.Cut(item, "=")
+		group, ok := strings.CutPrefix(key, "RATE_LIMIT_")
+		if !ok || group == "STORE" {
+			continue
+		}
+		env.policy(c.RateLimit.Policies, strings.ToLower(group), key)
+	}
+
+	env.string(&c.Spam.CaptchaProvider, "CAPTCHA_PROVIDER")
+	env.string(&c.Spam.CaptchaSecret, "CAPTCHA_SECRET")
+	env.list(&c.Spam.DisposableDomains, "DISPOSABLE_EMAIL_DOMAINS")
+	env.limit(&c.Spam.RegistrationIPLimit, "REGISTRATION_RATE_LIMIT_IP")
+	env.limit(&c.Spam.RegistrationEmailLimit, "REGISTRATION_RATE_LIMIT_EMAIL")
+
+	env.bool(&c.Metrics.Enabled, "METRICS_ENABLED")
+	env.string(&c.Metrics.Token, "METRICS_TOKEN")
+
+	env.string(&c.Tracing.Exporter, "TRACING_EXPORTER")
+	env.string(&c.Tracing.Endpoint, "TRACING_ENDPOINT")
+	env.string(&c.Tracing.ServiceName, "TRACING_SERVICE_NAME")
+	env.float(&c.Tracing.SampleRatio, "TRACING_SAMPLE_RATIO")
+
+	// CORS_<POLICY>_ORIGINS|CREDENTIALS|MAX_AGE, contoh CORS_ADMIN_ORIGINS="https://admin.example.com"
+	for name, policy := range c.CORS.Policies {
+		prefix := "CORS_" + strings.ToUpper(name) + "_"
+		env.list(&policy.AllowedOrigins, prefix+"ORIGINS")
+		env.bool(&policy.AllowCredentials, prefix+"CREDENTIALS")
+		env.duration(&policy.MaxAge, prefix+"MAX_AGE")
+		c.CORS.Policies[name] = policy
+	}
+
+	return errors.Join(env.errs...)
+}
+
+// Validate cek nilai yang wajib ada dan kombinasi yang tidak valid
+func (c Config) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if port, err := strconv.Atoi(c.App.Port); err != nil || port <= 0 || port > 65535 {
+		invalid("PORT must be a valid TCP port, got %q", c.App.Port)
+	}
+
+	for key, timeout := range map[string]time.Duration{
+		"SERVER_READ_HEADER_TIMEOUT": c.Server.ReadHeaderTimeout,
+		"SERVER_READ_TIMEOUT":        c.Server.ReadTimeout,
+		"SERVER_WRITE_TIMEOUT":       c.Server.WriteTimeout,
+		"SERVER_IDLE_TIMEOUT":        c.Server.IdleTimeout,
+		"SERVER_SHUTDOWN_TIMEOUT":    c.Server.ShutdownTimeout,
+		"SERVER_REQUEST_TIMEOUT":     c.Server.RequestTimeout,
+	} {
+		if timeout <= 0 {
+			invalid("%s must be positive", key)
+		}
+	}
+	for key, size := range map[string]int64{
+		"SERVER_MAX_BODY_SIZE":   c.Server.MaxBodySize,
+		"SERVER_MAX_UPLOAD_SIZE": c.Server.MaxUploadSize,
+	} {
+		if size <= 0 {
+			invalid("%s must be positive", key)
+		}
+	}
+	if c.Server.DrainPeriod < 0 {
+		invalid("SERVER_DRAIN_PERIOD must not be negative")
+	}
+	if c.Server.HSTSMaxAge < 0 {
+		invalid("SERVER_HSTS_MAX_AGE must not be negative")
+	}
+	for _, proxy := range c.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			invalid("TRUSTED_PROXIES must contain IP addresses or CIDR ranges, got %q", proxy)
+		}
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		invalid("LOG_LEVEL must be debug, info, warn or error, got %q", c.Log.Level)
+	}
+	switch strings.ToLower(c.Log.Format) {
+	case "json", "text":
+	default:
+		invalid("LOG_FORMAT must be json or text, got %q", c.Log.Format)
+	}
+
+	if c.Database.Name == "" {
+		invalid("DB_NAME is required")
+	}
+	if c.Database.User == "" {
+		invalid("DB_USER is required")
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 {
+		invalid("DB_MAX_OPEN_CONNS and DB_MAX_IDLE_CONNS must not be negative")
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		invalid("DB_MAX_IDLE_CONNS must not exceed DB_MAX_OPEN_CONNS")
+	}
+
+	if c.JWT.Secret == "" {
+		invalid("JWT_SECRET is required")
+	} else if len(c.JWT.Secret) < MinJWTSecretLength {
+		invalid("JWT_SECRET must be at least %d characters", MinJWTSecretLength)
+	}
+	if c.JWT.TTL <= 0 {
+		invalid("JWT_TTL must be positive")
+	}
+
+	switch strings.ToLower(c.Mail.Driver) {
+	case "outbox":
+	case "smtp":
+		if c.Mail.SMTP.Host == "" {
+			invalid("SMTP_HOST is required when MAIL_DRIVER=smtp")
+		}
+	default:
+		invalid("MAIL_DRIVER must be smtp or outbox, got %q", c.Mail.Driver)
+	}
+
+	if c.Jobs.Workers < 0 {
+		invalid("JOB_WORKERS must not be negative")
+	}
+	for _, day := range c.Jobs.ReminderDays {
+		if day < 0 || day > ReminderMaxDays {
+			invalid("REMINDER_DAYS must only contain days between 0 and %d", ReminderMaxDays)
+			break
+		}
+	}
+	if c.Jobs.ReminderInterval <= 0 {
+		invalid("REMINDER_INTERVAL must be positive")
+	}
+
+	switch c.RateLimit.Store {
+	case ratelimit.StoreMemory:
+	case ratelimit.StoreRedis:
+		if c.RateLimit.RedisURL == "" {
+			invalid("REDIS_URL is required when RATE_LIMIT_STORE=redis")
+		}
+	default:
+		invalid("RATE_LIMIT_STORE must be memory or redis, got %q", c.RateLimit.Store)
+	}
+
+	switch strings.ToLower(c.Spam.CaptchaProvider) {
+	case "", "none":
+	case "recaptcha", "hcaptcha", "turnstile", "fake":
+		if c.Spam.CaptchaSecret == "" {
+			invalid("CAPTCHA_SECRET is required when CAPTCHA_PROVIDER=%s", c.Spam.CaptchaProvider)
+		}
+	default:
+		invalid("unknown CAPTCHA_PROVIDER %q", c.Spam.CaptchaProvider)
+	}
+
+	switch strings.ToLower(c.Tracing.Exporter) {
+	case "", "none", "stdout", "otlp":
+	default:
+		invalid("TRACING_EXPORTER must be none, stdout or otlp, got %q", c.Tracing.Exporter)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		invalid("TRACING_SAMPLE_RATIO must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+	}
+
+	if _, err := cors.NewRouter(c.CORS.Policies); err != nil {
+		errs = append(errs, err)
+	}
+	for name, policy := range c.CORS.Policies {
+		for _, path := range policy.Paths {
+			if !strings.HasPrefix(path, "/") {
+				invalid("cors policy %s: path %q must start with /", name, path)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// envReader kumpulkan error parsing supaya semua kesalahan dilaporkan sekaligus
+type envReader struct {
+	errs []error
+}
+
+func (e *envReader) lookup(key string) (string, bool) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSpace(value), true
+}
+
+func (e *envReader) fail(key string, value string, err error) {
+	e.errs = append(e.errs, fmt.Errorf("%s=%q: %w", key, value, err))
+}
+
+func (e *envReader) string(dst *string, key string) {
+	if value, ok := e.lookup(key); ok && value != "" {
+		*dst = value
+	}
+}
+
+func (e *envReader) bool(dst *bool, key string) {
+	value, ok := e.lookup(key)
+	if !ok || value == "" {
+		return
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		e.fail(key, value, err)
+		return
+	}
+	*dst = parsed
+}
+
+func (e *envReader) int(dst *int, key string) {
+	value, ok := e.lookup(key)
+	if !ok || value == "" {
+		return
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		e.fail(key, value, err)
+		return
+	}
+	*dst = parsed
+}
+
+func (e *envReader) int64(dst *int64, key string) {
+	value, ok := e.lookup(key)
+	if !ok || value == "" {
+		return
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		e.fail(key, value, err)
+		return
+	}
+	*dst = parsed
+}
+
+func (e *envReader) float(dst *float64, key string) {
+	value, ok := e.lookup(key)
+	if !ok || value == "" {
+		return
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		e.fail(key, value, err)
+		return
+	}
+	*dst = parsed
+}
+
+func (e *envReader) duration(dst *time.Duration, key string) {
+	value, ok := e.lookup(key)
+	if !ok || value == "" {
+		return
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		e.fail(key, value, err)
+		return
+	}
+	*dst = parsed
+}
+
+// list nilai dipisah koma, contoh "a@x.com, b@x.com"
+func (e *envReader) list(dst *[]string, key string) {
+	value, ok := e.lookup(key)
+	if !ok || value == "" {
+		return
+	}
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	*dst = result
+}
+
+func (e *envReader) ints(dst *[]int, key string) {
+	var items []string
+	e.list(&items, key)
+	if items == nil {
+		return
+	}
+	result := make([]int, 0, len(items))
+	for _, item := range items {
+		parsed, err := strconv.Atoi(item)
+		if err != nil {
+			e.fail(key, item, err)
+			return
+		}
+		result = append(result, parsed)
+	}
+	*dst = result
+}
+
+func (e *envReader) limit(dst *ratelimit.Limit, key string) {
+	value, ok := e.lookup(key)
+	if !ok || value == "" {
+		return
+	}
+	if err := dst.UnmarshalText([]byte(value)); err != nil {
+		e.fail(key, value, err)
+	}
+}
+
+// policy "off" menghapus limit group tersebut
+func (e *envReader) policy(policies map[string]ratelimit.Policy, group string, key string) {
+	value, ok := e.lookup(key)
+	if !ok || value == "" {
+		return
+	}
+	if value == "off" {
+		delete(policies, group)
+		return
+	}
+	var policy ratelimit.Policy
+	if err := policy.UnmarshalText([]byte(value)); err != nil {
+		e.fail(key, value, err)
+		return
+	}
+	policies[group] = policy
+}
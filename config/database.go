@@ -2,34 +2,29 @@ package config
 
 import (
 	"fmt"
-	"log"
-	"os"
 
-	"github.com/tech-azim/be-learnova/models"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 var DB *gorm.DB
 
-func ConnectDB() {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_SSLMODE"),
-	)
-
-	database, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+func ConnectDB(cfg DatabaseConfig) error {
+	database, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{})
 
 	if err != nil {
-		log.Fatal("Failed to connect db", err)
+		return fmt.Errorf("connect db: %w", err)
 	}
 
-	database.AutoMigrate(&models.User{}, &models.Hero{}, &models.Program{}, &models.Registration{}, &models.Service{}, &models.Portfolio{}, &models.Feature{},  &models.Gallery{},  &models.FlyerGallery{}, &models.VideoGallery{},)
+	sqlDB, err := database.DB()
+	if err != nil {
+		return fmt.Errorf("get db connection pool: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
 	DB = database
-	log.Print("Successfully connect database")
+	return nil
 }
@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/apikey"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/services"
+	"github.com/tech-azim/be-learnova/utils"
+	"github.com/tech-azim/be-learnova/validation"
+)
+
+type APIKeyRequest struct {
+	Name string `json:"name" binding:"required,max=255"`
+	// Scopes contoh "programs:read", "registrations:write", "*:read" atau "*"
+	Scopes []string `json:"scopes" binding:"required,min=1"`
+	// AllowedIPs IP atau CIDR, kosong berarti semua IP boleh
+	AllowedIPs []string   `json:"allowed_ips" binding:"omitempty,dive,ip|cidr"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	IsActive   *bool      `json:"is_active"`
+}
+
+type APIKeyController struct {
+	apiKeyService services.APIKeyService
+}
+
+func NewAPIKeyController(apiKeyService services.APIKeyService) *APIKeyController {
+	return &APIKeyController{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// bindAPIKey bind & validasi request, termasuk scope yang tidak dikenal dan expiry di masa lalu
+// (expiry hanya dicek saat create atau saat expiry diubah)
+// Return false jika response error sudah dikirim ke client
+func bindAPIKey(c *gin.Context, req *APIKeyRequest, current *time.Time) bool {
+	errs := validation.New(c)
+	if err := errs.Bind(c.ShouldBindJSON(req)); err != nil {
+		respondError(c, err)
+		return false
+	}
+
+	for i, scope := range req.Scopes {
+		if !apikey.ValidScope(apikey.NormalizeScope(scope)) {
+			errs.Add(fmt.Sprintf("scopes[%d]", i), "invalid", "")
+		}
+	}
+	changed := req.ExpiresAt != nil && (current == nil || !req.ExpiresAt.Equal(*current))
+	if changed && !req.ExpiresAt.After(time.Now()) {
+		errs.Add("expires_at", "future", "")
+	}
+
+	if err := errs.Err(); err != nil {
+		respondError(c, err)
+		return false
+	}
+	return true
+}
+
+// findKey ambil :id dari URL dan memastikan API key ada
+func (ctrl *APIKeyController) findKey(c *gin.Context) (models.APIKey, bool) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return models.APIKey{}, false
+	}
+
+	key, err := ctrl.apiKeyService.FindByID(id)
+	if err != nil {
+		respondError(c, err)
+		return models.APIKey{}, false
+	}
+
+	return key, true
+}
+
+// Scopes daftar scope yang bisa diberikan ke API key
+func (ctrl *APIKeyController) Scopes(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data": apikey.Scopes(),
+	})
+}
+
+func (ctrl *APIKeyController) FindAll(c *gin.Context) {
+	params := utils.GetPaginationParams(c)
+
+	data, total, err := ctrl.apiKeyService.FindAll(params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"pagination": gin.H{
+			"page":  params.Page,
+			"limit": params.Limit,
+			"total": total,
+		},
+	})
+}
+
+func (ctrl *APIKeyController) FindByID(c *gin.Context) {
+	key, ok := ctrl.findKey(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": key,
+	})
+}
+
+// Create key plaintext hanya ditampilkan sekali di response ini (dan saat rotate)
+func (ctrl *APIKeyController) Create(c *gin.Context) {
+	var req APIKeyRequest
+	if !bindAPIKey(c, &req, nil) {
+		return
+	}
+
+	payload := models.APIKey{
+		Name:       req.Name,
+		Scopes:     req.Scopes,
+		AllowedIPs: req.AllowedIPs,
+		ExpiresAt:  req.ExpiresAt,
+		IsActive:   req.IsActive == nil || *req.IsActive,
+	}
+	if userID, ok := c.Get("user_id"); ok {
+		if id, ok := userID.(uint); ok {
+			payload.CreatedBy = &id
+		}
+	}
+
+	data, key, err := ctrl.apiKeyService.Create(payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    data,
+		"key":     key,
+		"message": "API key created successfully",
+	})
+}
+
+// Update key tidak bisa diubah di sini, gunakan rotate
+func (ctrl *APIKeyController) Update(c *gin.Context) {
+	existing, ok := ctrl.findKey(c)
+	if !ok {
+		return
+	}
+
+	var req APIKeyRequest
+	if !bindAPIKey(c, &req, existing.ExpiresAt) {
+		return
+	}
+
+	payload := existing
+	payload.Name = req.Name
+	payload.Scopes = req.Scopes
+	payload.AllowedIPs = req.AllowedIPs
+	payload.ExpiresAt = req.ExpiresAt
+	if req.IsActive != nil {
+		payload.IsActive = *req.IsActive
+	}
+
+	data, err := ctrl.apiKeyService.Update(payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "API key updated successfully",
+	})
+}
+
+func (ctrl *APIKeyController) Delete(c *gin.Context) {
+	existing, ok := ctrl.findKey(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.apiKeyService.Delete(existing.ID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API key deleted successfully",
+		"data": gin.H{
+			"id":     existing.ID,
+			"prefix": existing.Prefix,
+		},
+	})
+}
+
+// Rotate buat key baru untuk API key yang sama, key lama langsung tidak berlaku
+func (ctrl *APIKeyController) Rotate(c *gin.Context) {
+	existing, ok := ctrl.findKey(c)
+	if !ok {
+		return
+	}
+
+	data, key, err := ctrl.apiKeyService.Rotate(existing.ID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"key":     key,
+		"message": "API key rotated successfully",
+	})
+}
@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/services"
+	"gorm.io/gorm"
+)
+
+type AttendeeRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Email    string `json:"email" binding:"omitempty,email"`
+	Position string `json:"position"`
+}
+
+// ReplaceAttendeesRequest payload untuk mengganti seluruh attendee registrasi
+type ReplaceAttendeesRequest struct {
+	Attendees []AttendeeRequest `json:"attendees" binding:"required,min=1,dive"`
+}
+
+// toAttendees konversi request attendee ke model
+func toAttendees(reqs []AttendeeRequest) []models.Attendee {
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	attendees := make([]models.Attendee, 0, len(reqs))
+	for _, req := range reqs {
+		attendees = append(attendees, models.Attendee{
+			Name:     req.Name,
+			Email:    req.Email,
+			Position: req.Position,
+		})
+	}
+	return attendees
+}
+
+type AttendeeController struct {
+	attendeeService     services.AttendeeService
+	registrationService services.RegistrationService
+}
+
+func NewAttendeeController(attendeeService services.AttendeeService, registrationService services.RegistrationService) *AttendeeController {
+	return &AttendeeController{
+		attendeeService:     attendeeService,
+		registrationService: registrationService,
+	}
+}
+
+// findRegistration ambil :id registrasi dari URL dan memastikan registrasi ada
+func (ctrl *AttendeeController) findRegistration(c *gin.Context) (models.Registration, bool) {
+	registrationID, ok := parseUintParam(c, "id")
+	if !ok {
+		return models.Registration{}, false
+	}
+
+	registration, err := ctrl.registrationService.FindByID(c.Request.Context(), registrationID)
+	if err != nil {
+		respondError(c, err)
+		return models.Registration{}, false
+	}
+
+	return registration, true
+}
+
+// findAttendee ambil :attendeeId dari URL dan memastikan attendee milik registrasi
+func (ctrl *AttendeeController) findAttendee(c *gin.Context) (models.Attendee, bool) {
+	registration, ok := ctrl.findRegistration(c)
+	if !ok {
+		return models.Attendee{}, false
+	}
+
+	attendeeID, ok := parseUintParam(c, "attendeeId")
+	if !ok {
+		return models.Attendee{}, false
+	}
+
+	attendee, err := ctrl.attendeeService.FindByID(c.Request.Context(), registration.ID, attendeeID)
+	if err != nil {
+		respondError(c, err)
+		return models.Attendee{}, false
+	}
+
+	return attendee, true
+}
+
+func (ctrl *AttendeeController) FindAll(c *gin.Context) {
+	registration, ok := ctrl.findRegistration(c)
+	if !ok {
+		return
+	}
+
+	data, err := ctrl.attendeeService.FindByRegistrationID(c.Request.Context(), registration.ID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":         data,
+		"participants": registration.Participants,
+	})
+}
+
+func (ctrl *AttendeeController) Create(c *gin.Context) {
+	registration, ok := ctrl.findRegistration(c)
+	if !ok {
+		return
+	}
+
+	var req AttendeeRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	payload := models.Attendee{
+		RegistrationID: registration.ID,
+		Name:           req.Name,
+		Email:          req.Email,
+		Position:       req.Position,
+	}
+
+	attendee, err := ctrl.attendeeService.Create(c.Request.Context(), payload)
+	if errors.Is(err, services.ErrAttendeeLimit) {
+		// Tambah peserta lewat PUT /registrations/:id/attendees supaya participants ikut berubah
+		c.JSON(http.StatusConflict, gin.H{
+			"message": "Attendee list already matches participant count",
+		})
+		return
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    attendee,
+		"message": "Attendee created successfully",
+	})
+}
+
+// Replace mengganti seluruh attendee, participants registrasi disesuaikan dengan jumlah attendee
+func (ctrl *AttendeeController) Replace(c *gin.Context) {
+	registration, ok := ctrl.findRegistration(c)
+	if !ok {
+		return
+	}
+
+	var req ReplaceAttendeesRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	data, err := ctrl.attendeeService.Replace(c.Request.Context(), registration, toAttendees(req.Attendees))
+	if errors.Is(err, services.ErrProgramFull) {
+		c.JSON(http.StatusConflict, gin.H{
+			"message": "Program is full",
+		})
+		return
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":         data,
+		"participants": len(data),
+		"message":      "Attendees replaced successfully",
+	})
+}
+
+func (ctrl *AttendeeController) Update(c *gin.Context) {
+	existingAttendee, ok := ctrl.findAttendee(c)
+	if !ok {
+		return
+	}
+
+	var req AttendeeRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	payload := existingAttendee
+	payload.Name = req.Name
+	payload.Email = req.Email
+	payload.Position = req.Position
+
+	data, err := ctrl.attendeeService.Update(c.Request.Context(), payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Attendee updated successfully",
+	})
+}
+
+func (ctrl *AttendeeController) Delete(c *gin.Context) {
+	existingAttendee, ok := ctrl.findAttendee(c)
+	if !ok {
+		return
+	}
+
+	err := ctrl.attendeeService.Delete(c.Request.Context(), existingAttendee.RegistrationID, existingAttendee.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"message": "Attendee not found",
+		})
+		return
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Attendee deleted successfully",
+		"data": gin.H{
+			"id":   existingAttendee.ID,
+			"name": existingAttendee.Name,
+		},
+	})
+}
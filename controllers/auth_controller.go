@@ -25,18 +25,14 @@ func NewAuthController(authService services.AuthService) *AuthController {
 
 func (ctrl *AuthController) Login(c *gin.Context){
 	var req LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H {
-			"Mesage": err.Error(),
-		})
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	token, user, err := ctrl.authService.Login(req.Email,req.Password)
+	token, user, err := ctrl.authService.Login(c.Request.Context(), req.Email,req.Password)
 	if  err != nil {
-		c.JSON(http.StatusBadRequest, gin.H {
-			"Mesage": err.Error(),
-		})
+		// Email/password salah menjadi 401, error database menjadi 500
+		respondError(c, err)
 		return
 	}
 	
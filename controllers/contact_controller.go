@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/services"
+	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
+)
+
+type ContactRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Email    string `json:"email" binding:"omitempty,email"`
+	Phone    string `json:"phone"`
+	Position string `json:"position"`
+	Company  string `json:"company"`
+}
+
+type ContactMergeRequest struct {
+	SourceIDs []uint `json:"source_ids" binding:"required,min=1,unique"`
+}
+
+type ContactController struct {
+	contactService services.ContactService
+}
+
+func NewContactController(contactService services.ContactService) *ContactController {
+	return &ContactController{
+		contactService: contactService,
+	}
+}
+
+func (ctrl *ContactController) FindAll(c *gin.Context) {
+	params := utils.GetPaginationParams(c)
+
+	data, total, err := ctrl.contactService.FindAll(c.Request.Context(), params, c.Query("search"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"pagination": gin.H{
+			"page":  params.Page,
+			"limit": params.Limit,
+			"total": total,
+		},
+	})
+}
+
+// FindByID detail contact beserta semua registrasinya di seluruh program
+func (ctrl *ContactController) FindByID(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	data, err := ctrl.contactService.FindDetailByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+// FindDuplicates kandidat contact duplikat untuk di-merge
+func (ctrl *ContactController) FindDuplicates(c *gin.Context) {
+	data, err := ctrl.contactService.FindDuplicates(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (ctrl *ContactController) Update(c *gin.Context) {
+	// 1. Ambil ID dari URL parameter
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// 2. Cek apakah contact exist
+	existingContact, err := ctrl.contactService.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// 3. Bind request JSON
+	var req ContactRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	existingContact.Name = req.Name
+	existingContact.Email = req.Email
+	existingContact.Phone = req.Phone
+	existingContact.Position = req.Position
+	existingContact.Organization = nil
+
+	// 4. Update ke database
+	data, err := ctrl.contactService.Update(c.Request.Context(), existingContact, req.Company)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Contact updated successfully",
+	})
+}
+
+// Merge gabungkan contact duplikat (source_ids) ke contact :id
+func (ctrl *ContactController) Merge(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req ContactMergeRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	data, err := ctrl.contactService.Merge(c.Request.Context(), id, req.SourceIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrContactMergeSelf):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"message": "Cannot merge contact into itself",
+			})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more contacts not found",
+			})
+		default:
+			respondError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Contacts merged successfully",
+	})
+}
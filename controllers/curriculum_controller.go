@@ -0,0 +1,310 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/services"
+	"gorm.io/gorm"
+)
+
+type CurriculumModuleRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+}
+
+type CurriculumLessonRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	Duration    string `json:"duration"`
+}
+
+type CurriculumController struct {
+	curriculumService services.CurriculumService
+	programService    services.ProgramService
+}
+
+func NewCurriculumController(curriculumService services.CurriculumService, programService services.ProgramService) *CurriculumController {
+	return &CurriculumController{
+		curriculumService: curriculumService,
+		programService:    programService,
+	}
+}
+
+// findModule ambil :moduleId dari URL dan memastikan module milik program
+func (ctrl *CurriculumController) findModule(c *gin.Context) (models.CurriculumModule, bool) {
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return models.CurriculumModule{}, false
+	}
+
+	moduleID, ok := parseUintParam(c, "moduleId")
+	if !ok {
+		return models.CurriculumModule{}, false
+	}
+
+	module, err := ctrl.curriculumService.FindModuleByID(c.Request.Context(), programID, moduleID)
+	if err != nil {
+		respondError(c, err)
+		return models.CurriculumModule{}, false
+	}
+
+	return module, true
+}
+
+func (ctrl *CurriculumController) FindAll(c *gin.Context) {
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return
+	}
+
+	data, err := ctrl.curriculumService.FindModulesByProgramID(c.Request.Context(), programID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (ctrl *CurriculumController) CreateModule(c *gin.Context) {
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return
+	}
+
+	var req CurriculumModuleRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	payload := models.CurriculumModule{
+		ProgramID:   programID,
+		Title:       req.Title,
+		Description: req.Description,
+	}
+
+	module, err := ctrl.curriculumService.CreateModule(c.Request.Context(), payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    module,
+		"message": "Curriculum module created successfully",
+	})
+}
+
+func (ctrl *CurriculumController) UpdateModule(c *gin.Context) {
+	existingModule, ok := ctrl.findModule(c)
+	if !ok {
+		return
+	}
+
+	var req CurriculumModuleRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	payload := existingModule
+	payload.Lessons = nil
+	payload.Title = req.Title
+	payload.Description = req.Description
+
+	data, err := ctrl.curriculumService.UpdateModule(c.Request.Context(), payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Curriculum module updated successfully",
+	})
+}
+
+func (ctrl *CurriculumController) DeleteModule(c *gin.Context) {
+	existingModule, ok := ctrl.findModule(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.curriculumService.DeleteModule(c.Request.Context(), existingModule.ID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Curriculum module deleted successfully",
+		"data": gin.H{
+			"id":    existingModule.ID,
+			"title": existingModule.Title,
+		},
+	})
+}
+
+func (ctrl *CurriculumController) ReorderModules(c *gin.Context) {
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return
+	}
+
+	ids, ok := bindReorderRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.curriculumService.ReorderModules(c.Request.Context(), programID, ids); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more curriculum modules not found in this program",
+			})
+			return
+		}
+
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Curriculum modules reordered successfully",
+		"data": gin.H{
+			"ids": ids,
+		},
+	})
+}
+
+func (ctrl *CurriculumController) CreateLesson(c *gin.Context) {
+	module, ok := ctrl.findModule(c)
+	if !ok {
+		return
+	}
+
+	var req CurriculumLessonRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	payload := models.CurriculumLesson{
+		ModuleID:    module.ID,
+		Title:       req.Title,
+		Description: req.Description,
+		Duration:    req.Duration,
+	}
+
+	lesson, err := ctrl.curriculumService.CreateLesson(c.Request.Context(), payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    lesson,
+		"message": "Lesson created successfully",
+	})
+}
+
+// findLesson ambil :lessonId dari URL dan memastikan lesson milik module
+func (ctrl *CurriculumController) findLesson(c *gin.Context) (models.CurriculumLesson, bool) {
+	module, ok := ctrl.findModule(c)
+	if !ok {
+		return models.CurriculumLesson{}, false
+	}
+
+	lessonID, ok := parseUintParam(c, "lessonId")
+	if !ok {
+		return models.CurriculumLesson{}, false
+	}
+
+	lesson, err := ctrl.curriculumService.FindLessonByID(c.Request.Context(), module.ID, lessonID)
+	if err != nil {
+		respondError(c, err)
+		return models.CurriculumLesson{}, false
+	}
+
+	return lesson, true
+}
+
+func (ctrl *CurriculumController) UpdateLesson(c *gin.Context) {
+	existingLesson, ok := ctrl.findLesson(c)
+	if !ok {
+		return
+	}
+
+	var req CurriculumLessonRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	payload := existingLesson
+	payload.Title = req.Title
+	payload.Description = req.Description
+	payload.Duration = req.Duration
+
+	data, err := ctrl.curriculumService.UpdateLesson(c.Request.Context(), payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Lesson updated successfully",
+	})
+}
+
+func (ctrl *CurriculumController) DeleteLesson(c *gin.Context) {
+	existingLesson, ok := ctrl.findLesson(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.curriculumService.DeleteLesson(c.Request.Context(), existingLesson.ID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Lesson deleted successfully",
+		"data": gin.H{
+			"id":    existingLesson.ID,
+			"title": existingLesson.Title,
+		},
+	})
+}
+
+func (ctrl *CurriculumController) ReorderLessons(c *gin.Context) {
+	module, ok := ctrl.findModule(c)
+	if !ok {
+		return
+	}
+
+	ids, ok := bindReorderRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.curriculumService.ReorderLessons(c.Request.Context(), module.ID, ids); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more lessons not found in this module",
+			})
+			return
+		}
+
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Lessons reordered successfully",
+		"data": gin.H{
+			"ids": ids,
+		},
+	})
+}
@@ -16,13 +16,9 @@ func NewDashboardController(service services.DashboardService) *DashboardControl
 }
 
 func (c *DashboardController) GetDashboard(ctx *gin.Context) {
-	data, err := c.service.GetDashboardData()
+	data, err := c.service.GetDashboardData(ctx.Request.Context())
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Failed to fetch dashboard data",
-			"error":   err.Error(),
-		})
+		respondError(ctx, err)
 		return
 	}
 
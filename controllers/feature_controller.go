@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -8,59 +9,41 @@ import (
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/services"
 	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
 )
 
+// FeatureForm field form untuk membuat feature
+type FeatureForm struct {
+	Icon        string `form:"icon" binding:"required"`
+	Title       string `form:"title" binding:"required"`
+	Description string `form:"description" binding:"required"`
+	IsActive    string `form:"is_active" binding:"omitempty,boolean"`
+}
+
 type FeatureController struct {
-	featureService services.FeatureService
+	featureService     services.FeatureService
+	translationService services.TranslationService
 }
 
-func NewFeatureController(featureService services.FeatureService) *FeatureController {
+func NewFeatureController(featureService services.FeatureService, translationService services.TranslationService) *FeatureController {
 	return &FeatureController{
-		featureService: featureService,
+		featureService:     featureService,
+		translationService: translationService,
 	}
 }
 
 func (ctrl *FeatureController) Create(c *gin.Context) {
-	// Ambil field dari form
-	icon := c.PostForm("icon")
-	title := c.PostForm("title")
-	description := c.PostForm("description")
-	isActive := c.PostForm("is_active")
-
-	// Validasi field wajib
-	if icon == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Icon is required",
-		})
+	// Bind & validasi field form
+	var form FeatureForm
+	if !bindForm(c, &form) {
 		return
 	}
+	icon, title, description, isActive := form.Icon, form.Title, form.Description, form.IsActive
 
-	if title == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Title is required",
-		})
-		return
-	}
-
-	if description == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Description is required",
-		})
-		return
-	}
-
-	// Parse is_active (default true jika tidak ada)
+	// Parse is_active (default true jika tidak ada), format sudah divalidasi saat bind
 	isActiveBool := true
 	if isActive != "" {
-		var err error
-		isActiveBool, err = strconv.ParseBool(isActive)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "Invalid is_active format",
-				"error":   err.Error(),
-			})
-			return
-		}
+		isActiveBool, _ = strconv.ParseBool(isActive)
 	}
 
 	payload := models.Feature{
@@ -70,12 +53,9 @@ func (ctrl *FeatureController) Create(c *gin.Context) {
 		IsActive:    isActiveBool,
 	}
 
-	feature, err := ctrl.featureService.Create(payload)
+	feature, err := ctrl.featureService.Create(c.Request.Context(), payload)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to create feature",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -89,10 +69,7 @@ func (ctrl *FeatureController) FindAll(c *gin.Context) {
 	var params utils.PaginationParams
 
 	// Bind query parameters
-	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bindQuery(c, &params) {
 		return
 	}
 
@@ -104,14 +81,14 @@ func (ctrl *FeatureController) FindAll(c *gin.Context) {
 		params.Limit = 10
 	}
 
-	data, total, err := ctrl.featureService.FindAll(params)
+	data, total, err := ctrl.featureService.FindAll(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch features",
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatableFeature, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 		"pagination": gin.H{
@@ -123,14 +100,14 @@ func (ctrl *FeatureController) FindAll(c *gin.Context) {
 }
 
 func (ctrl *FeatureController) FindAllActive(c *gin.Context) {
-	data, err := ctrl.featureService.FindAllActive()
+	data, err := ctrl.featureService.FindAllActive(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch active features",
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizePublicResponse(c, ctrl.translationService, services.TranslatableFeature, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
@@ -140,22 +117,18 @@ func (ctrl *FeatureController) FindByID(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
-	data, err := ctrl.featureService.FindByID(uint(uint64Val))
+	data, err := ctrl.featureService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Feature not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatableFeature, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
@@ -166,20 +139,14 @@ func (ctrl *FeatureController) Update(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah feature exist
-	existingFeature, err := ctrl.featureService.FindByID(uint(uint64Val))
+	existingFeature, err := ctrl.featureService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Feature not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -205,10 +172,7 @@ func (ctrl *FeatureController) Update(c *gin.Context) {
 	if isActive != "" {
 		isActiveBool, err = strconv.ParseBool(isActive)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "Invalid is_active format",
-				"error":   err.Error(),
-			})
+			respondInvalidParam(c, "is_active", "boolean", "")
 			return
 		}
 	}
@@ -216,6 +180,7 @@ func (ctrl *FeatureController) Update(c *gin.Context) {
 	// 4. Buat payload untuk update
 	payload := models.Feature{
 		ID:          uint(uint64Val),
+		SortOrder:   existingFeature.SortOrder,
 		Icon:        icon,
 		Title:       title,
 		Description: description,
@@ -223,12 +188,9 @@ func (ctrl *FeatureController) Update(c *gin.Context) {
 	}
 
 	// 5. Update ke database
-	data, err := ctrl.featureService.Update(payload)
+	data, err := ctrl.featureService.Update(c.Request.Context(), payload)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to update feature",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -243,30 +205,21 @@ func (ctrl *FeatureController) Delete(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah feature exist
-	existingFeature, err := ctrl.featureService.FindByID(uint(uint64Val))
+	existingFeature, err := ctrl.featureService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Feature not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// 3. Soft delete feature (set is_deleted = true)
-	err = ctrl.featureService.Delete(uint(uint64Val))
+	err = ctrl.featureService.Delete(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to delete feature",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -277,4 +230,32 @@ func (ctrl *FeatureController) Delete(c *gin.Context) {
 			"title": existingFeature.Title,
 		},
 	})
-}
\ No newline at end of file
+}
+
+func (ctrl *FeatureController) Reorder(c *gin.Context) {
+	// 1. Bind daftar ID sesuai urutan baru
+	ids, ok := bindReorderRequest(c)
+	if !ok {
+		return
+	}
+
+	// 2. Simpan urutan baru (atomic, rollback jika ada ID tidak valid)
+	if err := ctrl.featureService.Reorder(c.Request.Context(), ids); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more features not found",
+			})
+			return
+		}
+
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Features reordered successfully",
+		"data": gin.H{
+			"ids": ids,
+		},
+	})
+}
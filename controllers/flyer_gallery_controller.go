@@ -1,10 +1,13 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -13,41 +16,62 @@ import (
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/services"
 	"github.com/tech-azim/be-learnova/utils"
+	"github.com/tech-azim/be-learnova/validation"
+	"gorm.io/gorm"
 )
 
+// FlyerGalleryForm field form untuk membuat flyer
+type FlyerGalleryForm struct {
+	Image       *multipart.FileHeader `form:"image" binding:"required"`
+	Title       string                `form:"title" binding:"required"`
+	Description string                `form:"description"`
+	IsActive    string                `form:"is_active" binding:"omitempty,boolean"`
+}
+
 type FlyerGalleryController struct {
 	flyerGalleryService services.FlyerGalleryService
+	translationService  services.TranslationService
 }
 
-func NewFlyerGalleryController(flyerGalleryService services.FlyerGalleryService) *FlyerGalleryController {
+func NewFlyerGalleryController(flyerGalleryService services.FlyerGalleryService, translationService services.TranslationService) *FlyerGalleryController {
 	return &FlyerGalleryController{
 		flyerGalleryService: flyerGalleryService,
+		translationService:  translationService,
 	}
 }
 
+// imageExtensions ekstensi file gambar yang boleh diupload
+var imageExtensions = []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
+
+// maxImageSize ukuran maksimal file gambar (5MB)
+const maxImageSize = int64(5 * 1024 * 1024)
+
 // validateImageFile validates file extension and size, returns ext and error message
 func validateImageFile(filename string, size int64) (string, string) {
-	allowedExtensions := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".gif":  true,
-		".webp": true,
-	}
-
 	ext := strings.ToLower(filepath.Ext(filename))
-	if !allowedExtensions[ext] {
+	if !slices.Contains(imageExtensions, ext) {
 		return "", "Invalid file type. Only jpg, jpeg, png, gif, webp allowed"
 	}
 
-	maxSize := int64(5 * 1024 * 1024) // 5MB
-	if size > maxSize {
+	if size > maxImageSize {
 		return "", "File size too large. Maximum 5MB allowed"
 	}
 
 	return ext, ""
 }
 
+// checkImageFile validasi tipe & ukuran file gambar di form, file nil (tidak diupload) dilewati
+func checkImageFile(errs *validation.Errors, field string, file *multipart.FileHeader) {
+	if file == nil {
+		return
+	}
+	if !slices.Contains(imageExtensions, strings.ToLower(filepath.Ext(file.Filename))) {
+		errs.Add(field, "file_type", strings.Join(imageExtensions, ", "))
+	} else if file.Size > maxImageSize {
+		errs.Add(field, "file_size", "5MB")
+	}
+}
+
 // saveUploadedFile saves the uploaded file to uploads/ and returns the file path
 func saveUploadedFile(c *gin.Context, filename string, ext string) (string, error) {
 	uploadPath := "uploads/"
@@ -70,68 +94,39 @@ func saveUploadedFile(c *gin.Context, filename string, ext string) (string, erro
 }
 
 func (ctrl *FlyerGalleryController) Create(c *gin.Context) {
-	// 1. Ambil file
-	file, err := c.FormFile("image")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "File is required",
-			"error":   err.Error(),
-		})
-		return
-	}
-
-	// 2. Validasi tipe & ukuran file
-	ext, errMsg := validateImageFile(file.Filename, file.Size)
-	if errMsg != "" {
-		c.JSON(http.StatusBadRequest, gin.H{"message": errMsg})
+	// 1. Bind & validasi form, termasuk tipe & ukuran file
+	var form FlyerGalleryForm
+	if !bindForm(c, &form, func(errs *validation.Errors) {
+		checkImageFile(errs, "image", form.Image)
+	}) {
 		return
 	}
+	file := form.Image
+	ext := strings.ToLower(filepath.Ext(file.Filename))
 
 	// 3. Generate path file
 	filePath, err := saveUploadedFile(c, file.Filename, ext)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// 4. Simpan file
-	if err := c.SaveUploadedFile(file, filePath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to save file",
-			"error":   err.Error(),
-		})
+	if err := storeUpload(c, file, filePath); err != nil {
+		respondError(c, err)
 		return
 	}
 
 	// 5. Ambil field dari form
-	title := c.PostForm("title")
-	description := c.PostForm("description")
-	isActive := c.PostForm("is_active")
+	title, description, isActive := form.Title, form.Description, form.IsActive
 
-	// 6. Validasi field wajib
-	if title == "" {
-		os.Remove(filePath)
-		c.JSON(http.StatusBadRequest, gin.H{"message": "Title is required"})
-		return
-	}
-
-	// 7. Parse is_active (default true)
+	// 6. Parse is_active (default true), format sudah divalidasi saat bind
 	isActiveBool := true
 	if isActive != "" {
-		isActiveBool, err = strconv.ParseBool(isActive)
-		if err != nil {
-			os.Remove(filePath)
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "Invalid is_active format",
-				"error":   err.Error(),
-			})
-			return
-		}
+		isActiveBool, _ = strconv.ParseBool(isActive)
 	}
 
-	// 8. Buat payload & simpan ke database
+	// 7. Buat payload & simpan ke database
 	payload := models.FlyerGallery{
 		Title:       title,
 		Image:       filePath,
@@ -139,13 +134,10 @@ func (ctrl *FlyerGalleryController) Create(c *gin.Context) {
 		IsActive:    isActiveBool,
 	}
 
-	flyerGallery, err := ctrl.flyerGalleryService.Create(payload)
+	flyerGallery, err := ctrl.flyerGalleryService.Create(c.Request.Context(), payload)
 	if err != nil {
-		os.Remove(filePath)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to create flyer gallery",
-			"error":   err.Error(),
-		})
+		removeFile(c, filePath)
+		respondError(c, err)
 		return
 	}
 
@@ -158,8 +150,7 @@ func (ctrl *FlyerGalleryController) Create(c *gin.Context) {
 func (ctrl *FlyerGalleryController) FindAll(c *gin.Context) {
 	var params utils.PaginationParams
 
-	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindQuery(c, &params) {
 		return
 	}
 
@@ -170,12 +161,14 @@ func (ctrl *FlyerGalleryController) FindAll(c *gin.Context) {
 		params.Limit = 10
 	}
 
-	data, total, err := ctrl.flyerGalleryService.FindAll(params)
+	data, total, err := ctrl.flyerGalleryService.FindAll(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch flyer galleries"})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatableFlyerGallery, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 		"pagination": gin.H{
@@ -187,12 +180,14 @@ func (ctrl *FlyerGalleryController) FindAll(c *gin.Context) {
 }
 
 func (ctrl *FlyerGalleryController) FindAllActive(c *gin.Context) {
-	data, err := ctrl.flyerGalleryService.FindAllActive()
+	data, err := ctrl.flyerGalleryService.FindAllActive(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch active flyer galleries"})
+		respondError(c, err)
 		return
 	}
 
+	localizePublicResponse(c, ctrl.translationService, services.TranslatableFlyerGallery, &data)
+
 	c.JSON(http.StatusOK, gin.H{"data": data})
 }
 
@@ -200,22 +195,18 @@ func (ctrl *FlyerGalleryController) FindByID(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
-	data, err := ctrl.flyerGalleryService.FindByID(uint(uint64Val))
+	data, err := ctrl.flyerGalleryService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Flyer gallery not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatableFlyerGallery, &data)
+
 	c.JSON(http.StatusOK, gin.H{"data": data})
 }
 
@@ -224,20 +215,14 @@ func (ctrl *FlyerGalleryController) Update(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah flyer gallery exist
-	existingFlyerGallery, err := ctrl.flyerGalleryService.FindByID(uint(uint64Val))
+	existingFlyerGallery, err := ctrl.flyerGalleryService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Flyer gallery not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -258,22 +243,19 @@ func (ctrl *FlyerGalleryController) Update(c *gin.Context) {
 		// Generate path file baru
 		newFilePath, err := saveUploadedFile(c, file.Filename, ext)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			respondError(c, err)
 			return
 		}
 
 		// Simpan file baru
-		if err := c.SaveUploadedFile(file, newFilePath); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"message": "Failed to save file",
-				"error":   err.Error(),
-			})
+		if err := storeUpload(c, file, newFilePath); err != nil {
+			respondError(c, err)
 			return
 		}
 
 		filePath = newFilePath
 		newFileUploaded = true
-		fmt.Printf("New file uploaded: %s\n", filePath)
+		requestLogger(c).Info("file uploaded", "path", filePath)
 	}
 
 	// 4. Ambil field dari form, gunakan nilai lama jika kosong
@@ -294,12 +276,9 @@ func (ctrl *FlyerGalleryController) Update(c *gin.Context) {
 		isActiveBool, err = strconv.ParseBool(isActive)
 		if err != nil {
 			if newFileUploaded {
-				os.Remove(filePath)
+				removeFile(c, filePath)
 			}
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "Invalid is_active format",
-				"error":   err.Error(),
-			})
+			respondInvalidParam(c, "is_active", "boolean", "")
 			return
 		}
 	}
@@ -307,35 +286,33 @@ func (ctrl *FlyerGalleryController) Update(c *gin.Context) {
 	// 6. Buat payload & update ke database
 	payload := models.FlyerGallery{
 		ID:          uint(uint64Val),
+		SortOrder:   existingFlyerGallery.SortOrder,
 		Title:       title,
 		Image:       filePath,
 		Description: description,
 		IsActive:    isActiveBool,
 	}
 
-	data, err := ctrl.flyerGalleryService.Update(payload)
+	data, err := ctrl.flyerGalleryService.Update(c.Request.Context(), payload)
 	if err != nil {
 		// Rollback: hapus file baru jika gagal update database
 		if newFileUploaded && filePath != oldFilePath {
-			if removeErr := os.Remove(filePath); removeErr != nil {
-				fmt.Printf("Failed to rollback new file %s: %v\n", filePath, removeErr)
+			if removeErr := removeFile(c, filePath); removeErr != nil {
+				requestLogger(c).Error("failed to roll back uploaded file", "path", filePath, "error", removeErr)
 			} else {
-				fmt.Printf("Rolled back: Deleted new file %s due to database error\n", filePath)
+				requestLogger(c).Info("rolled back uploaded file", "path", filePath)
 			}
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to update flyer gallery",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// 7. Hapus file lama jika berhasil upload file baru
 	if newFileUploaded && oldFilePath != "" && oldFilePath != filePath {
-		if err := os.Remove(oldFilePath); err != nil {
-			fmt.Printf("Warning: Failed to delete old file %s: %v\n", oldFilePath, err)
+		if err := removeFile(c, oldFilePath); err != nil {
+			requestLogger(c).Warn("failed to delete old file", "path", oldFilePath, "error", err)
 		} else {
-			fmt.Printf("Successfully deleted old file: %s\n", oldFilePath)
+			requestLogger(c).Info("deleted old file", "path", oldFilePath)
 		}
 	}
 
@@ -350,38 +327,29 @@ func (ctrl *FlyerGalleryController) Delete(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah flyer gallery exist
-	existingFlyerGallery, err := ctrl.flyerGalleryService.FindByID(uint(uint64Val))
+	existingFlyerGallery, err := ctrl.flyerGalleryService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Flyer gallery not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// 3. Delete dari database
-	if err = ctrl.flyerGalleryService.Delete(uint(uint64Val)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to delete flyer gallery",
-			"error":   err.Error(),
-		})
+	if err = ctrl.flyerGalleryService.Delete(c.Request.Context(), uint(uint64Val)); err != nil {
+		respondError(c, err)
 		return
 	}
 
 	// 4. Hapus file fisik
 	if existingFlyerGallery.Image != "" {
-		if err := os.Remove(existingFlyerGallery.Image); err != nil {
-			fmt.Printf("Warning: Failed to delete file %s: %v\n", existingFlyerGallery.Image, err)
+		if err := removeFile(c, existingFlyerGallery.Image); err != nil {
+			requestLogger(c).Warn("failed to delete file", "path", existingFlyerGallery.Image, "error", err)
 		} else {
-			fmt.Printf("Successfully deleted file: %s\n", existingFlyerGallery.Image)
+			requestLogger(c).Info("deleted file", "path", existingFlyerGallery.Image)
 		}
 	}
 
@@ -393,3 +361,31 @@ func (ctrl *FlyerGalleryController) Delete(c *gin.Context) {
 		},
 	})
 }
+
+func (ctrl *FlyerGalleryController) Reorder(c *gin.Context) {
+	// 1. Bind daftar ID sesuai urutan baru
+	ids, ok := bindReorderRequest(c)
+	if !ok {
+		return
+	}
+
+	// 2. Simpan urutan baru (atomic, rollback jika ada ID tidak valid)
+	if err := ctrl.flyerGalleryService.Reorder(c.Request.Context(), ids); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more flyer galleries not found",
+			})
+			return
+		}
+
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Flyer galleries reordered successfully",
+		"data": gin.H{
+			"ids": ids,
+		},
+	})
+}
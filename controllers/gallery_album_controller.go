@@ -0,0 +1,502 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/services"
+	"github.com/tech-azim/be-learnova/utils"
+	"github.com/tech-azim/be-learnova/validation"
+	"gorm.io/gorm"
+)
+
+// maxAlbumFiles batas jumlah file dalam satu request upload album
+const maxAlbumFiles = 50
+
+// MaxAlbumUploadSize batas body request upload album: maxAlbumFiles gambar @5MB ditambah field form
+const MaxAlbumUploadSize = maxAlbumFiles*(5<<20) + 1<<20
+
+// GalleryAlbumForm field form untuk membuat album, gambar album dikirim lewat field "files"
+type GalleryAlbumForm struct {
+	Title       string                  `form:"title" binding:"required"`
+	Description string                  `form:"description"`
+	EventDate   string                  `form:"event_date" binding:"required,datetime=2006-01-02"`
+	IsActive    string                  `form:"is_active" binding:"omitempty,boolean"`
+	ProgramID   string                  `form:"program_id" binding:"omitempty,number"`
+	Cover       *multipart.FileHeader   `form:"cover"`
+	Files       []*multipart.FileHeader `form:"files"`
+}
+
+type GalleryAlbumController struct {
+	galleryAlbumService services.GalleryAlbumService
+	programService      services.ProgramService
+	translationService  services.TranslationService
+}
+
+func NewGalleryAlbumController(galleryAlbumService services.GalleryAlbumService, programService services.ProgramService, translationService services.TranslationService) *GalleryAlbumController {
+	return &GalleryAlbumController{
+		galleryAlbumService: galleryAlbumService,
+		programService:      programService,
+		translationService:  translationService,
+	}
+}
+
+// removeFiles menghapus file yang sudah tersimpan (rollback upload)
+func removeFiles(c *gin.Context, paths []string) {
+	for _, path := range paths {
+		if err := removeFile(c, path); err != nil {
+			requestLogger(c).Warn("failed to delete file", "path", path, "error", err)
+		}
+	}
+}
+
+// saveAlbumImages validasi semua file terlebih dahulu, lalu menyimpan semuanya.
+// Jika salah satu gagal disimpan, file yang sudah tersimpan dihapus kembali.
+// Return status code & pesan error (0 jika sukses)
+func saveAlbumImages(c *gin.Context, files []*multipart.FileHeader, album models.GalleryAlbum) ([]models.Gallery, []string, int, gin.H) {
+	if len(files) > maxAlbumFiles {
+		return nil, nil, http.StatusBadRequest, gin.H{
+			"message": fmt.Sprintf("Too many files. Maximum %d files per upload", maxAlbumFiles),
+		}
+	}
+
+	exts := make([]string, len(files))
+	for i, file := range files {
+		ext, errMsg := validateImageFile(file.Filename, file.Size)
+		if errMsg != "" {
+			return nil, nil, http.StatusBadRequest, gin.H{
+				"message": errMsg,
+				"file":    file.Filename,
+			}
+		}
+		exts[i] = ext
+	}
+
+	images := make([]models.Gallery, 0, len(files))
+	saved := make([]string, 0, len(files))
+	for i, file := range files {
+		filePath, err := saveUploadedFile(c, file.Filename, exts[i])
+		if err == nil {
+			err = storeUpload(c, file, filePath)
+		}
+		if err != nil {
+			removeFiles(c, saved)
+			requestLogger(c).Error("failed to save album image", "file", file.Filename, "error", err)
+			return nil, nil, http.StatusInternalServerError, gin.H{
+				"message": "Failed to save file",
+				"file":    file.Filename,
+			}
+		}
+		saved = append(saved, filePath)
+
+		images = append(images, models.Gallery{
+			Title:       album.Title,
+			Description: album.Description,
+			URL:         filePath,
+			Date:        album.EventDate,
+			IsActive:    true,
+		})
+	}
+
+	return images, saved, 0, nil
+}
+
+// parseAlbumProgramID parse program_id opsional dan memastikan program ada
+func (ctrl *GalleryAlbumController) parseAlbumProgramID(c *gin.Context, value string) (*uint, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	uint64Val, err := strconv.ParseUint(value, 10, 0)
+	if err != nil {
+		respondInvalidParam(c, "program_id", "number", "")
+		return nil, false
+	}
+
+	if _, err := ctrl.programService.FindByID(c.Request.Context(), uint(uint64Val)); err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+
+	programID := uint(uint64Val)
+	return &programID, true
+}
+
+func (ctrl *GalleryAlbumController) Create(c *gin.Context) {
+	// 1-2. Bind & validasi form, termasuk jumlah, tipe & ukuran file
+	var form GalleryAlbumForm
+	if !bindForm(c, &form, func(errs *validation.Errors) {
+		checkImageFile(errs, "cover", form.Cover)
+		if len(form.Files) > maxAlbumFiles {
+			errs.Add("files", "max_items", strconv.Itoa(maxAlbumFiles))
+		}
+		for i, file := range form.Files {
+			checkImageFile(errs, fmt.Sprintf("files[%d]", i), file)
+		}
+	}) {
+		return
+	}
+	title, description := form.Title, form.Description
+	eventDateTime, _ := time.Parse(time.DateOnly, form.EventDate)
+	isActiveBool := true
+	if form.IsActive != "" {
+		isActiveBool, _ = strconv.ParseBool(form.IsActive)
+	}
+
+	// 3. Validasi program (opsional)
+	programID, ok := ctrl.parseAlbumProgramID(c, form.ProgramID)
+	if !ok {
+		return
+	}
+
+	album := models.GalleryAlbum{
+		Title:       title,
+		Description: description,
+		EventDate:   eventDateTime,
+		ProgramID:   programID,
+		IsActive:    isActiveBool,
+	}
+
+	// 4. Simpan cover (opsional)
+	var saved []string
+	if coverFile := form.Cover; coverFile != nil {
+		ext := strings.ToLower(filepath.Ext(coverFile.Filename))
+		coverPath, err := saveUploadedFile(c, coverFile.Filename, ext)
+		if err == nil {
+			err = storeUpload(c, coverFile, coverPath)
+		}
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		album.Cover = coverPath
+		saved = append(saved, coverPath)
+	}
+
+	// 5. Simpan semua gambar album (field "files", boleh lebih dari satu)
+	var images []models.Gallery
+	if len(form.Files) > 0 {
+		var imagePaths []string
+		var status int
+		var errBody gin.H
+
+		images, imagePaths, status, errBody = saveAlbumImages(c, form.Files, album)
+		if status != 0 {
+			removeFiles(c, saved)
+			c.JSON(status, errBody)
+			return
+		}
+		saved = append(saved, imagePaths...)
+	}
+
+	// 6. Simpan album + gambar dalam satu transaksi
+	data, err := ctrl.galleryAlbumService.Create(c.Request.Context(), album, images)
+	if err != nil {
+		removeFiles(c, saved)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    data,
+		"message": "Gallery album created successfully",
+	})
+}
+
+func (ctrl *GalleryAlbumController) UploadImages(c *gin.Context) {
+	// 1. Ambil ID dari URL parameter
+	id := c.Param("id")
+	uint64Val, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		respondInvalidParam(c, "id", "number", "")
+		return
+	}
+
+	// 2. Cek apakah album exist
+	album, err := ctrl.galleryAlbumService.FindByID(c.Request.Context(), uint(uint64Val))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// 3. Ambil semua file
+	form, err := c.MultipartForm()
+	if err != nil || len(form.File["files"]) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "At least one file is required",
+		})
+		return
+	}
+
+	// 4. Validasi & simpan file
+	images, saved, status, errBody := saveAlbumImages(c, form.File["files"], album)
+	if status != 0 {
+		c.JSON(status, errBody)
+		return
+	}
+
+	// 5. Simpan ke database dalam satu transaksi
+	data, err := ctrl.galleryAlbumService.AddImages(c.Request.Context(), album.ID, images)
+	if err != nil {
+		removeFiles(c, saved)
+		respondError(c, err)
+		return
+	}
+
+	// 6. Album tanpa cover memakai gambar pertama
+	if album.Cover == "" && len(data) > 0 {
+		album.Cover = data[0].URL
+		album.Images = nil
+		if _, err := ctrl.galleryAlbumService.Update(c.Request.Context(), album); err != nil {
+			requestLogger(c).Warn("failed to set album cover", "album_id", album.ID, "error", err)
+		}
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    data,
+		"message": fmt.Sprintf("%d images uploaded successfully", len(data)),
+	})
+}
+
+func (ctrl *GalleryAlbumController) FindAll(c *gin.Context) {
+	var params utils.PaginationParams
+
+	if !bindQuery(c, &params) {
+		return
+	}
+
+	if params.Page == 0 {
+		params.Page = 1
+	}
+	if params.Limit == 0 {
+		params.Limit = 10
+	}
+
+	data, total, err := ctrl.galleryAlbumService.FindAll(c.Request.Context(), params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	localizeResponse(c, ctrl.translationService, services.TranslatableGalleryAlbum, &data)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"pagination": gin.H{
+			"page":  params.Page,
+			"limit": params.Limit,
+			"total": total,
+		},
+	})
+}
+
+func (ctrl *GalleryAlbumController) FindAllActive(c *gin.Context) {
+	params := utils.GetPaginationParams(c)
+
+	data, total, err := ctrl.galleryAlbumService.FindAllActive(c.Request.Context(), params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	localizePublicResponse(c, ctrl.translationService, services.TranslatableGalleryAlbum, &data)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"pagination": gin.H{
+			"page":  params.Page,
+			"limit": params.Limit,
+			"total": total,
+		},
+	})
+}
+
+func (ctrl *GalleryAlbumController) FindByID(c *gin.Context) {
+	id := c.Param("id")
+	uint64Val, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		respondInvalidParam(c, "id", "number", "")
+		return
+	}
+
+	data, err := ctrl.galleryAlbumService.FindActiveByID(c.Request.Context(), uint(uint64Val))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	localizePublicResponse(c, ctrl.translationService, services.TranslatableGalleryAlbum, &data)
+	localizePublicResponse(c, ctrl.translationService, services.TranslatableGallery, &data.Images)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (ctrl *GalleryAlbumController) Update(c *gin.Context) {
+	// 1. Ambil ID dari URL parameter
+	id := c.Param("id")
+	uint64Val, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		respondInvalidParam(c, "id", "number", "")
+		return
+	}
+
+	// 2. Cek apakah album exist
+	existingAlbum, err := ctrl.galleryAlbumService.FindByID(c.Request.Context(), uint(uint64Val))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// 3. Ambil field dari form, gunakan nilai lama jika kosong
+	payload := existingAlbum
+	payload.Images = nil
+	payload.Program = nil
+
+	if title := c.PostForm("title"); title != "" {
+		payload.Title = title
+	}
+	if description := c.PostForm("description"); description != "" {
+		payload.Description = description
+	}
+	if eventDate := c.PostForm("event_date"); eventDate != "" {
+		payload.EventDate, err = time.Parse("2006-01-02", eventDate)
+		if err != nil {
+			respondInvalidParam(c, "date", "datetime", "2006-01-02")
+			return
+		}
+	}
+	if isActive := c.PostForm("is_active"); isActive != "" {
+		payload.IsActive, err = strconv.ParseBool(isActive)
+		if err != nil {
+			respondInvalidParam(c, "is_active", "boolean", "")
+			return
+		}
+	}
+	if programID, exists := c.GetPostForm("program_id"); exists {
+		parsed, ok := ctrl.parseAlbumProgramID(c, programID)
+		if !ok {
+			return
+		}
+		payload.ProgramID = parsed
+	}
+
+	// 4. Handle cover baru (opsional)
+	newCover := ""
+	if coverFile, err := c.FormFile("cover"); err == nil {
+		ext, errMsg := validateImageFile(coverFile.Filename, coverFile.Size)
+		if errMsg != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"message": errMsg})
+			return
+		}
+
+		newCover, err = saveUploadedFile(c, coverFile.Filename, ext)
+		if err == nil {
+			err = storeUpload(c, coverFile, newCover)
+		}
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		payload.Cover = newCover
+	}
+
+	// 5. Update ke database
+	data, err := ctrl.galleryAlbumService.Update(c.Request.Context(), payload)
+	if err != nil {
+		if newCover != "" {
+			removeFiles(c, []string{newCover})
+		}
+		respondError(c, err)
+		return
+	}
+
+	// 6. Hapus cover lama jika bukan salah satu gambar album
+	if newCover != "" && existingAlbum.Cover != "" && !albumHasImage(existingAlbum, existingAlbum.Cover) {
+		removeFiles(c, []string{existingAlbum.Cover})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Gallery album updated successfully",
+	})
+}
+
+// albumHasImage cek apakah path dipakai oleh gambar di dalam album
+func albumHasImage(album models.GalleryAlbum, path string) bool {
+	for _, image := range album.Images {
+		if image.URL == path {
+			return true
+		}
+	}
+	return false
+}
+
+func (ctrl *GalleryAlbumController) Delete(c *gin.Context) {
+	// 1. Ambil ID dari URL parameter
+	id := c.Param("id")
+	uint64Val, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		respondInvalidParam(c, "id", "number", "")
+		return
+	}
+
+	// 2. Cek apakah album exist
+	existingAlbum, err := ctrl.galleryAlbumService.FindByID(c.Request.Context(), uint(uint64Val))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// 3. Soft delete album beserta gambarnya
+	if err := ctrl.galleryAlbumService.Delete(c.Request.Context(), uint(uint64Val)); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Gallery album deleted successfully",
+		"data": gin.H{
+			"id":    existingAlbum.ID,
+			"title": existingAlbum.Title,
+		},
+	})
+}
+
+func (ctrl *GalleryAlbumController) Reorder(c *gin.Context) {
+	// 1. Bind daftar ID sesuai urutan baru
+	ids, ok := bindReorderRequest(c)
+	if !ok {
+		return
+	}
+
+	// 2. Simpan urutan baru (atomic, rollback jika ada ID tidak valid)
+	if err := ctrl.galleryAlbumService.Reorder(c.Request.Context(), ids); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more gallery albums not found",
+			})
+			return
+		}
+
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Gallery albums reordered successfully",
+		"data": gin.H{
+			"ids": ids,
+		},
+	})
+}
@@ -1,7 +1,9 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -13,62 +15,46 @@ import (
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/services"
 	"github.com/tech-azim/be-learnova/utils"
+	"github.com/tech-azim/be-learnova/validation"
+	"gorm.io/gorm"
 )
 
+// GalleryForm field form untuk membuat gallery, slug & SEO dibaca bindSEOForm
+type GalleryForm struct {
+	File        *multipart.FileHeader `form:"file" binding:"required"`
+	Title       string                `form:"title" binding:"required"`
+	Description string                `form:"description"`
+	Date        string                `form:"date" binding:"required,datetime=2006-01-02"`
+	IsActive    string                `form:"is_active" binding:"omitempty,boolean"`
+}
+
 type GalleryController struct {
-	galleryService services.GalleryService
+	galleryService     services.GalleryService
+	translationService services.TranslationService
 }
 
-func NewGalleryController(galleryService services.GalleryService) *GalleryController {
+func NewGalleryController(galleryService services.GalleryService, translationService services.TranslationService) *GalleryController {
 	return &GalleryController{
-		galleryService: galleryService,
+		galleryService:     galleryService,
+		translationService: translationService,
 	}
 }
 
 func (ctrl *GalleryController) Create(c *gin.Context) {
-	// 1. Ambil file
-	file, err := c.FormFile("file")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "File is required",
-			"error":   err.Error(),
-		})
+	// 1-3. Bind & validasi form, termasuk tipe & ukuran file
+	var form GalleryForm
+	if !bindForm(c, &form, func(errs *validation.Errors) {
+		checkImageFile(errs, "file", form.File)
+	}) {
 		return
 	}
-
-	// 2. Validasi tipe file
-	allowedExtensions := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".gif":  true,
-		".webp": true,
-	}
-
+	file := form.File
 	ext := strings.ToLower(filepath.Ext(file.Filename))
-	if !allowedExtensions[ext] {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid file type. Only jpg, jpeg, png, gif, webp allowed",
-		})
-		return
-	}
-
-	// 3. Validasi ukuran file (max 5MB)
-	maxSize := int64(5 * 1024 * 1024) // 5MB
-	if file.Size > maxSize {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "File size too large. Maximum 5MB allowed",
-		})
-		return
-	}
 
 	// 4. Buat folder upload kalau belum ada
 	uploadPath := "uploads/"
 	if err := os.MkdirAll(uploadPath, os.ModePerm); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to create upload directory",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -85,62 +71,26 @@ func (ctrl *GalleryController) Create(c *gin.Context) {
 	}
 
 	// 7. Simpan file
-	if err := c.SaveUploadedFile(file, filePath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to save file",
-			"error":   err.Error(),
-		})
-		return
-	}
-
-	// 8. Ambil field dari form
-	title := c.PostForm("title")
-	description := c.PostForm("description")
-	date := c.PostForm("date")
-	isActive := c.PostForm("is_active")
-
-	// 9. Validasi field wajib
-	if title == "" {
-		os.Remove(filePath)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Title is required",
-		})
+	if err := storeUpload(c, file, filePath); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	if date == "" {
-		os.Remove(filePath)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Date is required",
-		})
-		return
+	// 8-11. Ambil field dari form, format date & is_active sudah divalidasi saat bind
+	title, description := form.Title, form.Description
+	dateTime, _ := time.Parse(time.DateOnly, form.Date)
+	isActiveBool := true
+	if form.IsActive != "" {
+		isActiveBool, _ = strconv.ParseBool(form.IsActive)
 	}
 
-	// 10. Parse date
-	dateTime, err := time.Parse("2006-01-02", date)
-	if err != nil {
-		os.Remove(filePath)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid date format. Use YYYY-MM-DD",
-			"error":   err.Error(),
-		})
+	// Slug & SEO metadata (optional)
+	slug, seo, ogImagePath, ok := bindSEOForm(c, "", models.SEO{})
+	if !ok {
+		removeFile(c, filePath)
 		return
 	}
 
-	// 11. Parse is_active (default true)
-	isActiveBool := true
-	if isActive != "" {
-		isActiveBool, err = strconv.ParseBool(isActive)
-		if err != nil {
-			os.Remove(filePath)
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "Invalid is_active format",
-				"error":   err.Error(),
-			})
-			return
-		}
-	}
-
 	// 12. Buat payload
 	payload := models.Gallery{
 		Title:       title,
@@ -148,16 +98,19 @@ func (ctrl *GalleryController) Create(c *gin.Context) {
 		URL:         filePath, // Gunakan filePath sebagai URL
 		Date:        dateTime,
 		IsActive:    isActiveBool,
+		Slug:        slug,
+		SEO:         seo,
 	}
 
 	// 13. Simpan ke database
-	gallery, err := ctrl.galleryService.Create(payload)
+	gallery, err := ctrl.galleryService.Create(c.Request.Context(), payload)
 	if err != nil {
-		os.Remove(filePath)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to create gallery",
-			"error":   err.Error(),
-		})
+		removeFile(c, filePath)
+		removeSEOImage(c, ogImagePath)
+		if respondSlugError(c, err) {
+			return
+		}
+		respondError(c, err)
 		return
 	}
 
@@ -170,10 +123,7 @@ func (ctrl *GalleryController) Create(c *gin.Context) {
 func (ctrl *GalleryController) FindAll(c *gin.Context) {
 	var params utils.PaginationParams
 
-	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bindQuery(c, &params) {
 		return
 	}
 
@@ -184,14 +134,14 @@ func (ctrl *GalleryController) FindAll(c *gin.Context) {
 		params.Limit = 10
 	}
 
-	data, total, err := ctrl.galleryService.FindAll(params)
+	data, total, err := ctrl.galleryService.FindAll(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch galleries",
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatableGallery, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 		"pagination": gin.H{
@@ -203,14 +153,14 @@ func (ctrl *GalleryController) FindAll(c *gin.Context) {
 }
 
 func (ctrl *GalleryController) FindAllActive(c *gin.Context) {
-	data, err := ctrl.galleryService.FindAllActive()
+	data, err := ctrl.galleryService.FindAllActive(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch active galleries",
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizePublicResponse(c, ctrl.translationService, services.TranslatableGallery, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
@@ -220,22 +170,44 @@ func (ctrl *GalleryController) FindByID(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
+		return
+	}
+
+	data, err := ctrl.galleryService.FindByID(c.Request.Context(), uint(uint64Val))
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
-	data, err := ctrl.galleryService.FindByID(uint(uint64Val))
+	localizeResponse(c, ctrl.translationService, services.TranslatableGallery, &data)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+// FindBySlug detail gallery aktif untuk halaman publik, slug lama diarahkan (301) ke slug terbaru
+func (ctrl *GalleryController) FindBySlug(c *gin.Context) {
+	data, redirected, err := ctrl.galleryService.FindBySlug(c.Request.Context(), c.Param("slug"))
 	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if !data.IsActive {
 		c.JSON(http.StatusNotFound, gin.H{
 			"message": "Gallery not found",
-			"error":   err.Error(),
 		})
 		return
 	}
 
+	if redirected {
+		respondSlugRedirect(c, "/api/v1/galleries/slug/", data.Slug)
+		return
+	}
+
+	localizePublicResponse(c, ctrl.translationService, services.TranslatableGallery, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
@@ -246,20 +218,14 @@ func (ctrl *GalleryController) Update(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah gallery exist
-	existingGallery, err := ctrl.galleryService.FindByID(uint(uint64Val))
+	existingGallery, err := ctrl.galleryService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Gallery not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -298,10 +264,7 @@ func (ctrl *GalleryController) Update(c *gin.Context) {
 
 		uploadPath := "uploads/"
 		if err := os.MkdirAll(uploadPath, os.ModePerm); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"message": "Failed to create upload directory",
-				"error":   err.Error(),
-			})
+			respondError(c, err)
 			return
 		}
 
@@ -318,16 +281,13 @@ func (ctrl *GalleryController) Update(c *gin.Context) {
 		}
 
 		// Simpan file baru
-		if err := c.SaveUploadedFile(file, filePath); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"message": "Failed to save file",
-				"error":   err.Error(),
-			})
+		if err := storeUpload(c, file, filePath); err != nil {
+			respondError(c, err)
 			return
 		}
 
 		newFileUploaded = true
-		fmt.Printf("New file uploaded: %s\n", filePath)
+		requestLogger(c).Info("file uploaded", "path", filePath)
 	}
 
 	// 4. Ambil field dari form
@@ -351,12 +311,9 @@ func (ctrl *GalleryController) Update(c *gin.Context) {
 		if err != nil {
 			// Rollback file baru jika ada
 			if newFileUploaded {
-				os.Remove(filePath)
+				removeFile(c, filePath)
 			}
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "Invalid date format. Use YYYY-MM-DD",
-				"error":   err.Error(),
-			})
+			respondInvalidParam(c, "date", "datetime", "2006-01-02")
 			return
 		}
 	}
@@ -368,51 +325,65 @@ func (ctrl *GalleryController) Update(c *gin.Context) {
 		if err != nil {
 			// Rollback file baru jika ada
 			if newFileUploaded {
-				os.Remove(filePath)
+				removeFile(c, filePath)
 			}
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "Invalid is_active format",
-				"error":   err.Error(),
-			})
+			respondInvalidParam(c, "is_active", "boolean", "")
 			return
 		}
 	}
 
+	slug, seo, ogImagePath, ok := bindSEOForm(c, existingGallery.Slug, existingGallery.SEO)
+	if !ok {
+		if newFileUploaded {
+			removeFile(c, filePath)
+		}
+		return
+	}
+
 	// 5. Buat payload
 	payload := models.Gallery{
 		ID:          uint(uint64Val),
+		AlbumID:     existingGallery.AlbumID,
+		SortOrder:   existingGallery.SortOrder,
 		Title:       title,
 		Description: description,
 		URL:         filePath,
 		Date:        dateTime,
 		IsActive:    isActiveBool,
+		Slug:        slug,
+		SEO:         seo,
 	}
 
 	// 6. Update ke database
-	data, err := ctrl.galleryService.Update(payload)
+	data, err := ctrl.galleryService.Update(c.Request.Context(), payload)
 	if err != nil {
 		// Rollback: hapus file baru jika gagal update database
 		if newFileUploaded && filePath != oldFilePath {
-			if removeErr := os.Remove(filePath); removeErr != nil {
-				fmt.Printf("Failed to rollback new file %s: %v\n", filePath, removeErr)
+			if removeErr := removeFile(c, filePath); removeErr != nil {
+				requestLogger(c).Error("failed to roll back uploaded file", "path", filePath, "error", removeErr)
 			} else {
-				fmt.Printf("Rolled back: Deleted new file %s due to database error\n", filePath)
+				requestLogger(c).Info("rolled back uploaded file", "path", filePath)
 			}
 		}
+		removeSEOImage(c, ogImagePath)
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to update gallery",
-			"error":   err.Error(),
-		})
+		if respondSlugError(c, err) {
+			return
+		}
+
+		respondError(c, err)
 		return
 	}
 
 	// 7. Hapus file lama jika ada file baru
+	if ogImagePath != "" {
+		removeSEOImage(c, existingGallery.SEO.OGImage)
+	}
 	if newFileUploaded && oldFilePath != "" && oldFilePath != filePath {
-		if err := os.Remove(oldFilePath); err != nil {
-			fmt.Printf("Warning: Failed to delete old file %s: %v\n", oldFilePath, err)
+		if err := removeFile(c, oldFilePath); err != nil {
+			requestLogger(c).Warn("failed to delete old file", "path", oldFilePath, "error", err)
 		} else {
-			fmt.Printf("Successfully deleted old file: %s\n", oldFilePath)
+			requestLogger(c).Info("deleted old file", "path", oldFilePath)
 		}
 	}
 
@@ -427,39 +398,30 @@ func (ctrl *GalleryController) Delete(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah gallery exist
-	existingGallery, err := ctrl.galleryService.FindByID(uint(uint64Val))
+	existingGallery, err := ctrl.galleryService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Gallery not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// 3. Delete dari database
-	err = ctrl.galleryService.Delete(uint(uint64Val))
+	err = ctrl.galleryService.Delete(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to delete gallery",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// 4. Hapus file fisik
 	if existingGallery.URL != "" {
-		if err := os.Remove(existingGallery.URL); err != nil {
-			fmt.Printf("Warning: Failed to delete file %s: %v\n", existingGallery.URL, err)
+		if err := removeFile(c, existingGallery.URL); err != nil {
+			requestLogger(c).Warn("failed to delete file", "path", existingGallery.URL, "error", err)
 		} else {
-			fmt.Printf("Successfully deleted file: %s\n", existingGallery.URL)
+			requestLogger(c).Info("deleted file", "path", existingGallery.URL)
 		}
 	}
 
@@ -470,4 +432,32 @@ func (ctrl *GalleryController) Delete(c *gin.Context) {
 			"title": existingGallery.Title,
 		},
 	})
-}
\ No newline at end of file
+}
+
+func (ctrl *GalleryController) Reorder(c *gin.Context) {
+	// 1. Bind daftar ID sesuai urutan baru
+	ids, ok := bindReorderRequest(c)
+	if !ok {
+		return
+	}
+
+	// 2. Simpan urutan baru (atomic, rollback jika ada ID tidak valid)
+	if err := ctrl.galleryService.Reorder(c.Request.Context(), ids); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more galleries not found",
+			})
+			return
+		}
+
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Galleries reordered successfully",
+		"data": gin.H{
+			"ids": ids,
+		},
+	})
+}
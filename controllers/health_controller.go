@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/services"
+)
+
+// readinessTimeout batas waktu seluruh pemeriksaan readiness
+const readinessTimeout = 3 * time.Second
+
+type HealthController struct {
+	healthService services.HealthService
+}
+
+func NewHealthController(healthService services.HealthService) *HealthController {
+	return &HealthController{
+		healthService: healthService,
+	}
+}
+
+// Healthz liveness probe, cukup menandakan proses masih melayani request
+func (ctrl *HealthController) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "ok",
+	})
+}
+
+// Readyz readiness probe: database, migrasi, storage, dan belum dalam proses shutdown
+func (ctrl *HealthController) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	checks, ok := ctrl.healthService.Ready(ctx)
+	if !ok {
+		for name, check := range checks {
+			if check.Error != nil {
+				requestLogger(c).Warn("readiness check failed", "check", name, "error", check.Error)
+			}
+		}
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status": "unavailable",
+			"checks": checks,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "ok",
+		"checks": checks,
+	})
+}
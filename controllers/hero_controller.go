@@ -1,7 +1,9 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -13,6 +15,8 @@ import (
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/services"
 	"github.com/tech-azim/be-learnova/utils"
+	"github.com/tech-azim/be-learnova/validation"
+	"gorm.io/gorm"
 )
 
 type HeroRequest struct  {
@@ -22,59 +26,39 @@ type HeroRequest struct  {
 	Descipriotn string `json:"description"`
 }
 
+// HeroForm field form untuk membuat hero
+type HeroForm struct {
+	File        *multipart.FileHeader `form:"file" binding:"required"`
+	Title       string                `form:"title" binding:"required"`
+	Description string                `form:"description"`
+}
+
 type HeroController struct {
-	heroService services.HeroService
+	heroService        services.HeroService
+	translationService services.TranslationService
 }
 
-func NewHeroController(heroService services.HeroService) *HeroController {
+func NewHeroController(heroService services.HeroService, translationService services.TranslationService) *HeroController {
 	return &HeroController{
-		heroService: heroService,
+		heroService:        heroService,
+		translationService: translationService,
 	}
 }
 func (ctrl *HeroController) Create(c *gin.Context) {
-	// ambil file
-	file, err := c.FormFile("file")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "File is required",
-			"error":   err.Error(),
-		})
+	// bind & validasi form, termasuk tipe & ukuran file
+	var form HeroForm
+	if !bindForm(c, &form, func(errs *validation.Errors) {
+		checkImageFile(errs, "file", form.File)
+	}) {
 		return
 	}
-
-	// Validasi tipe file (opsional tapi recommended)
-	allowedExtensions := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".gif":  true,
-		".webp": true,
-	}
-	
+	file := form.File
 	ext := strings.ToLower(filepath.Ext(file.Filename))
-	if !allowedExtensions[ext] {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid file type. Only jpg, jpeg, png, gif, webp allowed",
-		})
-		return
-	}
-
-	// Validasi ukuran file (misal max 5MB)
-	maxSize := int64(5 * 1024 * 1024) // 5MB
-	if file.Size > maxSize {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "File size too large. Maximum 5MB allowed",
-		})
-		return
-	}
 
 	// buat folder upload kalau belum ada
 	uploadPath := "uploads/"
 	if err := os.MkdirAll(uploadPath, os.ModePerm); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to create upload directory",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -92,28 +76,15 @@ func (ctrl *HeroController) Create(c *gin.Context) {
 	}
 
 	// simpan file
-	if err := c.SaveUploadedFile(file, filePath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to save file",
-			"error":   err.Error(),
-		})
+	if err := storeUpload(c, file, filePath); err != nil {
+		respondError(c, err)
 		return
 	}
 
 	// ambil field lain dari form
-	title := c.PostForm("title")
+	title := form.Title
 	alt := "Hero"
-	description := c.PostForm("description")
-
-	// Validasi field wajib
-	if title == "" {
-		// Hapus file yang sudah diupload
-		os.Remove(filePath)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Title is required",
-		})
-		return
-	}
+	description := form.Description
 
 	payload := models.Hero{
 		SRC:         filePath, 
@@ -122,14 +93,11 @@ func (ctrl *HeroController) Create(c *gin.Context) {
 		Title:       title,
 	}
 
-	hero, err := ctrl.heroService.Create(payload)
+	hero, err := ctrl.heroService.Create(c.Request.Context(), payload)
 	if err != nil {
-		os.Remove(filePath)
+		removeFile(c, filePath)
 		
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to create hero",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -144,10 +112,7 @@ func (ctrl *HeroController) FindAll(c *gin.Context) {
 	var params utils.PaginationParams
 	
 	// Bind query parameters
-	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bindQuery(c, &params) {
 		return
 	}
 	
@@ -159,14 +124,14 @@ func (ctrl *HeroController) FindAll(c *gin.Context) {
 		params.Limit = 10
 	}
 	
-	data,total, err := ctrl.heroService.FindAll(params)
+	data,total, err := ctrl.heroService.FindAll(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch heroes",
-		})
+		respondError(c, err)
 		return
 	}
 	
+	localizeResponse(c, ctrl.translationService, services.TranslatableHero, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 		"pagination": gin.H{
@@ -182,16 +147,20 @@ func (ctrl *HeroController) FindById(c *gin.Context) {
 	id := c.Param("id")
 
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		respondInvalidParam(c, "id", "number", "")
+		return
+	}
 
-	data, err := ctrl.heroService.FindByID(uint(uint64Val))
+	data, err := ctrl.heroService.FindByID(c.Request.Context(), uint(uint64Val))
 
 	if err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{
-			"error": err,
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatableHero, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
@@ -201,20 +170,14 @@ func (ctrl *HeroController) Update(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah hero exist
-	existingHero, err := ctrl.heroService.FindByID(uint(uint64Val))
+	existingHero, err := ctrl.heroService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Hero not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -230,10 +193,7 @@ func (ctrl *HeroController) Update(c *gin.Context) {
 
 		uploadPath := "uploads/"
 		if err := os.MkdirAll(uploadPath, os.ModePerm); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"message": "Failed to create upload directory",
-				"error":   err.Error(),
-			})
+			respondError(c, err)
 			return
 		}
 
@@ -242,16 +202,13 @@ func (ctrl *HeroController) Update(c *gin.Context) {
 		filePath = uploadPath + filename
 
 		// Simpan file baru
-		if err := c.SaveUploadedFile(file, filePath); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"message": "Failed to save file",
-				"error":   err.Error(),
-			})
+		if err := storeUpload(c, file, filePath); err != nil {
+			respondError(c, err)
 			return
 		}
 
 		newFileUploaded = true
-		fmt.Printf("New file uploaded: %s\n", filePath)
+		requestLogger(c).Info("file uploaded", "path", filePath)
 	}
 
 	title := c.PostForm("title")
@@ -271,34 +228,32 @@ func (ctrl *HeroController) Update(c *gin.Context) {
 
 	payload := models.Hero{
 		ID:          uint(uint64Val),
+		SortOrder:   existingHero.SortOrder,
 		SRC:         filePath,
 		ALT:         alt,
 		Description: description,
 		Title:       title,
 	}
 
-	data, err := ctrl.heroService.Update(payload)
+	data, err := ctrl.heroService.Update(c.Request.Context(), payload)
 	if err != nil {
 		if newFileUploaded && filePath != oldFilePath {
-			if removeErr := os.Remove(filePath); removeErr != nil {
-				fmt.Printf("Failed to rollback new file %s: %v\n", filePath, removeErr)
+			if removeErr := removeFile(c, filePath); removeErr != nil {
+				requestLogger(c).Error("failed to roll back uploaded file", "path", filePath, "error", removeErr)
 			} else {
-				fmt.Printf("Rolled back: Deleted new file %s due to database error\n", filePath)
+				requestLogger(c).Info("rolled back uploaded file", "path", filePath)
 			}
 		}
 		
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to update hero",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	if newFileUploaded && oldFilePath != "" && oldFilePath != filePath {
-		if err := os.Remove(oldFilePath); err != nil {
-			fmt.Printf("Warning: Failed to delete old file %s: %v\n", oldFilePath, err)
+		if err := removeFile(c, oldFilePath); err != nil {
+			requestLogger(c).Warn("failed to delete old file", "path", oldFilePath, "error", err)
 		} else {
-			fmt.Printf("Successfully deleted old file: %s\n", oldFilePath)
+			requestLogger(c).Info("deleted old file", "path", oldFilePath)
 		}
 	}
 
@@ -312,34 +267,25 @@ func (ctrl *HeroController) Delete(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
-	existingHero, err := ctrl.heroService.FindByID(uint(uint64Val))
+	existingHero, err := ctrl.heroService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Hero not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
-	err = ctrl.heroService.Delete(uint(uint64Val))
+	err = ctrl.heroService.Delete(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to delete hero",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	if existingHero.SRC != "" {
-		if err := os.Remove(existingHero.SRC); err != nil {
-			fmt.Printf("Warning: Failed to delete file %s: %v\n", existingHero.SRC, err)
+		if err := removeFile(c, existingHero.SRC); err != nil {
+			requestLogger(c).Warn("failed to delete file", "path", existingHero.SRC, "error", err)
 		}
 	}
 
@@ -352,24 +298,49 @@ func (ctrl *HeroController) FindByID(c *gin.Context){
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
-	data, err := ctrl.heroService.FindByID(uint(uint64Val))
+	data, err := ctrl.heroService.FindByID(c.Request.Context(), uint(uint64Val))
 
 	if err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatableHero, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
 	
-}
\ No newline at end of file
+}
+
+func (ctrl *HeroController) Reorder(c *gin.Context) {
+	// 1. Bind daftar ID sesuai urutan baru
+	ids, ok := bindReorderRequest(c)
+	if !ok {
+		return
+	}
+
+	// 2. Simpan urutan baru (atomic, rollback jika ada ID tidak valid)
+	if err := ctrl.heroService.Reorder(c.Request.Context(), ids); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more heroes not found",
+			})
+			return
+		}
+
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Heroes reordered successfully",
+		"data": gin.H{
+			"ids": ids,
+		},
+	})
+}
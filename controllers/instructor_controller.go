@@ -0,0 +1,284 @@
+package controllers
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/services"
+	"github.com/tech-azim/be-learnova/utils"
+	"github.com/tech-azim/be-learnova/validation"
+	"gorm.io/gorm"
+)
+
+type ProgramInstructorsRequest struct {
+	InstructorIDs []uint `json:"instructor_ids"`
+}
+
+// InstructorForm field form untuk membuat instructor, foto disimpan oleh saveInstructorPhoto
+type InstructorForm struct {
+	Name  string                `form:"name" binding:"required"`
+	Title string                `form:"title"`
+	Bio   string                `form:"bio"`
+	Photo *multipart.FileHeader `form:"photo"`
+}
+
+type InstructorController struct {
+	instructorService services.InstructorService
+	programService    services.ProgramService
+}
+
+func NewInstructorController(instructorService services.InstructorService, programService services.ProgramService) *InstructorController {
+	return &InstructorController{
+		instructorService: instructorService,
+		programService:    programService,
+	}
+}
+
+// saveInstructorPhoto validasi & simpan foto instructor (opsional)
+// Return path kosong jika tidak ada file yang diupload
+func saveInstructorPhoto(c *gin.Context) (string, bool) {
+	file, err := c.FormFile("photo")
+	if err != nil {
+		return "", true
+	}
+
+	ext, errMsg := validateImageFile(file.Filename, file.Size)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": errMsg})
+		return "", false
+	}
+
+	filePath, err := saveUploadedFile(c, file.Filename, ext)
+	if err == nil {
+		err = storeUpload(c, file, filePath)
+	}
+	if err != nil {
+		respondError(c, err)
+		return "", false
+	}
+
+	return filePath, true
+}
+
+func (ctrl *InstructorController) Create(c *gin.Context) {
+	// 1-2. Bind & validasi field form, termasuk tipe & ukuran foto
+	var form InstructorForm
+	if !bindForm(c, &form, func(errs *validation.Errors) {
+		checkImageFile(errs, "photo", form.Photo)
+	}) {
+		return
+	}
+	name, title, bio := form.Name, form.Title, form.Bio
+
+	// 3. Simpan foto (opsional)
+	photoPath, ok := saveInstructorPhoto(c)
+	if !ok {
+		return
+	}
+
+	payload := models.Instructor{
+		Name:  name,
+		Title: title,
+		Bio:   bio,
+		Photo: photoPath,
+	}
+
+	instructor, err := ctrl.instructorService.Create(c.Request.Context(), payload)
+	if err != nil {
+		if photoPath != "" {
+			removeFile(c, photoPath)
+		}
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    instructor,
+		"message": "Instructor created successfully",
+	})
+}
+
+func (ctrl *InstructorController) FindAll(c *gin.Context) {
+	params := utils.GetPaginationParams(c)
+
+	data, total, err := ctrl.instructorService.FindAll(c.Request.Context(), params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"pagination": gin.H{
+			"page":  params.Page,
+			"limit": params.Limit,
+			"total": total,
+		},
+	})
+}
+
+func (ctrl *InstructorController) FindByID(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	data, err := ctrl.instructorService.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (ctrl *InstructorController) Update(c *gin.Context) {
+	// 1. Ambil ID dari URL parameter
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// 2. Cek apakah instructor exist
+	existingInstructor, err := ctrl.instructorService.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// 3. Ambil field dari form, gunakan nilai lama jika kosong
+	payload := existingInstructor
+	if name := c.PostForm("name"); name != "" {
+		payload.Name = name
+	}
+	if title := c.PostForm("title"); title != "" {
+		payload.Title = title
+	}
+	if bio := c.PostForm("bio"); bio != "" {
+		payload.Bio = bio
+	}
+
+	// 4. Handle foto baru (opsional)
+	photoPath, ok := saveInstructorPhoto(c)
+	if !ok {
+		return
+	}
+	if photoPath != "" {
+		payload.Photo = photoPath
+	}
+
+	// 5. Update ke database
+	data, err := ctrl.instructorService.Update(c.Request.Context(), payload)
+	if err != nil {
+		if photoPath != "" {
+			removeFile(c, photoPath)
+		}
+		respondError(c, err)
+		return
+	}
+
+	// 6. Hapus foto lama jika ada foto baru
+	if photoPath != "" && existingInstructor.Photo != "" {
+		if err := removeFile(c, existingInstructor.Photo); err != nil {
+			requestLogger(c).Warn("failed to delete old file", "path", existingInstructor.Photo, "error", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Instructor updated successfully",
+	})
+}
+
+func (ctrl *InstructorController) Delete(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	existingInstructor, err := ctrl.instructorService.FindByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if err := ctrl.instructorService.Delete(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Instructor deleted successfully",
+		"data": gin.H{
+			"id":   existingInstructor.ID,
+			"name": existingInstructor.Name,
+		},
+	})
+}
+
+func (ctrl *InstructorController) FindByProgram(c *gin.Context) {
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return
+	}
+
+	data, err := ctrl.instructorService.FindByProgramID(c.Request.Context(), programID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (ctrl *InstructorController) SetForProgram(c *gin.Context) {
+	// 1. Validasi program
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return
+	}
+
+	// 2. Bind daftar instructor sesuai urutan tampil (kosong = hapus semua)
+	var req ProgramInstructorsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	seen := make(map[uint]bool, len(req.InstructorIDs))
+	for _, id := range req.InstructorIDs {
+		if seen[id] {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"message": "Duplicate instructor ID",
+				"id":      id,
+			})
+			return
+		}
+		seen[id] = true
+	}
+
+	// 3. Simpan relasi program <-> instructor
+	data, err := ctrl.instructorService.SetProgramInstructors(c.Request.Context(), programID, req.InstructorIDs)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more instructors not found",
+			})
+			return
+		}
+
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Program instructors updated successfully",
+	})
+}
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/services"
+	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
+)
+
+type JobController struct {
+	jobService services.JobService
+}
+
+func NewJobController(jobService services.JobService) *JobController {
+	return &JobController{
+		jobService: jobService,
+	}
+}
+
+// FindAll listing job, filter opsional ?status=&type=&queue=
+func (ctrl *JobController) FindAll(c *gin.Context) {
+	params := utils.GetPaginationParams(c)
+	filter := repositories.JobFilter{
+		Status: c.Query("status"),
+		Type:   c.Query("type"),
+		Queue:  c.Query("queue"),
+	}
+
+	data, total, err := ctrl.jobService.FindAll(params, filter)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"pagination": gin.H{
+			"page":  params.Page,
+			"limit": params.Limit,
+			"total": total,
+		},
+	})
+}
+
+// Stats jumlah job per status
+func (ctrl *JobController) Stats(c *gin.Context) {
+	data, err := ctrl.jobService.Stats()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (ctrl *JobController) FindByID(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	data, err := ctrl.jobService.FindByID(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+// Retry jalankan ulang job yang masuk dead-letter
+func (ctrl *JobController) Retry(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	data, err := ctrl.jobService.Retry(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "Job not found",
+			})
+		case errors.Is(err, services.ErrJobNotRetryable):
+			c.JSON(http.StatusConflict, gin.H{
+				"message": "Only dead jobs can be retried",
+			})
+		default:
+			respondError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Job queued for retry",
+	})
+}
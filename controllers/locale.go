@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/middlewares"
+	"github.com/tech-azim/be-learnova/services"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+// localizeResponse menerjemahkan data untuk route yang dibuka halaman publik dan panel admin.
+// Request publik memakai locale request (?lang= / Accept-Language), request admin (membawa
+// Authorization / X-API-Key) hanya ?lang=, supaya Accept-Language browser admin tidak mengganti
+// nilai yang diedit.
+func localizeResponse(c *gin.Context, translationService services.TranslationService, entityType string, data any) {
+	c.Writer.Header().Add("Vary", "Authorization")
+	c.Writer.Header().Add("Vary", middlewares.APIKeyHeader)
+	if !hasCredentials(c) {
+		localizePublicResponse(c, translationService, entityType, data)
+		return
+	}
+
+	locale, ok := utils.QueryLocale(c)
+	if !ok {
+		c.Header("Content-Language", utils.DefaultLocale)
+		return
+	}
+
+	localize(c, translationService, entityType, locale, data)
+}
+
+// localizePublicResponse menerjemahkan data untuk route khusus halaman publik
+// sesuai locale request (?lang= / Accept-Language).
+func localizePublicResponse(c *gin.Context, translationService services.TranslationService, entityType string, data any) {
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	localize(c, translationService, entityType, utils.GetLocale(c), data)
+}
+
+// hasCredentials true jika request membawa JWT atau API key (request panel admin / integrasi)
+func hasCredentials(c *gin.Context) bool {
+	return c.GetHeader("Authorization") != "" || c.GetHeader(middlewares.APIKeyHeader) != ""
+}
+
+// localize jika gagal, data tetap dikirim dalam bahasa default
+func localize(c *gin.Context, translationService services.TranslationService, entityType string, locale string, data any) {
+	c.Header("Content-Language", locale)
+
+	if err := translationService.Localize(c.Request.Context(), entityType, locale, data); err != nil {
+		requestLogger(c).Warn("failed to localize response", "entity", entityType, "error", err)
+		c.Header("Content-Language", utils.DefaultLocale)
+	}
+}
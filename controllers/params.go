@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/logging"
+	"github.com/tech-azim/be-learnova/validation"
+)
+
+// requestLogger logger request ini (sudah membawa request_id)
+func requestLogger(c *gin.Context) *slog.Logger {
+	return logging.FromContext(c.Request.Context())
+}
+
+// parseUintParam parse path parameter bertipe ID (contoh: :id, :moduleId)
+// Return false jika response error sudah dikirim ke client
+func parseUintParam(c *gin.Context, name string) (uint, bool) {
+	uint64Val, err := strconv.ParseUint(c.Param(name), 10, 0)
+	if err != nil {
+		respondInvalidParam(c, name, "number", "")
+		return 0, false
+	}
+
+	return uint(uint64Val), true
+}
+
+// respondInvalidParam kirim kesalahan validasi (422) untuk parameter URL / field form
+// yang dibaca manual, contoh respondInvalidParam(c, "id", "number", "")
+func respondInvalidParam(c *gin.Context, field, rule, param string) {
+	errs := validation.New(c)
+	errs.Add(field, rule, param)
+	respondError(c, errs.Err())
+}
+
+// respondError serahkan error ke middleware ErrorResponses yang menentukan status & body
+// dari jenis error (apperrors). Error selain apperrors dikirim sebagai 500.
+func respondError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// bindJSON bind & validasi body JSON, semua kesalahan field dikirim sekaligus (422)
+// Return false jika response error sudah dikirim ke client
+func bindJSON(c *gin.Context, obj any) bool {
+	if err := validation.BindJSON(c, obj); err != nil {
+		respondError(c, err)
+		return false
+	}
+	return true
+}
+
+// bindQuery bind & validasi query string, parameter yang tidak valid dikirim sebagai kesalahan field (422)
+// Return false jika response error sudah dikirim ke client
+func bindQuery(c *gin.Context, obj any) bool {
+	if err := validation.BindQuery(c, obj); err != nil {
+		respondError(c, err)
+		return false
+	}
+	return true
+}
+
+// bindForm bind & validasi form multipart/urlencoded, semua kesalahan field dikirim sekaligus (422).
+// checks dijalankan setelah bind untuk aturan yang tidak bisa ditulis sebagai tag (contoh tipe file).
+// Return false jika response error sudah dikirim ke client
+func bindForm(c *gin.Context, obj any, checks ...func(*validation.Errors)) bool {
+	errs := validation.New(c)
+	if err := errs.Bind(validation.ShouldBindForm(c, obj)); err != nil {
+		respondError(c, err)
+		return false
+	}
+	for _, check := range checks {
+		check(errs)
+	}
+
+	if err := errs.Err(); err != nil {
+		respondError(c, err)
+		return false
+	}
+	return true
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/services"
 	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
 )
 
 type PortfolioRequest struct {
@@ -16,43 +18,32 @@ type PortfolioRequest struct {
 	Description string `json:"description"`
 }
 
+// PortfolioForm field form untuk membuat portfolio
+type PortfolioForm struct {
+	Title       string `form:"title" binding:"required"`
+	Count       string `form:"count" binding:"required"`
+	Description string `form:"description" binding:"required"`
+}
+
 type PortfolioController struct {
-	portfolioService services.PortfolioService
+	portfolioService   services.PortfolioService
+	translationService services.TranslationService
 }
 
-func NewPortfolioController(portfolioService services.PortfolioService) *PortfolioController {
+func NewPortfolioController(portfolioService services.PortfolioService, translationService services.TranslationService) *PortfolioController {
 	return &PortfolioController{
-		portfolioService: portfolioService,
+		portfolioService:   portfolioService,
+		translationService: translationService,
 	}
 }
 
 func (ctrl *PortfolioController) Create(c *gin.Context) {
-	// Ambil field dari form
-	title := c.PostForm("title")
-	count := c.PostForm("count")
-	description := c.PostForm("description")
-
-	// Validasi field wajib
-	if title == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Title is required",
-		})
-		return
-	}
-
-	if count == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Count is required",
-		})
-		return
-	}
-
-	if description == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Description is required",
-		})
+	// Bind & validasi field form
+	var form PortfolioForm
+	if !bindForm(c, &form) {
 		return
 	}
+	title, count, description := form.Title, form.Count, form.Description
 
 	payload := models.Portfolio{
 		Title:       title,
@@ -60,12 +51,9 @@ func (ctrl *PortfolioController) Create(c *gin.Context) {
 		Description: description,
 	}
 
-	portfolio, err := ctrl.portfolioService.Create(payload)
+	portfolio, err := ctrl.portfolioService.Create(c.Request.Context(), payload)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to create portfolio",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -79,10 +67,7 @@ func (ctrl *PortfolioController) FindAll(c *gin.Context) {
 	var params utils.PaginationParams
 
 	// Bind query parameters
-	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bindQuery(c, &params) {
 		return
 	}
 
@@ -94,14 +79,14 @@ func (ctrl *PortfolioController) FindAll(c *gin.Context) {
 		params.Limit = 10
 	}
 
-	data, total, err := ctrl.portfolioService.FindAll(params)
+	data, total, err := ctrl.portfolioService.FindAll(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch portfolios",
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatablePortfolio, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 		"pagination": gin.H{
@@ -116,22 +101,18 @@ func (ctrl *PortfolioController) FindByID(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
-	data, err := ctrl.portfolioService.FindByID(uint(uint64Val))
+	data, err := ctrl.portfolioService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Portfolio not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatablePortfolio, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
@@ -142,20 +123,14 @@ func (ctrl *PortfolioController) Update(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah portfolio exist
-	existingPortfolio, err := ctrl.portfolioService.FindByID(uint(uint64Val))
+	existingPortfolio, err := ctrl.portfolioService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Portfolio not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -178,18 +153,16 @@ func (ctrl *PortfolioController) Update(c *gin.Context) {
 	// 4. Buat payload untuk update
 	payload := models.Portfolio{
 		ID:          uint(uint64Val),
+		SortOrder:   existingPortfolio.SortOrder,
 		Title:       title,
 		Count:       count,
 		Description: description,
 	}
 
 	// 5. Update ke database
-	data, err := ctrl.portfolioService.Update(payload)
+	data, err := ctrl.portfolioService.Update(c.Request.Context(), payload)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to update portfolio",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -204,30 +177,21 @@ func (ctrl *PortfolioController) Delete(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah portfolio exist
-	existingPortfolio, err := ctrl.portfolioService.FindByID(uint(uint64Val))
+	existingPortfolio, err := ctrl.portfolioService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Portfolio not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// 3. Soft delete portfolio (set is_deleted = true)
-	err = ctrl.portfolioService.Delete(uint(uint64Val))
+	err = ctrl.portfolioService.Delete(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to delete portfolio",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -238,4 +202,32 @@ func (ctrl *PortfolioController) Delete(c *gin.Context) {
 			"title": existingPortfolio.Title,
 		},
 	})
-}
\ No newline at end of file
+}
+
+func (ctrl *PortfolioController) Reorder(c *gin.Context) {
+	// 1. Bind daftar ID sesuai urutan baru
+	ids, ok := bindReorderRequest(c)
+	if !ok {
+		return
+	}
+
+	// 2. Simpan urutan baru (atomic, rollback jika ada ID tidak valid)
+	if err := ctrl.portfolioService.Reorder(c.Request.Context(), ids); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more portfolios not found",
+			})
+			return
+		}
+
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Portfolios reordered successfully",
+		"data": gin.H{
+			"ids": ids,
+		},
+	})
+}
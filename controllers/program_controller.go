@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -13,6 +14,7 @@ import (
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/services"
 	"github.com/tech-azim/be-learnova/utils"
+	"github.com/tech-azim/be-learnova/validation"
 	"github.com/lib/pq"
 )
 
@@ -27,60 +29,46 @@ type ProgramRequest struct {
 	Image        string   `json:"image"`
 }
 
+// ProgramForm field form untuk membuat program. Benefits dibaca terpisah karena bisa dikirim
+// sebagai array atau string dipisah koma, slug & SEO dibaca bindSEOForm
+type ProgramForm struct {
+	File         *multipart.FileHeader `form:"file" binding:"required"`
+	Icon         string                `form:"icon"`
+	Title        string                `form:"title" binding:"required"`
+	Duration     string                `form:"duration" binding:"required"`
+	Participants string                `form:"participants"`
+	Level        string                `form:"level" binding:"required"`
+	Description  string                `form:"description"`
+	Capacity     string                `form:"capacity" binding:"omitempty,number"`
+}
+
 type ProgramController struct {
-	programService services.ProgramService
+	programService     services.ProgramService
+	translationService services.TranslationService
 }
 
-func NewProgramController(programService services.ProgramService) *ProgramController {
+func NewProgramController(programService services.ProgramService, translationService services.TranslationService) *ProgramController {
 	return &ProgramController{
-		programService: programService,
+		programService:     programService,
+		translationService: translationService,
 	}
 }
 
 func (ctrl *ProgramController) Create(c *gin.Context) {
-	// Ambil file image
-	file, err := c.FormFile("file")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "File is required",
-			"error":   err.Error(),
-		})
+	// Bind & validasi form, termasuk tipe & ukuran file image
+	var form ProgramForm
+	if !bindForm(c, &form, func(errs *validation.Errors) {
+		checkImageFile(errs, "file", form.File)
+	}) {
 		return
 	}
-
-	// Validasi tipe file
-	allowedExtensions := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".gif":  true,
-		".webp": true,
-	}
-
+	file := form.File
 	ext := strings.ToLower(filepath.Ext(file.Filename))
-	if !allowedExtensions[ext] {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid file type. Only jpg, jpeg, png, gif, webp allowed",
-		})
-		return
-	}
-
-	// Validasi ukuran file (max 5MB)
-	maxSize := int64(5 * 1024 * 1024) // 5MB
-	if file.Size > maxSize {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "File size too large. Maximum 5MB allowed",
-		})
-		return
-	}
 
 	// Buat folder upload kalau belum ada
 	uploadPath := "uploads/programs/"
 	if err := os.MkdirAll(uploadPath, os.ModePerm); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to create upload directory",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -97,21 +85,17 @@ func (ctrl *ProgramController) Create(c *gin.Context) {
 	}
 
 	// Simpan file
-	if err := c.SaveUploadedFile(file, filePath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to save file",
-			"error":   err.Error(),
-		})
+	if err := storeUpload(c, file, filePath); err != nil {
+		respondError(c, err)
 		return
 	}
 
 	// Ambil field lain dari form
-	icon := c.PostForm("icon")
-	title := c.PostForm("title")
-	duration := c.PostForm("duration")
-	participants := c.PostForm("participants")
-	level := c.PostForm("level")
-	description := c.PostForm("description")
+	icon, title, duration, participants, level, description :=
+		form.Icon, form.Title, form.Duration, form.Participants, form.Level, form.Description
+
+	// Kapasitas kursi (opsional, 0 = tidak terbatas), format sudah divalidasi saat bind
+	capacity, _ := strconv.Atoi(form.Capacity)
 
 	// Parse benefits (array string)
 	benefitsStr := c.PostFormArray("benefits")
@@ -127,28 +111,10 @@ func (ctrl *ProgramController) Create(c *gin.Context) {
 		}
 	}
 
-	// Validasi field wajib
-	if title == "" {
-		os.Remove(filePath)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Title is required",
-		})
-		return
-	}
-
-	if duration == "" {
-		os.Remove(filePath)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Duration is required",
-		})
-		return
-	}
-
-	if level == "" {
-		os.Remove(filePath)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Level is required",
-		})
+	// Slug & SEO metadata (optional)
+	slug, seo, ogImagePath, ok := bindSEOForm(c, "", models.SEO{})
+	if !ok {
+		removeFile(c, filePath)
 		return
 	}
 
@@ -157,19 +123,23 @@ func (ctrl *ProgramController) Create(c *gin.Context) {
 		Title:        title,
 		Duration:     duration,
 		Participants: participants,
+		Capacity:     capacity,
 		Level:        level,
 		Description:  description,
 		Benefits: pq.StringArray(benefitsStr),
 		Image:        filePath,
+		Slug:         slug,
+		SEO:          seo,
 	}
 
-	program, err := ctrl.programService.Create(payload)
+	program, err := ctrl.programService.Create(c.Request.Context(), payload)
 	if err != nil {
-		os.Remove(filePath)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to create program",
-			"error":   err.Error(),
-		})
+		removeFile(c, filePath)
+		removeSEOImage(c, ogImagePath)
+		if respondSlugError(c, err) {
+			return
+		}
+		respondError(c, err)
 		return
 	}
 
@@ -183,10 +153,7 @@ func (ctrl *ProgramController) FindAll(c *gin.Context) {
 	var params utils.PaginationParams
 
 	// Bind query parameters
-	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bindQuery(c, &params) {
 		return
 	}
 
@@ -198,14 +165,14 @@ func (ctrl *ProgramController) FindAll(c *gin.Context) {
 		params.Limit = 10
 	}
 
-	data, total, err := ctrl.programService.FindAll(params)
+	data, total, err := ctrl.programService.FindAll(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch programs",
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatableProgram, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 		"pagination": gin.H{
@@ -220,22 +187,38 @@ func (ctrl *ProgramController) FindByID(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
-	data, err := ctrl.programService.FindByID(uint(uint64Val))
+	data, err := ctrl.programService.FindDetailByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Program not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
+		return
+	}
+
+	localizeResponse(c, ctrl.translationService, services.TranslatableProgram, &data)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+// FindBySlug detail program untuk halaman publik, slug lama diarahkan (301) ke slug terbaru
+func (ctrl *ProgramController) FindBySlug(c *gin.Context) {
+	data, redirected, err := ctrl.programService.FindBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if redirected {
+		respondSlugRedirect(c, "/api/v1/programs/slug/", data.Slug)
 		return
 	}
 
+	localizePublicResponse(c, ctrl.translationService, services.TranslatableProgram, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
@@ -246,20 +229,14 @@ func (ctrl *ProgramController) Update(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah program exist
-	existingProgram, err := ctrl.programService.FindByID(uint(uint64Val))
+	existingProgram, err := ctrl.programService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Program not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -298,10 +275,7 @@ func (ctrl *ProgramController) Update(c *gin.Context) {
 
 		uploadPath := "uploads/programs/"
 		if err := os.MkdirAll(uploadPath, os.ModePerm); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"message": "Failed to create upload directory",
-				"error":   err.Error(),
-			})
+			respondError(c, err)
 			return
 		}
 
@@ -310,16 +284,13 @@ func (ctrl *ProgramController) Update(c *gin.Context) {
 		filePath = uploadPath + filename
 
 		// Simpan file baru
-		if err := c.SaveUploadedFile(file, filePath); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"message": "Failed to save file",
-				"error":   err.Error(),
-			})
+		if err := storeUpload(c, file, filePath); err != nil {
+			respondError(c, err)
 			return
 		}
 
 		newFileUploaded = true
-		fmt.Printf("New file uploaded: %s\n", filePath)
+		requestLogger(c).Info("file uploaded", "path", filePath)
 	}
 
 	// 4. Ambil field dari form
@@ -330,6 +301,19 @@ func (ctrl *ProgramController) Update(c *gin.Context) {
 	level := c.PostForm("level")
 	description := c.PostForm("description")
 
+	// Kapasitas kursi (opsional, kosong berarti nilai lama dipertahankan)
+	capacity := existingProgram.Capacity
+	if c.PostForm("capacity") != "" {
+		parsed, ok := parseCapacityForm(c)
+		if !ok {
+			if newFileUploaded {
+				removeFile(c, filePath)
+			}
+			return
+		}
+		capacity = parsed
+	}
+
 	// Parse benefits
 	benefitsStr := c.PostFormArray("benefits")
 	if len(benefitsStr) == 0 {
@@ -364,6 +348,14 @@ func (ctrl *ProgramController) Update(c *gin.Context) {
 		description = existingProgram.Description
 	}
 
+	slug, seo, ogImagePath, ok := bindSEOForm(c, existingProgram.Slug, existingProgram.SEO)
+	if !ok {
+		if newFileUploaded {
+			removeFile(c, filePath)
+		}
+		return
+	}
+
 	// 5. Buat payload untuk update
 	payload := models.Program{
 		ID:           uint(uint64Val),
@@ -371,37 +363,45 @@ func (ctrl *ProgramController) Update(c *gin.Context) {
 		Title:        title,
 		Duration:     duration,
 		Participants: participants,
+		Capacity:     capacity,
 		Level:        level,
 		Description:  description,
 		Benefits:     benefitsStr,
 		Image:        filePath,
+		Slug:         slug,
+		SEO:          seo,
 	}
 
 	// 6. Update ke database
-	data, err := ctrl.programService.Update(payload)
+	data, err := ctrl.programService.Update(c.Request.Context(), payload)
 	if err != nil {
 		// Rollback: hapus file baru jika gagal update database
 		if newFileUploaded && filePath != oldFilePath {
-			if removeErr := os.Remove(filePath); removeErr != nil {
-				fmt.Printf("Failed to rollback new file %s: %v\n", filePath, removeErr)
+			if removeErr := removeFile(c, filePath); removeErr != nil {
+				requestLogger(c).Error("failed to roll back uploaded file", "path", filePath, "error", removeErr)
 			} else {
-				fmt.Printf("Rolled back: Deleted new file %s due to database error\n", filePath)
+				requestLogger(c).Info("rolled back uploaded file", "path", filePath)
 			}
 		}
+		removeSEOImage(c, ogImagePath)
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to update program",
-			"error":   err.Error(),
-		})
+		if respondSlugError(c, err) {
+			return
+		}
+
+		respondError(c, err)
 		return
 	}
 
 	// 7. Hapus file lama jika ada file baru yang berhasil diupload
+	if ogImagePath != "" {
+		removeSEOImage(c, existingProgram.SEO.OGImage)
+	}
 	if newFileUploaded && oldFilePath != "" && oldFilePath != filePath {
-		if err := os.Remove(oldFilePath); err != nil {
-			fmt.Printf("Warning: Failed to delete old file %s: %v\n", oldFilePath, err)
+		if err := removeFile(c, oldFilePath); err != nil {
+			requestLogger(c).Warn("failed to delete old file", "path", oldFilePath, "error", err)
 		} else {
-			fmt.Printf("Successfully deleted old file: %s\n", oldFilePath)
+			requestLogger(c).Info("deleted old file", "path", oldFilePath)
 		}
 	}
 
@@ -416,30 +416,21 @@ func (ctrl *ProgramController) Delete(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah program exist
-	existingProgram, err := ctrl.programService.FindByID(uint(uint64Val))
+	existingProgram, err := ctrl.programService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Program not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// 3. Soft delete program (set is_deleted = true)
-	err = ctrl.programService.Delete(uint(uint64Val))
+	err = ctrl.programService.Delete(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to delete program",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -448,10 +439,10 @@ func (ctrl *ProgramController) Delete(c *gin.Context) {
 	// Tapi jika ingin menghapus file, uncomment code di bawah:
 	/*
 		if existingProgram.Image != "" {
-			if err := os.Remove(existingProgram.Image); err != nil {
-				fmt.Printf("Warning: Failed to delete file %s: %v\n", existingProgram.Image, err)
+			if err := removeFile(c, existingProgram.Image); err != nil {
+				requestLogger(c).Warn("failed to delete file", "path", existingProgram.Image, "error", err)
 			} else {
-				fmt.Printf("Successfully deleted file: %s\n", existingProgram.Image)
+				requestLogger(c).Info("deleted file", "path", existingProgram.Image)
 			}
 		}
 	*/
@@ -464,3 +455,22 @@ func (ctrl *ProgramController) Delete(c *gin.Context) {
 		},
 	})
 }
+
+// parseCapacityForm parse field form "capacity", kosong berarti 0 (tidak terbatas)
+// Return false jika response error sudah dikirim ke client
+func parseCapacityForm(c *gin.Context) (int, bool) {
+	value := c.PostForm("capacity")
+	if value == "" {
+		return 0, true
+	}
+
+	capacity, err := strconv.Atoi(value)
+	if err != nil || capacity < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Capacity must be a non-negative number",
+		})
+		return 0, false
+	}
+
+	return capacity, true
+}
@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/services"
+	"gorm.io/gorm"
+)
+
+type ProgramFAQRequest struct {
+	Question string `json:"question" binding:"required"`
+	Answer   string `json:"answer" binding:"required"`
+}
+
+type ProgramFAQController struct {
+	programFAQService services.ProgramFAQService
+	programService    services.ProgramService
+}
+
+func NewProgramFAQController(programFAQService services.ProgramFAQService, programService services.ProgramService) *ProgramFAQController {
+	return &ProgramFAQController{
+		programFAQService: programFAQService,
+		programService:    programService,
+	}
+}
+
+// findFAQ ambil :faqId dari URL dan memastikan FAQ milik program
+func (ctrl *ProgramFAQController) findFAQ(c *gin.Context) (models.ProgramFAQ, bool) {
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return models.ProgramFAQ{}, false
+	}
+
+	faqID, ok := parseUintParam(c, "faqId")
+	if !ok {
+		return models.ProgramFAQ{}, false
+	}
+
+	faq, err := ctrl.programFAQService.FindByID(c.Request.Context(), programID, faqID)
+	if err != nil {
+		respondError(c, err)
+		return models.ProgramFAQ{}, false
+	}
+
+	return faq, true
+}
+
+func (ctrl *ProgramFAQController) FindAll(c *gin.Context) {
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return
+	}
+
+	data, err := ctrl.programFAQService.FindByProgramID(c.Request.Context(), programID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (ctrl *ProgramFAQController) Create(c *gin.Context) {
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return
+	}
+
+	var req ProgramFAQRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	payload := models.ProgramFAQ{
+		ProgramID: programID,
+		Question:  req.Question,
+		Answer:    req.Answer,
+	}
+
+	faq, err := ctrl.programFAQService.Create(c.Request.Context(), payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    faq,
+		"message": "FAQ created successfully",
+	})
+}
+
+func (ctrl *ProgramFAQController) Update(c *gin.Context) {
+	existingFAQ, ok := ctrl.findFAQ(c)
+	if !ok {
+		return
+	}
+
+	var req ProgramFAQRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	payload := existingFAQ
+	payload.Question = req.Question
+	payload.Answer = req.Answer
+
+	data, err := ctrl.programFAQService.Update(c.Request.Context(), payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "FAQ updated successfully",
+	})
+}
+
+func (ctrl *ProgramFAQController) Delete(c *gin.Context) {
+	existingFAQ, ok := ctrl.findFAQ(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.programFAQService.Delete(c.Request.Context(), existingFAQ.ID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "FAQ deleted successfully",
+		"data": gin.H{
+			"id": existingFAQ.ID,
+		},
+	})
+}
+
+func (ctrl *ProgramFAQController) Reorder(c *gin.Context) {
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return
+	}
+
+	ids, ok := bindReorderRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.programFAQService.Reorder(c.Request.Context(), programID, ids); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more FAQs not found in this program",
+			})
+			return
+		}
+
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "FAQs reordered successfully",
+		"data": gin.H{
+			"ids": ids,
+		},
+	})
+}
@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/services"
+)
+
+// findProgram ambil :id program dari URL dan memastikan program ada.
+// Dipakai route turunan program (kurikulum, FAQ, instruktur, reminder).
+// Return false jika response error sudah dikirim ke client
+func findProgram(c *gin.Context, programService services.ProgramService) (uint, bool) {
+	programID, ok := parseUintParam(c, "id")
+	if !ok {
+		return 0, false
+	}
+
+	if _, err := programService.FindByID(c.Request.Context(), programID); err != nil {
+		respondError(c, err)
+		return 0, false
+	}
+
+	return programID, true
+}
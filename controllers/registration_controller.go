@@ -1,14 +1,18 @@
 package controllers
 
 import (
+	"errors"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/services"
 	"github.com/tech-azim/be-learnova/utils"
+	"github.com/tech-azim/be-learnova/validation"
 )
 
 type RegistrationRequest struct {
@@ -19,52 +23,125 @@ type RegistrationRequest struct {
 	Position      string `json:"position"`
 	ProgramID     uint   `json:"programId" binding:"required"`
 	Participants  int    `json:"participants" binding:"required,min=1"`
-	PreferredDate string `json:"preferredDate" binding:"required"`
+	PreferredDate string `json:"preferredDate" binding:"required,datetime=2006-01-02"`
 	Message       string `json:"message"`
 	Status        string `json:"status"`
+	// Attendees opsional untuk registrasi grup, jumlahnya harus sama dengan Participants
+	Attendees []AttendeeRequest `json:"attendees" binding:"omitempty,dive"`
+
+	// Website field honeypot, disembunyikan di form sehingga hanya diisi bot
+	Website string `json:"website"`
+	// CaptchaToken token dari widget CAPTCHA, bisa juga dikirim lewat header X-Captcha-Token
+	CaptchaToken string `json:"captchaToken"`
 }
 
 type RegistrationController struct {
 	registrationService services.RegistrationService
 	programService      services.ProgramService
+	spamService         services.SpamService
 }
 
-func NewRegistrationController(registrationService services.RegistrationService, programService services.ProgramService) *RegistrationController {
+func NewRegistrationController(registrationService services.RegistrationService, programService services.ProgramService, spamService services.SpamService) *RegistrationController {
 	return &RegistrationController{
 		registrationService: registrationService,
 		programService:      programService,
+		spamService:         spamService,
 	}
 }
 
-func (ctrl *RegistrationController) Create(c *gin.Context) {
-	var req RegistrationRequest
+// checkSpam jalankan pemeriksaan spam untuk submission publik.
+// Mengembalikan false jika request ditolak dan response sudah dikirim.
+func (ctrl *RegistrationController) checkSpam(c *gin.Context, req RegistrationRequest) (services.SpamVerdict, bool) {
+	token := req.CaptchaToken
+	if token == "" {
+		token = c.GetHeader("X-Captcha-Token")
+	}
 
-	// Bind dan validasi JSON request
-	if err := c.ShouldBindJSON(&req); err != nil {
+	verdict, err := ctrl.spamService.Check(c.Request.Context(), services.SpamCheck{
+		IP:           c.ClientIP(),
+		Email:        req.Email,
+		Honeypot:     req.Website,
+		CaptchaToken: token,
+		Message:      req.Message,
+	})
+	switch {
+	case errors.Is(err, services.ErrRateLimited):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(verdict.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"message": "Too many registrations, please try again later",
+		})
+		return verdict, false
+	case errors.Is(err, services.ErrDisposableEmail):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"message": "Disposable email addresses are not allowed",
+		})
+		return verdict, false
+	case errors.Is(err, services.ErrCaptchaInvalid):
 		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid request data",
-			"error":   err.Error(),
+			"message": "Captcha verification failed",
 		})
+		return verdict, false
+	case err != nil:
+		respondError(c, err)
+		return verdict, false
+	}
+
+	return verdict, true
+}
+
+// bindRegistration bind & validasi RegistrationRequest sekaligus aturan yang tidak bisa ditulis
+// sebagai tag: preferredDate tidak di masa lalu dan jumlah attendee sama dengan participants
+// (hanya untuk registrasi baru). Return false jika response error sudah dikirim ke client.
+func bindRegistration(c *gin.Context, req *RegistrationRequest, isNew bool) (time.Time, bool) {
+	errs := validation.New(c)
+	if err := errs.Bind(c.ShouldBindJSON(req)); err != nil {
+		respondError(c, err)
+		return time.Time{}, false
+	}
+
+	var preferredDate time.Time
+	if !errs.Has("preferredDate") {
+		preferredDate, _ = time.Parse(time.DateOnly, req.PreferredDate)
+		if isNew && preferredDate.Before(time.Now().Truncate(24*time.Hour)) {
+			errs.Add("preferredDate", "future", "")
+		}
+	}
+	if isNew && len(req.Attendees) > 0 && len(req.Attendees) != req.Participants {
+		errs.Add("attendees", "count", strconv.Itoa(req.Participants))
+	}
+
+	if err := errs.Err(); err != nil {
+		respondError(c, err)
+		return time.Time{}, false
+	}
+	return preferredDate, true
+}
+
+func (ctrl *RegistrationController) Create(c *gin.Context) {
+	// Bind dan validasi JSON request
+	var req RegistrationRequest
+	preferredDate, ok := bindRegistration(c, &req, true)
+	if !ok {
+		return
+	}
+
+	// Rate limit, honeypot, CAPTCHA & domain disposable dicek sebelum menyentuh database
+	verdict, ok := ctrl.checkSpam(c, req)
+	if !ok {
 		return
 	}
 
 	// Validasi apakah program exists
-	_, err := ctrl.programService.FindByID(req.ProgramID)
+	_, err := ctrl.programService.FindByID(c.Request.Context(), req.ProgramID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Program not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// Cek apakah email sudah terdaftar di program yang sama
-	exists, err := ctrl.registrationService.CheckEmailExists(req.Email, req.ProgramID)
+	exists, err := ctrl.registrationService.CheckEmailExists(c.Request.Context(), req.Email, req.ProgramID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to check email existence",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -75,24 +152,6 @@ func (ctrl *RegistrationController) Create(c *gin.Context) {
 		return
 	}
 
-	// Parse preferred date
-	preferredDate, err := time.Parse("2006-01-02", req.PreferredDate)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid date format. Use YYYY-MM-DD",
-			"error":   err.Error(),
-		})
-		return
-	}
-
-	// Validasi: preferred date tidak boleh di masa lalu
-	if preferredDate.Before(time.Now().Truncate(24 * time.Hour)) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Preferred date cannot be in the past",
-		})
-		return
-	}
-
 	// Buat payload
 	payload := models.Registration{
 		Name:          req.Name,
@@ -104,18 +163,31 @@ func (ctrl *RegistrationController) Create(c *gin.Context) {
 		Participants:  req.Participants,
 		PreferredDate: preferredDate,
 		Message:       req.Message,
+		Attendees:     toAttendees(req.Attendees),
+		IPAddress:     c.ClientIP(),
+	}
+
+	// Submission mencurigakan tetap disimpan untuk direview admin
+	if verdict.Quarantine {
+		payload.Status = services.RegistrationStatusQuarantined
+		payload.SpamReason = verdict.Reason
 	}
 
 	// Simpan registrasi
-	registration, err := ctrl.registrationService.Create(payload)
+	registration, err := ctrl.registrationService.Create(c.Request.Context(), payload)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to create registration",
-			"error":   err.Error(),
-		})
+		// Request bersamaan bisa lolos cek di atas, unique index di database yang menentukan (409)
+		respondError(c, err)
 		return
 	}
 
+	// Response sama seperti registrasi biasa supaya bot tidak tahu submission-nya dikarantina
+	if verdict.Quarantine {
+		registration.Status = "pending"
+		registration.SpamReason = ""
+	}
+	registration.IPAddress = ""
+
 	c.JSON(http.StatusCreated, gin.H{
 		"data":    registration,
 		"message": "Registration created successfully",
@@ -126,10 +198,7 @@ func (ctrl *RegistrationController) FindAll(c *gin.Context) {
 	var params utils.PaginationParams
 
 	// Bind query parameters
-	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bindQuery(c, &params) {
 		return
 	}
 
@@ -141,11 +210,9 @@ func (ctrl *RegistrationController) FindAll(c *gin.Context) {
 		params.Limit = 10
 	}
 
-	data, total, err := ctrl.registrationService.FindAll(params)
+	data, total, err := ctrl.registrationService.FindAll(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch registrations",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -163,19 +230,13 @@ func (ctrl *RegistrationController) FindByID(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
-	data, err := ctrl.registrationService.FindByID(uint(uint64Val))
+	data, err := ctrl.registrationService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Registration not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -188,20 +249,14 @@ func (ctrl *RegistrationController) FindByProgramID(c *gin.Context) {
 	programID := c.Param("programId")
 	uint64Val, err := strconv.ParseUint(programID, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid program ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "programId", "number", "")
 		return
 	}
 
 	var params utils.PaginationParams
 
 	// Bind query parameters
-	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bindQuery(c, &params) {
 		return
 	}
 
@@ -214,20 +269,15 @@ func (ctrl *RegistrationController) FindByProgramID(c *gin.Context) {
 	}
 
 	// Validasi apakah program exists
-	_, err = ctrl.programService.FindByID(uint(uint64Val))
+	_, err = ctrl.programService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Program not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
-	data, total, err := ctrl.registrationService.FindByProgramID(uint(uint64Val), params)
+	data, total, err := ctrl.registrationService.FindByProgramID(c.Request.Context(), uint(uint64Val), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch registrations",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -251,11 +301,15 @@ func (ctrl *RegistrationController) FindByEmail(c *gin.Context) {
 		return
 	}
 
-	data, err := ctrl.registrationService.FindByEmail(email)
+	data, err := ctrl.registrationService.FindByEmail(c.Request.Context(), email)
 	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if len(data) == 0 {
 		c.JSON(http.StatusNotFound, gin.H{
 			"message": "Registration not found",
-			"error":   err.Error(),
 		})
 		return
 	}
@@ -270,75 +324,50 @@ func (ctrl *RegistrationController) Update(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah registration exist
-	existingRegistration, err := ctrl.registrationService.FindByID(uint(uint64Val))
+	existingRegistration, err := ctrl.registrationService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Registration not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// 3. Bind request JSON
 	var req RegistrationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid request data",
-			"error":   err.Error(),
-		})
+	preferredDate, ok := bindRegistration(c, &req, false)
+	if !ok {
 		return
 	}
 
 	// 4. Validasi apakah program exists (jika program_id berubah)
 	if req.ProgramID != existingRegistration.ProgramID {
-		_, err := ctrl.programService.FindByID(req.ProgramID)
+		_, err := ctrl.programService.FindByID(c.Request.Context(), req.ProgramID)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{
-				"message": "Program not found",
-				"error":   err.Error(),
-			})
+			respondError(c, err)
 			return
 		}
+	}
 
-		// Cek email conflict jika pindah program
-		if req.Email != existingRegistration.Email || req.ProgramID != existingRegistration.ProgramID {
-			exists, err := ctrl.registrationService.CheckEmailExists(req.Email, req.ProgramID)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"message": "Failed to check email existence",
-					"error":   err.Error(),
-				})
-				return
-			}
-
-			if exists {
-				c.JSON(http.StatusConflict, gin.H{
-					"message": "Email already registered for this program",
-				})
-				return
-			}
+	// Cek email conflict jika email atau program berubah
+	if !strings.EqualFold(strings.TrimSpace(req.Email), existingRegistration.Email) || req.ProgramID != existingRegistration.ProgramID {
+		exists, err := ctrl.registrationService.CheckEmailExists(c.Request.Context(), req.Email, req.ProgramID)
+		if err != nil {
+			respondError(c, err)
+			return
 		}
-	}
 
-	// 5. Parse preferred date
-	preferredDate, err := time.Parse("2006-01-02", req.PreferredDate)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid date format. Use YYYY-MM-DD",
-			"error":   err.Error(),
-		})
-		return
+		if exists {
+			c.JSON(http.StatusConflict, gin.H{
+				"message": "Email already registered for this program",
+			})
+			return
+		}
 	}
 
-	// 6. Buat payload untuk update
+	// 5. Buat payload untuk update
 	payload := models.Registration{
 		ID:            uint(uint64Val),
 		Name:          req.Name,
@@ -351,15 +380,15 @@ func (ctrl *RegistrationController) Update(c *gin.Context) {
 		PreferredDate: preferredDate,
 		Message:       req.Message,
 		Status: 	   req.Status,
+		SpamReason:    existingRegistration.SpamReason,
+		IPAddress:     existingRegistration.IPAddress,
 	}
 
-	// 7. Update ke database
-	data, err := ctrl.registrationService.Update(payload)
+	// 6. Update ke database
+	data, err := ctrl.registrationService.Update(c.Request.Context(), payload)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to update registration",
-			"error":   err.Error(),
-		})
+		// Jumlah peserta registrasi yang punya attendee diubah lewat PUT /registrations/:id/attendees
+		respondError(c, err)
 		return
 	}
 
@@ -374,30 +403,21 @@ func (ctrl *RegistrationController) Delete(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah registration exist
-	existingRegistration, err := ctrl.registrationService.FindByID(uint(uint64Val))
+	existingRegistration, err := ctrl.registrationService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Registration not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// 3. Soft delete registration
-	err = ctrl.registrationService.Delete(uint(uint64Val))
+	err = ctrl.registrationService.Delete(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to delete registration",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
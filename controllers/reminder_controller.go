@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/services"
+)
+
+type ReminderTemplateRequest struct {
+	// DaysBefore 0 = hari H, maksimal services.ReminderMaxDays
+	DaysBefore *int   `json:"days_before" binding:"required,min=0,max=60"`
+	Subject    string `json:"subject" binding:"required"`
+	Body       string `json:"body" binding:"required"`
+	IsActive   *bool  `json:"is_active"`
+}
+
+type ReminderController struct {
+	reminderService services.ReminderService
+	programService  services.ProgramService
+}
+
+func NewReminderController(reminderService services.ReminderService, programService services.ProgramService) *ReminderController {
+	return &ReminderController{
+		reminderService: reminderService,
+		programService:  programService,
+	}
+}
+
+// findTemplate ambil :reminderId dari URL dan memastikan template milik program
+func (ctrl *ReminderController) findTemplate(c *gin.Context) (models.ReminderTemplate, bool) {
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return models.ReminderTemplate{}, false
+	}
+
+	reminderID, ok := parseUintParam(c, "reminderId")
+	if !ok {
+		return models.ReminderTemplate{}, false
+	}
+
+	template, err := ctrl.reminderService.FindTemplateByID(c.Request.Context(), programID, reminderID)
+	if err != nil {
+		respondError(c, err)
+		return models.ReminderTemplate{}, false
+	}
+
+	return template, true
+}
+
+// FindAll template reminder program. Jika kosong, program memakai jadwal default (default_days)
+func (ctrl *ReminderController) FindAll(c *gin.Context) {
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return
+	}
+
+	data, err := ctrl.reminderService.FindTemplates(c.Request.Context(), programID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":         data,
+		"default_days": ctrl.reminderService.DefaultDays(),
+		"placeholders": services.ReminderPlaceholders,
+	})
+}
+
+func (ctrl *ReminderController) Create(c *gin.Context) {
+	programID, ok := findProgram(c, ctrl.programService)
+	if !ok {
+		return
+	}
+
+	var req ReminderTemplateRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	payload := models.ReminderTemplate{
+		ProgramID:  programID,
+		DaysBefore: *req.DaysBefore,
+		Subject:    req.Subject,
+		Body:       req.Body,
+		IsActive:   req.IsActive == nil || *req.IsActive,
+	}
+
+	template, err := ctrl.reminderService.CreateTemplate(c.Request.Context(), payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    template,
+		"message": "Reminder template created successfully",
+	})
+}
+
+func (ctrl *ReminderController) Update(c *gin.Context) {
+	existing, ok := ctrl.findTemplate(c)
+	if !ok {
+		return
+	}
+
+	var req ReminderTemplateRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	payload := existing
+	payload.DaysBefore = *req.DaysBefore
+	payload.Subject = req.Subject
+	payload.Body = req.Body
+	if req.IsActive != nil {
+		payload.IsActive = *req.IsActive
+	}
+
+	data, err := ctrl.reminderService.UpdateTemplate(c.Request.Context(), payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Reminder template updated successfully",
+	})
+}
+
+func (ctrl *ReminderController) Delete(c *gin.Context) {
+	existing, ok := ctrl.findTemplate(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.reminderService.DeleteTemplate(c.Request.Context(), existing.ProgramID, existing.ID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reminder template deleted successfully",
+		"data": gin.H{
+			"id":          existing.ID,
+			"days_before": existing.DaysBefore,
+		},
+	})
+}
@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// ReorderRequest payload untuk endpoint reorder, berisi ID sesuai urutan tampil
+type ReorderRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1,unique"`
+}
+
+// bindReorderRequest bind & validasi payload reorder, ID tidak boleh duplikat
+// Return false jika response error sudah dikirim ke client
+func bindReorderRequest(c *gin.Context) ([]uint, bool) {
+	var req ReorderRequest
+	if !bindJSON(c, &req) {
+		return nil, false
+	}
+
+	return req.IDs, true
+}
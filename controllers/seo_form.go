@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/services"
+)
+
+// bindSEOForm membaca field slug & SEO dari form (meta_title, meta_description,
+// canonical_url, og_image). og_image bisa berupa file upload atau URL teks.
+// Nilai lama dipakai jika field tidak dikirim. Return path file og_image baru
+// (untuk rollback) dan false jika response error sudah dikirim ke client.
+func bindSEOForm(c *gin.Context, slug string, seo models.SEO) (string, models.SEO, string, bool) {
+	if value, exists := c.GetPostForm("slug"); exists {
+		slug = value
+	}
+	if value, exists := c.GetPostForm("meta_title"); exists {
+		seo.MetaTitle = value
+	}
+	if value, exists := c.GetPostForm("meta_description"); exists {
+		seo.MetaDescription = value
+	}
+	if value, exists := c.GetPostForm("canonical_url"); exists {
+		seo.CanonicalURL = value
+	}
+	if value, exists := c.GetPostForm("og_image"); exists {
+		seo.OGImage = value
+	}
+
+	file, err := c.FormFile("og_image")
+	if err != nil {
+		return slug, seo, "", true
+	}
+
+	ext, errMsg := validateImageFile(file.Filename, file.Size)
+	if errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": errMsg})
+		return slug, seo, "", false
+	}
+
+	filePath, err := saveUploadedFile(c, file.Filename, ext)
+	if err == nil {
+		err = storeUpload(c, file, filePath)
+	}
+	if err != nil {
+		respondError(c, err)
+		return slug, seo, "", false
+	}
+
+	seo.OGImage = filePath
+	return slug, seo, filePath, true
+}
+
+// removeSEOImage hapus file og_image hasil upload, URL eksternal diabaikan
+func removeSEOImage(c *gin.Context, path string) {
+	if !strings.HasPrefix(path, "uploads/") {
+		return
+	}
+	if err := removeFile(c, path); err != nil {
+		requestLogger(c).Warn("failed to delete file", "path", path, "error", err)
+	}
+}
+
+// respondSlugError mengirim 409/400 untuk error slug, return false jika bukan error slug
+func respondSlugError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, services.ErrSlugTaken):
+		c.JSON(http.StatusConflict, gin.H{
+			"message": "Slug already in use",
+		})
+		return true
+	case errors.Is(err, services.ErrSlugInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"message": "Slug is invalid",
+		})
+		return true
+	}
+	return false
+}
+
+// respondSlugRedirect mengirim 301 ke slug terbaru
+func respondSlugRedirect(c *gin.Context, basePath string, slug string) {
+	c.Header("Location", basePath+slug)
+	c.JSON(http.StatusMovedPermanently, gin.H{
+		"message": "Slug has changed",
+		"slug":    slug,
+	})
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -8,52 +9,41 @@ import (
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/services"
 	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
 )
 
 
+// ServiceForm field form untuk membuat service, slug & SEO dibaca bindSEOForm
+type ServiceForm struct {
+	Icon        string `form:"icon" binding:"required"`
+	Title       string `form:"title" binding:"required"`
+	Description string `form:"description" binding:"required"`
+	Color       string `form:"color" binding:"required"`
+}
+
 type ServiceController struct {
-	serviceService services.ServiceService
+	serviceService     services.ServiceService
+	translationService services.TranslationService
 }
 
-func NewServiceController(serviceService services.ServiceService) *ServiceController {
+func NewServiceController(serviceService services.ServiceService, translationService services.TranslationService) *ServiceController {
 	return &ServiceController{
-		serviceService: serviceService,
+		serviceService:     serviceService,
+		translationService: translationService,
 	}
 }
 
 func (ctrl *ServiceController) Create(c *gin.Context) {
-	// Ambil field dari form
-	icon := c.PostForm("icon")
-	title := c.PostForm("title")
-	description := c.PostForm("description")
-	color := c.PostForm("color")
-
-	// Validasi field wajib
-	if icon == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Icon is required",
-		})
-		return
-	}
-
-	if title == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Title is required",
-		})
-		return
-	}
-
-	if description == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Description is required",
-		})
+	// Bind & validasi field form
+	var form ServiceForm
+	if !bindForm(c, &form) {
 		return
 	}
+	icon, title, description, color := form.Icon, form.Title, form.Description, form.Color
 
-	if color == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Color is required",
-		})
+	// Slug & SEO metadata (optional)
+	slug, seo, ogImagePath, ok := bindSEOForm(c, "", models.SEO{})
+	if !ok {
 		return
 	}
 
@@ -62,14 +52,17 @@ func (ctrl *ServiceController) Create(c *gin.Context) {
 		Title:       title,
 		Description: description,
 		Color:       color,
+		Slug:        slug,
+		SEO:         seo,
 	}
 
-	service, err := ctrl.serviceService.Create(payload)
+	service, err := ctrl.serviceService.Create(c.Request.Context(), payload)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to create service",
-			"error":   err.Error(),
-		})
+		removeSEOImage(c, ogImagePath)
+		if respondSlugError(c, err) {
+			return
+		}
+		respondError(c, err)
 		return
 	}
 
@@ -83,10 +76,7 @@ func (ctrl *ServiceController) FindAll(c *gin.Context) {
 	var params utils.PaginationParams
 
 	// Bind query parameters
-	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bindQuery(c, &params) {
 		return
 	}
 
@@ -98,14 +88,14 @@ func (ctrl *ServiceController) FindAll(c *gin.Context) {
 		params.Limit = 10
 	}
 
-	data, total, err := ctrl.serviceService.FindAll(params)
+	data, total, err := ctrl.serviceService.FindAll(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch services",
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatableService, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 		"pagination": gin.H{
@@ -120,22 +110,38 @@ func (ctrl *ServiceController) FindByID(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
-	data, err := ctrl.serviceService.FindByID(uint(uint64Val))
+	data, err := ctrl.serviceService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Service not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatableService, &data)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+// FindBySlug detail service untuk halaman publik, slug lama diarahkan (301) ke slug terbaru
+func (ctrl *ServiceController) FindBySlug(c *gin.Context) {
+	data, redirected, err := ctrl.serviceService.FindBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if redirected {
+		respondSlugRedirect(c, "/api/v1/services/slug/", data.Slug)
+		return
+	}
+
+	localizePublicResponse(c, ctrl.translationService, services.TranslatableService, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
@@ -146,20 +152,14 @@ func (ctrl *ServiceController) Update(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah service exist
-	existingService, err := ctrl.serviceService.FindByID(uint(uint64Val))
+	existingService, err := ctrl.serviceService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Service not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -183,25 +183,38 @@ func (ctrl *ServiceController) Update(c *gin.Context) {
 		color = existingService.Color
 	}
 
+	slug, seo, ogImagePath, ok := bindSEOForm(c, existingService.Slug, existingService.SEO)
+	if !ok {
+		return
+	}
+
 	// 4. Buat payload untuk update
 	payload := models.Service{
 		ID:          uint(uint64Val),
+		SortOrder:   existingService.SortOrder,
 		Icon:        icon,
 		Title:       title,
 		Description: description,
 		Color:       color,
+		Slug:        slug,
+		SEO:         seo,
 	}
 
 	// 5. Update ke database
-	data, err := ctrl.serviceService.Update(payload)
+	data, err := ctrl.serviceService.Update(c.Request.Context(), payload)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to update service",
-			"error":   err.Error(),
-		})
+		removeSEOImage(c, ogImagePath)
+		if respondSlugError(c, err) {
+			return
+		}
+		respondError(c, err)
 		return
 	}
 
+	if ogImagePath != "" {
+		removeSEOImage(c, existingService.SEO.OGImage)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"data":    data,
 		"message": "Service updated successfully",
@@ -213,30 +226,21 @@ func (ctrl *ServiceController) Delete(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah service exist
-	existingService, err := ctrl.serviceService.FindByID(uint(uint64Val))
+	existingService, err := ctrl.serviceService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Service not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// 3. Soft delete service (set is_deleted = true)
-	err = ctrl.serviceService.Delete(uint(uint64Val))
+	err = ctrl.serviceService.Delete(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to delete service",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -247,4 +251,32 @@ func (ctrl *ServiceController) Delete(c *gin.Context) {
 			"title": existingService.Title,
 		},
 	})
-}
\ No newline at end of file
+}
+
+func (ctrl *ServiceController) Reorder(c *gin.Context) {
+	// 1. Bind daftar ID sesuai urutan baru
+	ids, ok := bindReorderRequest(c)
+	if !ok {
+		return
+	}
+
+	// 2. Simpan urutan baru (atomic, rollback jika ada ID tidak valid)
+	if err := ctrl.serviceService.Reorder(c.Request.Context(), ids); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more services not found",
+			})
+			return
+		}
+
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Services reordered successfully",
+		"data": gin.H{
+			"ids": ids,
+		},
+	})
+}
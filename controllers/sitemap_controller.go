@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/services"
+)
+
+type SitemapController struct {
+	sitemapService services.SitemapService
+	siteURL        string
+}
+
+func NewSitemapController(sitemapService services.SitemapService, siteURL string) *SitemapController {
+	return &SitemapController{
+		sitemapService: sitemapService,
+		siteURL:        siteURL,
+	}
+}
+
+// baseURL memakai SITE_URL, fallback ke host request jika belum diset
+func (ctrl *SitemapController) baseURL(c *gin.Context) string {
+	if ctrl.siteURL != "" {
+		return ctrl.siteURL
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+func (ctrl *SitemapController) Sitemap(c *gin.Context) {
+	body, err := ctrl.sitemapService.BuildSitemap(c.Request.Context(), ctrl.baseURL(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", body)
+}
+
+func (ctrl *SitemapController) Robots(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.String(http.StatusOK, ctrl.sitemapService.BuildRobots(ctrl.baseURL(c)))
+}
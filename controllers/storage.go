@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"mime/multipart"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// storeUpload simpan file upload ke disk dengan span tracing "storage.save"
+func storeUpload(c *gin.Context, file *multipart.FileHeader, dst string) (err error) {
+	_, span := tracing.Start(c.Request.Context(), "storage.save", trace.WithAttributes(
+		semconv.FilePath(dst),
+		semconv.FileSize(int(file.Size)),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	return c.SaveUploadedFile(file, dst)
+}
+
+// removeFile hapus file dari disk dengan span tracing "storage.remove"
+func removeFile(c *gin.Context, path string) (err error) {
+	_, span := tracing.Start(c.Request.Context(), "storage.remove", trace.WithAttributes(
+		semconv.FilePath(path),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	return os.Remove(path)
+}
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/services"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+type TranslationRequest struct {
+	Fields map[string]string `json:"fields" binding:"required,min=1"`
+}
+
+type TranslationController struct {
+	translationService services.TranslationService
+}
+
+func NewTranslationController(translationService services.TranslationService) *TranslationController {
+	return &TranslationController{
+		translationService: translationService,
+	}
+}
+
+// FindByEntity semua terjemahan satu record, dikelompokkan per locale
+func (ctrl *TranslationController) FindByEntity(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	entityType := c.Param("entity")
+	data, err := ctrl.translationService.FindByEntity(c.Request.Context(), entityType, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	fields, _ := ctrl.translationService.Fields(entityType)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"entity_type":    entityType,
+			"entity_id":      id,
+			"default_locale": utils.DefaultLocale,
+			"fields":         fields,
+			"translations":   data,
+		},
+	})
+}
+
+// Save simpan terjemahan satu locale, field dengan value kosong akan dihapus
+func (ctrl *TranslationController) Save(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req TranslationRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	data, err := ctrl.translationService.Save(c.Request.Context(), c.Param("entity"), id, c.Param("locale"), req.Fields)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Translation saved successfully",
+	})
+}
+
+// DeleteLocale hapus semua terjemahan satu locale untuk satu record
+func (ctrl *TranslationController) DeleteLocale(c *gin.Context) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := ctrl.translationService.DeleteLocale(c.Request.Context(), c.Param("entity"), id, c.Param("locale")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Translation deleted successfully",
+	})
+}
+
+// FindMissing daftar record yang terjemahannya belum lengkap
+// Query: locale (default en), entity (optional), page, limit
+func (ctrl *TranslationController) FindMissing(c *gin.Context) {
+	params := utils.GetPaginationParams(c)
+	locale := c.DefaultQuery("locale", "en")
+
+	data, err := ctrl.translationService.FindMissing(c.Request.Context(), c.Query("entity"), locale)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	total := len(data)
+	start := (params.Page - 1) * params.Limit
+	if start > total {
+		start = total
+	}
+	end := start + params.Limit
+	if end > total {
+		end = total
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":   data[start:end],
+		"locale": locale,
+		"pagination": gin.H{
+			"page":  params.Page,
+			"limit": params.Limit,
+			"total": total,
+		},
+	})
+}
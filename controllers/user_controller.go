@@ -40,9 +40,9 @@ func (c *UserController) fail(ctx *gin.Context, code int, message string) {
 // @Success      200  {object}  map[string]any
 // @Router       /users [get]
 func (c *UserController) GetAllUsers(ctx *gin.Context) {
-	users, err := c.service.GetAllUsers()
+	users, err := c.service.GetAllUsers(ctx.Request.Context())
 	if err != nil {
-		c.fail(ctx, http.StatusInternalServerError, "Failed to fetch users")
+		respondError(ctx, err)
 		return
 	}
 	c.success(ctx, http.StatusOK, "Users fetched successfully", users)
@@ -64,13 +64,9 @@ func (c *UserController) GetUserByID(ctx *gin.Context) {
 		return
 	}
 
-	user, err := c.service.GetUserByID(uint(id))
+	user, err := c.service.GetUserByID(ctx.Request.Context(), uint(id))
 	if err != nil {
-		if err.Error() == "user not found" {
-			c.fail(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		c.fail(ctx, http.StatusInternalServerError, "Failed to fetch user")
+		respondError(ctx, err)
 		return
 	}
 
@@ -89,18 +85,13 @@ func (c *UserController) GetUserByID(ctx *gin.Context) {
 // @Router       /users [post]
 func (c *UserController) CreateUser(ctx *gin.Context) {
 	var input services.CreateUserInput
-	if err := ctx.ShouldBindJSON(&input); err != nil {
-		c.fail(ctx, http.StatusBadRequest, err.Error())
+	if !bindJSON(ctx, &input) {
 		return
 	}
 
-	user, err := c.service.CreateUser(input)
+	user, err := c.service.CreateUser(ctx.Request.Context(), input)
 	if err != nil {
-		if err.Error() == "email already registered" {
-			c.fail(ctx, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
-		c.fail(ctx, http.StatusInternalServerError, "Failed to create user")
+		respondError(ctx, err)
 		return
 	}
 
@@ -127,21 +118,13 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 	}
 
 	var input services.UpdateUserInput
-	if err := ctx.ShouldBindJSON(&input); err != nil {
-		c.fail(ctx, http.StatusBadRequest, err.Error())
+	if !bindJSON(ctx, &input) {
 		return
 	}
 
-	user, err := c.service.UpdateUser(uint(id), input)
+	user, err := c.service.UpdateUser(ctx.Request.Context(), uint(id), input)
 	if err != nil {
-		switch err.Error() {
-		case "user not found":
-			c.fail(ctx, http.StatusNotFound, err.Error())
-		case "email already used by another user":
-			c.fail(ctx, http.StatusUnprocessableEntity, err.Error())
-		default:
-			c.fail(ctx, http.StatusInternalServerError, "Failed to update user")
-		}
+		respondError(ctx, err)
 		return
 	}
 
@@ -164,12 +147,8 @@ func (c *UserController) DeleteUser(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.service.DeleteUser(uint(id)); err != nil {
-		if err.Error() == "user not found" {
-			c.fail(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		c.fail(ctx, http.StatusInternalServerError, "Failed to delete user")
+	if err := c.service.DeleteUser(ctx.Request.Context(), uint(id)); err != nil {
+		respondError(ctx, err)
 		return
 	}
 
@@ -195,13 +174,9 @@ func (c *UserController) GetProfile(ctx *gin.Context) {
 		return
 	}
 
-	user, err := c.service.GetProfile(userID.(uint))
+	user, err := c.service.GetProfile(ctx.Request.Context(), userID.(uint))
 	if err != nil {
-		if err.Error() == "user not found" {
-			c.fail(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		c.fail(ctx, http.StatusInternalServerError, "Failed to fetch profile")
+		respondError(ctx, err)
 		return
 	}
 
@@ -228,21 +203,13 @@ func (c *UserController) UpdateProfile(ctx *gin.Context) {
 	}
 
 	var input services.UpdateProfileInput
-	if err := ctx.ShouldBindJSON(&input); err != nil {
-		c.fail(ctx, http.StatusBadRequest, err.Error())
+	if !bindJSON(ctx, &input) {
 		return
 	}
 
-	user, err := c.service.UpdateProfile(userID.(uint), input)
+	user, err := c.service.UpdateProfile(ctx.Request.Context(), userID.(uint), input)
 	if err != nil {
-		switch err.Error() {
-		case "user not found":
-			c.fail(ctx, http.StatusNotFound, err.Error())
-		case "email already used by another user":
-			c.fail(ctx, http.StatusUnprocessableEntity, err.Error())
-		default:
-			c.fail(ctx, http.StatusInternalServerError, "Failed to update profile")
-		}
+		respondError(ctx, err)
 		return
 	}
 
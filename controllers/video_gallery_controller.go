@@ -1,7 +1,9 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"path/filepath"
 	"strconv"
@@ -12,67 +14,41 @@ import (
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/services"
 	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
 )
 
+// VideoGalleryForm field form untuk membuat video gallery
+type VideoGalleryForm struct {
+	Title       string                `form:"title" binding:"required"`
+	Description string                `form:"description"`
+	Category    string                `form:"category" binding:"required"`
+	Date        string                `form:"date" binding:"required,datetime=2006-01-02"`
+	IsActive    string                `form:"is_active" binding:"omitempty,boolean"`
+	Thumbnail   *multipart.FileHeader `form:"thumbnail" binding:"required"`
+	Video       *multipart.FileHeader `form:"video" binding:"required"`
+}
+
 type VideoGalleryController struct {
 	videoGalleryService services.VideoGalleryService
+	translationService  services.TranslationService
 }
 
-func NewVideoGalleryController(videoGalleryService services.VideoGalleryService) *VideoGalleryController {
+func NewVideoGalleryController(videoGalleryService services.VideoGalleryService, translationService services.TranslationService) *VideoGalleryController {
 	return &VideoGalleryController{
 		videoGalleryService: videoGalleryService,
+		translationService:  translationService,
 	}
 }
 
 func (ctrl *VideoGalleryController) Create(c *gin.Context) {
-	// Ambil field dari form
-	title := c.PostForm("title")
-	description := c.PostForm("description")
-	category := c.PostForm("category")
-	date := c.PostForm("date")
-	isActive := c.PostForm("is_active")
-
-	// Validasi field wajib
-	if title == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Title is required",
-		})
-		return
-	}
-
-	if category == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Category is required",
-		})
-		return
-	}
-
-	if date == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Date is required",
-		})
-		return
-	}
-
-	// Parse date (format: YYYY-MM-DD)
-	dateTime, err := time.Parse("2006-01-02", date)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid date format. Use YYYY-MM-DD",
-			"error":   err.Error(),
-		})
-		return
-	}
-
-	// Upload thumbnail
-	thumbnailFile, err := c.FormFile("thumbnail")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Thumbnail is required",
-			"error":   err.Error(),
-		})
+	// Bind & validasi field form, thumbnail & video wajib diupload
+	var form VideoGalleryForm
+	if !bindForm(c, &form) {
 		return
 	}
+	title, description, category, isActive := form.Title, form.Description, form.Category, form.IsActive
+	dateTime, _ := time.Parse(time.DateOnly, form.Date)
+	thumbnailFile, videoFile := form.Thumbnail, form.Video
 
 	// Generate unique filename untuk thumbnail
 	thumbnailExt := filepath.Ext(thumbnailFile.Filename)
@@ -80,21 +56,8 @@ func (ctrl *VideoGalleryController) Create(c *gin.Context) {
 	thumbnailPath := filepath.Join("uploads", "thumbnails", thumbnailName)
 
 	// Simpan thumbnail
-	if err := c.SaveUploadedFile(thumbnailFile, thumbnailPath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to save thumbnail",
-			"error":   err.Error(),
-		})
-		return
-	}
-
-	// Upload video
-	videoFile, err := c.FormFile("video")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Video is required",
-			"error":   err.Error(),
-		})
+	if err := storeUpload(c, thumbnailFile, thumbnailPath); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -104,25 +67,15 @@ func (ctrl *VideoGalleryController) Create(c *gin.Context) {
 	videoPath := filepath.Join("uploads", "videos", videoName)
 
 	// Simpan video
-	if err := c.SaveUploadedFile(videoFile, videoPath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to save video",
-			"error":   err.Error(),
-		})
+	if err := storeUpload(c, videoFile, videoPath); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	// Parse is_active (default true jika tidak ada)
+	// Parse is_active (default true jika tidak ada), format sudah divalidasi saat bind
 	isActiveBool := true
 	if isActive != "" {
-		isActiveBool, err = strconv.ParseBool(isActive)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "Invalid is_active format",
-				"error":   err.Error(),
-			})
-			return
-		}
+		isActiveBool, _ = strconv.ParseBool(isActive)
 	}
 
 	payload := models.VideoGallery{
@@ -135,12 +88,9 @@ func (ctrl *VideoGalleryController) Create(c *gin.Context) {
 		IsActive:    isActiveBool,
 	}
 
-	videoGallery, err := ctrl.videoGalleryService.Create(payload)
+	videoGallery, err := ctrl.videoGalleryService.Create(c.Request.Context(), payload)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to create video gallery",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -154,10 +104,7 @@ func (ctrl *VideoGalleryController) FindAll(c *gin.Context) {
 	var params utils.PaginationParams
 
 	// Bind query parameters
-	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bindQuery(c, &params) {
 		return
 	}
 
@@ -169,14 +116,14 @@ func (ctrl *VideoGalleryController) FindAll(c *gin.Context) {
 		params.Limit = 10
 	}
 
-	data, total, err := ctrl.videoGalleryService.FindAll(params)
+	data, total, err := ctrl.videoGalleryService.FindAll(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch video galleries",
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatableVideoGallery, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 		"pagination": gin.H{
@@ -188,14 +135,14 @@ func (ctrl *VideoGalleryController) FindAll(c *gin.Context) {
 }
 
 func (ctrl *VideoGalleryController) FindAllActive(c *gin.Context) {
-	data, err := ctrl.videoGalleryService.FindAllActive()
+	data, err := ctrl.videoGalleryService.FindAllActive(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch active video galleries",
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizePublicResponse(c, ctrl.translationService, services.TranslatableVideoGallery, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
@@ -207,10 +154,7 @@ func (ctrl *VideoGalleryController) FindByCategory(c *gin.Context) {
 	var params utils.PaginationParams
 
 	// Bind query parameters
-	if err := c.ShouldBindQuery(&params); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+	if !bindQuery(c, &params) {
 		return
 	}
 
@@ -222,14 +166,14 @@ func (ctrl *VideoGalleryController) FindByCategory(c *gin.Context) {
 		params.Limit = 10
 	}
 
-	data, total, err := ctrl.videoGalleryService.FindByCategory(category, params)
+	data, total, err := ctrl.videoGalleryService.FindByCategory(c.Request.Context(), category, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch video galleries by category",
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizePublicResponse(c, ctrl.translationService, services.TranslatableVideoGallery, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 		"pagination": gin.H{
@@ -242,11 +186,9 @@ func (ctrl *VideoGalleryController) FindByCategory(c *gin.Context) {
 }
 
 func (ctrl *VideoGalleryController) FindAllCategories(c *gin.Context) {
-	data, err := ctrl.videoGalleryService.FindAllCategories()
+	data, err := ctrl.videoGalleryService.FindAllCategories(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch categories",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -259,22 +201,18 @@ func (ctrl *VideoGalleryController) FindByID(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
-	data, err := ctrl.videoGalleryService.FindByID(uint(uint64Val))
+	data, err := ctrl.videoGalleryService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Video gallery not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
+	localizeResponse(c, ctrl.translationService, services.TranslatableVideoGallery, &data)
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
@@ -285,20 +223,14 @@ func (ctrl *VideoGalleryController) Update(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah video gallery exist
-	existingVideoGallery, err := ctrl.videoGalleryService.FindByID(uint(uint64Val))
+	existingVideoGallery, err := ctrl.videoGalleryService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Video gallery not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -333,11 +265,8 @@ func (ctrl *VideoGalleryController) Update(c *gin.Context) {
 		thumbnailPath := filepath.Join("uploads", "thumbnails", thumbnailName)
 
 		// Simpan thumbnail
-		if err := c.SaveUploadedFile(thumbnailFile, thumbnailPath); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"message": "Failed to save thumbnail",
-				"error":   err.Error(),
-			})
+		if err := storeUpload(c, thumbnailFile, thumbnailPath); err != nil {
+			respondError(c, err)
 			return
 		}
 		thumbnailURL = "/" + thumbnailPath
@@ -352,11 +281,8 @@ func (ctrl *VideoGalleryController) Update(c *gin.Context) {
 		videoPath := filepath.Join("uploads", "videos", videoName)
 
 		// Simpan video
-		if err := c.SaveUploadedFile(videoFile, videoPath); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"message": "Failed to save video",
-				"error":   err.Error(),
-			})
+		if err := storeUpload(c, videoFile, videoPath); err != nil {
+			respondError(c, err)
 			return
 		}
 		videoURL = "/" + videoPath
@@ -367,10 +293,7 @@ func (ctrl *VideoGalleryController) Update(c *gin.Context) {
 	if date != "" {
 		dateTime, err = time.Parse("2006-01-02", date)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "Invalid date format. Use YYYY-MM-DD",
-				"error":   err.Error(),
-			})
+			respondInvalidParam(c, "date", "datetime", "2006-01-02")
 			return
 		}
 	}
@@ -380,10 +303,7 @@ func (ctrl *VideoGalleryController) Update(c *gin.Context) {
 	if isActive != "" {
 		isActiveBool, err = strconv.ParseBool(isActive)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "Invalid is_active format",
-				"error":   err.Error(),
-			})
+			respondInvalidParam(c, "is_active", "boolean", "")
 			return
 		}
 	}
@@ -391,6 +311,7 @@ func (ctrl *VideoGalleryController) Update(c *gin.Context) {
 	// 4. Buat payload untuk update
 	payload := models.VideoGallery{
 		ID:          uint(uint64Val),
+		SortOrder:   existingVideoGallery.SortOrder,
 		Title:       title,
 		Description: description,
 		Thumbnail:   thumbnailURL,
@@ -401,12 +322,9 @@ func (ctrl *VideoGalleryController) Update(c *gin.Context) {
 	}
 
 	// 5. Update ke database
-	data, err := ctrl.videoGalleryService.Update(payload)
+	data, err := ctrl.videoGalleryService.Update(c.Request.Context(), payload)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to update video gallery",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -421,30 +339,21 @@ func (ctrl *VideoGalleryController) Delete(c *gin.Context) {
 	id := c.Param("id")
 	uint64Val, err := strconv.ParseUint(id, 10, 0)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "Invalid ID format",
-			"error":   err.Error(),
-		})
+		respondInvalidParam(c, "id", "number", "")
 		return
 	}
 
 	// 2. Cek apakah video gallery exist
-	existingVideoGallery, err := ctrl.videoGalleryService.FindByID(uint(uint64Val))
+	existingVideoGallery, err := ctrl.videoGalleryService.FindByID(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"message": "Video gallery not found",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
 	// 3. Soft delete video gallery (set is_deleted = true)
-	err = ctrl.videoGalleryService.Delete(uint(uint64Val))
+	err = ctrl.videoGalleryService.Delete(c.Request.Context(), uint(uint64Val))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "Failed to delete video gallery",
-			"error":   err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -455,4 +364,32 @@ func (ctrl *VideoGalleryController) Delete(c *gin.Context) {
 			"title": existingVideoGallery.Title,
 		},
 	})
-}
\ No newline at end of file
+}
+
+func (ctrl *VideoGalleryController) Reorder(c *gin.Context) {
+	// 1. Bind daftar ID sesuai urutan baru
+	ids, ok := bindReorderRequest(c)
+	if !ok {
+		return
+	}
+
+	// 2. Simpan urutan baru (atomic, rollback jika ada ID tidak valid)
+	if err := ctrl.videoGalleryService.Reorder(c.Request.Context(), ids); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"message": "One or more video galleries not found",
+			})
+			return
+		}
+
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Video galleries reordered successfully",
+		"data": gin.H{
+			"ids": ids,
+		},
+	})
+}
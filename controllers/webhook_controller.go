@@ -0,0 +1,257 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/services"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+type WebhookRequest struct {
+	Name   string   `json:"name"`
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events" binding:"required,min=1"`
+	// Secret opsional saat create, dibuat otomatis jika kosong
+	Secret   string `json:"secret"`
+	IsActive *bool  `json:"is_active"`
+}
+
+type WebhookController struct {
+	webhookService services.WebhookService
+}
+
+func NewWebhookController(webhookService services.WebhookService) *WebhookController {
+	return &WebhookController{
+		webhookService: webhookService,
+	}
+}
+
+// findSubscription ambil :id dari URL dan memastikan subscription ada
+func (ctrl *WebhookController) findSubscription(c *gin.Context) (models.WebhookSubscription, bool) {
+	id, ok := parseUintParam(c, "id")
+	if !ok {
+		return models.WebhookSubscription{}, false
+	}
+
+	subscription, err := ctrl.webhookService.FindByID(id)
+	if err != nil {
+		respondError(c, err)
+		return models.WebhookSubscription{}, false
+	}
+
+	return subscription, true
+}
+
+// Events daftar event yang bisa di-subscribe
+func (ctrl *WebhookController) Events(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"data": services.WebhookEvents,
+	})
+}
+
+func (ctrl *WebhookController) FindAll(c *gin.Context) {
+	params := utils.GetPaginationParams(c)
+
+	data, total, err := ctrl.webhookService.FindAll(params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"pagination": gin.H{
+			"page":  params.Page,
+			"limit": params.Limit,
+			"total": total,
+		},
+	})
+}
+
+func (ctrl *WebhookController) FindByID(c *gin.Context) {
+	subscription, ok := ctrl.findSubscription(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": subscription,
+	})
+}
+
+// Create secret hanya ditampilkan sekali di response ini (dan saat rotate)
+func (ctrl *WebhookController) Create(c *gin.Context) {
+	var req WebhookRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	payload := models.WebhookSubscription{
+		Name:     req.Name,
+		URL:      req.URL,
+		Secret:   req.Secret,
+		Events:   req.Events,
+		IsActive: req.IsActive == nil || *req.IsActive,
+	}
+
+	subscription, err := ctrl.webhookService.Create(payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":    subscription,
+		"secret":  subscription.Secret,
+		"message": "Webhook created successfully",
+	})
+}
+
+// Update secret tidak bisa diubah di sini, gunakan rotate-secret
+func (ctrl *WebhookController) Update(c *gin.Context) {
+	existing, ok := ctrl.findSubscription(c)
+	if !ok {
+		return
+	}
+
+	var req WebhookRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	payload := existing
+	payload.Name = req.Name
+	payload.URL = req.URL
+	payload.Events = req.Events
+	if req.IsActive != nil {
+		payload.IsActive = *req.IsActive
+	}
+
+	data, err := ctrl.webhookService.Update(payload)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"message": "Webhook updated successfully",
+	})
+}
+
+func (ctrl *WebhookController) Delete(c *gin.Context) {
+	existing, ok := ctrl.findSubscription(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.webhookService.Delete(existing.ID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook deleted successfully",
+		"data": gin.H{
+			"id":  existing.ID,
+			"url": existing.URL,
+		},
+	})
+}
+
+func (ctrl *WebhookController) RotateSecret(c *gin.Context) {
+	existing, ok := ctrl.findSubscription(c)
+	if !ok {
+		return
+	}
+
+	data, err := ctrl.webhookService.RotateSecret(existing.ID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    data,
+		"secret":  data.Secret,
+		"message": "Webhook secret rotated successfully",
+	})
+}
+
+// FindDeliveries log delivery subscription, filter opsional ?status=
+func (ctrl *WebhookController) FindDeliveries(c *gin.Context) {
+	subscription, ok := ctrl.findSubscription(c)
+	if !ok {
+		return
+	}
+
+	params := utils.GetPaginationParams(c)
+
+	data, total, err := ctrl.webhookService.FindDeliveries(subscription.ID, params, c.Query("status"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"pagination": gin.H{
+			"page":  params.Page,
+			"limit": params.Limit,
+			"total": total,
+		},
+	})
+}
+
+func (ctrl *WebhookController) FindDeliveryByID(c *gin.Context) {
+	subscription, ok := ctrl.findSubscription(c)
+	if !ok {
+		return
+	}
+
+	deliveryID, ok := parseUintParam(c, "deliveryId")
+	if !ok {
+		return
+	}
+
+	data, err := ctrl.webhookService.FindDeliveryByID(subscription.ID, deliveryID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+// Redeliver kirim ulang payload delivery lama sebagai delivery baru
+func (ctrl *WebhookController) Redeliver(c *gin.Context) {
+	subscription, ok := ctrl.findSubscription(c)
+	if !ok {
+		return
+	}
+
+	deliveryID, ok := parseUintParam(c, "deliveryId")
+	if !ok {
+		return
+	}
+
+	if _, err := ctrl.webhookService.FindDeliveryByID(subscription.ID, deliveryID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data, err := ctrl.webhookService.Redeliver(subscription.ID, deliveryID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"data":    data,
+		"message": "Webhook redelivery queued",
+	})
+}
@@ -0,0 +1,193 @@
+package cors
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AnyOrigin entri allowlist yang mengizinkan semua origin (tanpa credentials)
+const AnyOrigin = "*"
+
+// Nama policy bawaan
+const (
+	PolicyPublic = "public"
+	PolicyAdmin  = "admin"
+)
+
+// Policy aturan CORS untuk satu kelompok route
+type Policy struct {
+	// AllowedOrigins origin persis (https://admin.example.com), wildcard subdomain
+	// (https://*.example.com) atau "*" untuk semua origin
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+	// AllowCredentials hanya berlaku untuk origin yang cocok dengan entri persis / wildcard subdomain,
+	// origin yang hanya cocok dengan "*" tidak pernah mendapat credentials
+	AllowCredentials bool `yaml:"allowCredentials"`
+	// MaxAge lama browser boleh cache hasil preflight, 0 berarti tidak dikirim
+	MaxAge time.Duration `yaml:"maxAge"`
+	// Paths prefix path yang memakai policy ini, kosong berarti policy default
+	Paths []string `yaml:"paths"`
+}
+
+// Match hasil pencocokan origin
+type Match int
+
+const (
+	// NotAllowed origin tidak ada di allowlist
+	NotAllowed Match = iota
+	// AllowedAny origin hanya cocok dengan "*"
+	AllowedAny
+	// AllowedExplicit origin cocok dengan entri persis / wildcard subdomain
+	AllowedExplicit
+)
+
+// Matcher allowlist origin yang sudah dinormalisasi
+type Matcher struct {
+	any      bool
+	exact    map[string]bool
+	suffixes []wildcardOrigin
+}
+
+// wildcardOrigin https://*.example.com → scheme https, suffix .example.com
+type wildcardOrigin struct {
+	scheme string
+	suffix string
+}
+
+// NewMatcher validasi dan normalisasi allowlist origin
+func NewMatcher(origins []string) (*Matcher, error) {
+	m := &Matcher{exact: make(map[string]bool)}
+	for _, origin := range origins {
+		origin = strings.TrimSpace(origin)
+		if origin == AnyOrigin {
+			m.any = true
+			continue
+		}
+
+		scheme, host, err := splitOrigin(origin)
+		if err != nil {
+			return nil, err
+		}
+		if suffix, ok := strings.CutPrefix(host, "*."); ok {
+			if suffix == "" || strings.Contains(suffix, "*") {
+				return nil, fmt.Errorf("invalid wildcard origin %q", origin)
+			}
+			m.suffixes = append(m.suffixes, wildcardOrigin{scheme, "." + suffix})
+			continue
+		}
+		if strings.Contains(host, "*") {
+			return nil, fmt.Errorf("invalid origin %q, wildcard is only allowed as the first label", origin)
+		}
+		m.exact[scheme+"://"+host] = true
+	}
+	return m, nil
+}
+
+// Match cocokkan header Origin dari request
+func (m *Matcher) Match(origin string) Match {
+	scheme, host, err := splitOrigin(origin)
+	if err != nil {
+		return NotAllowed
+	}
+
+	if m.exact[scheme+"://"+host] {
+		return AllowedExplicit
+	}
+	for _, wildcard := range m.suffixes {
+		// Hanya subdomain, domain utama (example.com) harus didaftarkan terpisah
+		if scheme == wildcard.scheme && strings.HasSuffix(host, wildcard.suffix) && len(host) > len(wildcard.suffix) {
+			return AllowedExplicit
+		}
+	}
+	if m.any {
+		return AllowedAny
+	}
+	return NotAllowed
+}
+
+// splitOrigin normalisasi origin menjadi scheme & host[:port] huruf kecil.
+// Origin tidak boleh berisi path, query, atau user info.
+func splitOrigin(origin string) (string, string, error) {
+	parsed, err := url.Parse(strings.ToLower(strings.TrimSpace(origin)))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid origin %q: %w", origin, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" ||
+		parsed.User != nil || strings.TrimSuffix(parsed.Path, "/") != "" || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", "", fmt.Errorf("invalid origin %q, expected scheme://host[:port]", origin)
+	}
+	return parsed.Scheme, parsed.Host, nil
+}
+
+// Router pilih policy berdasarkan prefix path terpanjang
+type Router struct {
+	routes        []route
+	defaultPolicy *compiledPolicy
+}
+
+type route struct {
+	prefix string
+	policy *compiledPolicy
+}
+
+type compiledPolicy struct {
+	Policy
+	name    string
+	matcher *Matcher
+}
+
+// NewRouter compile semua policy. Policy tanpa Paths menjadi default,
+// maksimal satu policy boleh tanpa Paths.
+func NewRouter(policies map[string]Policy) (*Router, error) {
+	router := &Router{}
+
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		policy := policies[name]
+		matcher, err := NewMatcher(policy.AllowedOrigins)
+		if err != nil {
+			return nil, fmt.Errorf("cors policy %s: %w", name, err)
+		}
+		compiled := &compiledPolicy{policy, name, matcher}
+
+		if len(policy.Paths) == 0 {
+			if router.defaultPolicy != nil {
+				return nil, fmt.Errorf("cors policies %s and %s both have no paths, only one default policy is allowed", router.defaultPolicy.name, name)
+			}
+			router.defaultPolicy = compiled
+			continue
+		}
+		for _, prefix := range policy.Paths {
+			router.routes = append(router.routes, route{strings.TrimSuffix(prefix, "/"), compiled})
+		}
+	}
+
+	// Prefix terpanjang dicek lebih dulu
+	sort.SliceStable(router.routes, func(i, j int) bool {
+		return len(router.routes[i].prefix) > len(router.routes[j].prefix)
+	})
+	return router, nil
+}
+
+// Resolve policy untuk path dan hasil pencocokan origin.
+// ok false jika tidak ada policy untuk path tersebut.
+func (r *Router) Resolve(path string, origin string) (policy Policy, match Match, ok bool) {
+	compiled := r.defaultPolicy
+	for _, route := range r.routes {
+		if path == route.prefix || strings.HasPrefix(path, route.prefix+"/") {
+			compiled = route.policy
+			break
+		}
+	}
+	if compiled == nil {
+		return Policy{}, NotAllowed, false
+	}
+	return compiled.Policy, compiled.matcher.Match(origin), true
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"fmt"
+
+	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
+)
+
+// backfillSlugs mengisi slug untuk data yang dibuat sebelum kolom slug ada (dari kolom title).
+// Error apa pun menggagalkan migrasi supaya tidak tercatat sudah dijalankan.
+func backfillSlugs(tx *gorm.DB) error {
+	for _, table := range []string{"programs", "services", "galleries"} {
+		if err := backfillTableSlugs(tx, table); err != nil {
+			return fmt.Errorf("backfill %s slugs: %w", table, err)
+		}
+	}
+	return nil
+}
+
+func backfillTableSlugs(tx *gorm.DB, table string) error {
+	var rows []struct {
+		ID    uint
+		Title string
+	}
+	if err := tx.Table(table).Select("id, title").Where("slug IS NULL OR slug = ''").Order("id ASC").Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		base := utils.Slugify(row.Title)
+		if base == "" {
+			base = fmt.Sprintf("%s-%d", table, row.ID)
+		}
+
+		slug := base
+		for i := 2; ; i++ {
+			var count int64
+			if err := tx.Table(table).Where("slug = ? AND id <> ?", slug, row.ID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				break
+			}
+			slug = fmt.Sprintf("%s-%d", base, i)
+		}
+
+		if err := tx.Table(table).Where("id = ?", row.ID).Update("slug", slug).Error; err != nil {
+			return fmt.Errorf("id %d: %w", row.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// RunCLI menjalankan subcommand `migrate up|down [steps]|status`
+func RunCLI(db *gorm.DB, args []string, logger *slog.Logger) error {
+	migrations, err := All()
+	if err != nil {
+		return err
+	}
+	migrator := NewMigrator(db, migrations, logger)
+
+	command := "status"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		done, err := migrator.Up()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migration(s)\n", len(done))
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid steps %q", args[1])
+			}
+		}
+
+		done, err := migrator.Down(steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Rolled back %d migration(s)\n", len(done))
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if status.Missing {
+				state += " (missing file)"
+			}
+			fmt.Printf("%04d_%-30s %s\n", status.Version, status.Name, state)
+		}
+
+	default:
+		return fmt.Errorf("unknown migrate command %q, use up, down [steps] or status", command)
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// lockKey key pg_advisory_lock supaya hanya satu instance yang menjalankan migrasi
+const lockKey = 7_240_301
+
+// Migration satu versi perubahan skema. Up/Down dijalankan di dalam transaksi.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// SchemaMigration baris pada tabel schema_migrations (versi yang sudah dijalankan)
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Status status satu migrasi untuk perintah `migrate status`
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	Missing   bool // tercatat di database tapi file migrasinya tidak ada
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	logger     *slog.Logger
+}
+
+func NewMigrator(db *gorm.DB, migrations []Migration, logger *slog.Logger) *Migrator {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	return &Migrator{db, sorted, logger}
+}
+
+// withLock menjalankan fn di satu koneksi yang memegang advisory lock
+func (m *Migrator) withLock(fn func(conn *gorm.DB) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", lockKey).Error; err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", lockKey)
+
+		if err := conn.AutoMigrate(&SchemaMigration{}); err != nil {
+			return fmt.Errorf("create schema_migrations: %w", err)
+		}
+
+		return fn(conn)
+	})
+}
+
+func appliedVersions(conn *gorm.DB) (map[int64]SchemaMigration, error) {
+	var rows []SchemaMigration
+	if err := conn.Order("version ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]SchemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// Up menjalankan semua migrasi yang belum dijalankan sesuai urutan versi
+func (m *Migrator) Up() ([]Migration, error) {
+	var done []Migration
+
+	err := m.withLock(func(conn *gorm.DB) error {
+		applied, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			m.logger.Info("migrate up", "version", migration.Version, "name", migration.Name)
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := migration.Up(tx); err != nil {
+					return err
+				}
+				return tx.Create(&SchemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					AppliedAt: time.Now(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			done = append(done, migration)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Down rollback sejumlah steps migrasi terakhir yang sudah dijalankan
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	var done []Migration
+
+	err := m.withLock(func(conn *gorm.DB) error {
+		applied, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("migration %04d_%s has no down migration", migration.Version, migration.Name)
+			}
+
+			m.logger.Info("migrate down", "version", migration.Version, "name", migration.Name)
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := migration.Down(tx); err != nil {
+					return err
+				}
+				return tx.Where("version = ?", migration.Version).Delete(&SchemaMigration{}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			done = append(done, migration)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Pending jumlah migrasi yang belum dijalankan. Tidak memakai advisory lock
+// supaya aman dipanggil berulang oleh readiness probe.
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	applied, err := appliedVersions(m.db.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// Status daftar semua migrasi beserta status sudah/belum dijalankan
+func (m *Migrator) Status() ([]Status, error) {
+	var result []Status
+
+	err := m.withLock(func(conn *gorm.DB) error {
+		applied, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		known := make(map[int64]bool, len(m.migrations))
+		for _, migration := range m.migrations {
+			known[migration.Version] = true
+
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if row, ok := applied[migration.Version]; ok {
+				status.Applied = true
+				status.AppliedAt = &row.AppliedAt
+			}
+			result = append(result, status)
+		}
+
+		for version, row := range applied {
+			if !known[version] {
+				appliedAt := row.AppliedAt
+				result = append(result, Status{
+					Version:   version,
+					Name:      row.Name,
+					Applied:   true,
+					AppliedAt: &appliedAt,
+					Missing:   true,
+				})
+			}
+		}
+
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].Version < result[j].Version
+		})
+		return nil
+	})
+
+	return result, err
+}
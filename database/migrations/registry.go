@@ -0,0 +1,100 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// goMigrations migrasi yang butuh logic Go (misal backfill data).
+// Versi tidak boleh bentrok dengan file SQL.
+var goMigrations = []Migration{
+	{
+		Version: 7,
+		Name:    "backfill_slugs",
+		Up:      backfillSlugs,
+		Down:    func(tx *gorm.DB) error { return nil },
+	},
+}
+
+// All seluruh migrasi (SQL + Go) terurut berdasarkan versi.
+// File SQL memakai format sql/<versi>_<nama>.up.sql dan sql/<versi>_<nama>.down.sql
+func All() ([]Migration, error) {
+	entries, err := sqlFiles.ReadDir("sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		name := entry.Name()
+
+		base, direction, ok := strings.Cut(strings.TrimSuffix(name, ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("invalid migration file name %s", name)
+		}
+		versionStr, title, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name %s", name)
+		}
+		version, err := strconv.ParseInt(versionStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", name, err)
+		}
+
+		content, err := sqlFiles.ReadFile(path.Join("sql", name))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: title}
+			byVersion[version] = migration
+		}
+		if migration.Name != title {
+			return nil, fmt.Errorf("migration version %d used by %s and %s", version, migration.Name, title)
+		}
+
+		if direction == "up" {
+			migration.Up = execSQL(string(content))
+		} else {
+			migration.Down = execSQL(string(content))
+		}
+	}
+
+	for _, migration := range goMigrations {
+		if existing, exists := byVersion[migration.Version]; exists {
+			return nil, fmt.Errorf("migration version %d used by %s and %s", migration.Version, existing.Name, migration.Name)
+		}
+		m := migration
+		byVersion[m.Version] = &m
+	}
+
+	result := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == nil {
+			return nil, fmt.Errorf("migration %04d_%s has no up migration", migration.Version, migration.Name)
+		}
+		result = append(result, *migration)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+
+	return result, nil
+}
+
+func execSQL(query string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		return tx.Exec(query).Error
+	}
+}
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS video_galleries;
+DROP TABLE IF EXISTS flyer_galleries;
+DROP TABLE IF EXISTS galleries;
+DROP TABLE IF EXISTS features;
+DROP TABLE IF EXISTS portfolios;
+DROP TABLE IF EXISTS services;
+DROP TABLE IF EXISTS registrations;
+DROP TABLE IF EXISTS programs;
+DROP TABLE IF EXISTS heros;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,133 @@
+-- Skema awal (sebelumnya dibuat oleh AutoMigrate).
+-- Memakai IF NOT EXISTS supaya database lama yang sudah berisi tabel bisa langsung diadopsi.
+
+CREATE TABLE IF NOT EXISTS users (
+    id bigserial PRIMARY KEY,
+    name text,
+    email text CONSTRAINT uni_users_email UNIQUE,
+    password text,
+    phone text
+);
+
+CREATE TABLE IF NOT EXISTS heros (
+    id bigserial PRIMARY KEY,
+    src text,
+    alt text,
+    title text,
+    description text
+);
+
+CREATE TABLE IF NOT EXISTS programs (
+    id bigserial PRIMARY KEY,
+    icon varchar(100),
+    title varchar(255),
+    duration varchar(50),
+    participants varchar(100),
+    level varchar(50),
+    description text,
+    benefits text[],
+    image varchar(255),
+    is_deleted boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS registrations (
+    id bigserial PRIMARY KEY,
+    name text,
+    email text CONSTRAINT uni_registrations_email UNIQUE,
+    phone text,
+    company text,
+    position text,
+    program_id bigint CONSTRAINT fk_programs_registration REFERENCES programs (id),
+    participants int,
+    preferred_date date,
+    message text,
+    status varchar(20) DEFAULT 'pending',
+    is_deleted boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_registrations_program_id ON registrations (program_id);
+
+CREATE TABLE IF NOT EXISTS services (
+    id bigserial PRIMARY KEY,
+    icon varchar(255) NOT NULL,
+    title varchar(255) NOT NULL,
+    description text NOT NULL,
+    color varchar(50) NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    is_deleted boolean DEFAULT false
+);
+CREATE INDEX IF NOT EXISTS idx_services_deleted_at ON services (deleted_at);
+
+CREATE TABLE IF NOT EXISTS portfolios (
+    id bigserial PRIMARY KEY,
+    title varchar(255) NOT NULL,
+    count varchar(50) NOT NULL,
+    description text NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    is_deleted boolean DEFAULT false
+);
+CREATE INDEX IF NOT EXISTS idx_portfolios_deleted_at ON portfolios (deleted_at);
+
+CREATE TABLE IF NOT EXISTS features (
+    id bigserial PRIMARY KEY,
+    icon varchar(255) NOT NULL,
+    title varchar(255) NOT NULL,
+    description text NOT NULL,
+    sort_order int DEFAULT 0,
+    is_active boolean DEFAULT true,
+    is_deleted boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_features_deleted_at ON features (deleted_at);
+
+CREATE TABLE IF NOT EXISTS galleries (
+    id bigserial PRIMARY KEY,
+    title varchar(255) NOT NULL,
+    description text,
+    url varchar(500) NOT NULL,
+    date date NOT NULL,
+    is_active boolean DEFAULT true,
+    is_deleted boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_galleries_deleted_at ON galleries (deleted_at);
+
+CREATE TABLE IF NOT EXISTS flyer_galleries (
+    id bigserial PRIMARY KEY,
+    title varchar(255) NOT NULL,
+    image varchar(500) NOT NULL,
+    description text,
+    is_active boolean DEFAULT true,
+    is_deleted boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_flyer_galleries_deleted_at ON flyer_galleries (deleted_at);
+
+CREATE TABLE IF NOT EXISTS video_galleries (
+    id bigserial PRIMARY KEY,
+    title varchar(255) NOT NULL,
+    description text,
+    thumbnail varchar(500) NOT NULL,
+    video_url varchar(500) NOT NULL,
+    category varchar(100) NOT NULL,
+    date date NOT NULL,
+    is_active boolean DEFAULT true,
+    is_deleted boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_video_galleries_deleted_at ON video_galleries (deleted_at);
@@ -0,0 +1,6 @@
+ALTER TABLE flyer_galleries DROP COLUMN IF EXISTS sort_order;
+ALTER TABLE video_galleries DROP COLUMN IF EXISTS sort_order;
+ALTER TABLE galleries DROP COLUMN IF EXISTS sort_order;
+ALTER TABLE portfolios DROP COLUMN IF EXISTS sort_order;
+ALTER TABLE services DROP COLUMN IF EXISTS sort_order;
+ALTER TABLE heros DROP COLUMN IF EXISTS sort_order;
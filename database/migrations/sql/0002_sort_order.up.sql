@@ -0,0 +1,6 @@
+ALTER TABLE heros ADD COLUMN IF NOT EXISTS sort_order int DEFAULT 0;
+ALTER TABLE services ADD COLUMN IF NOT EXISTS sort_order int DEFAULT 0;
+ALTER TABLE portfolios ADD COLUMN IF NOT EXISTS sort_order int DEFAULT 0;
+ALTER TABLE galleries ADD COLUMN IF NOT EXISTS sort_order int DEFAULT 0;
+ALTER TABLE video_galleries ADD COLUMN IF NOT EXISTS sort_order int DEFAULT 0;
+ALTER TABLE flyer_galleries ADD COLUMN IF NOT EXISTS sort_order int DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE galleries DROP CONSTRAINT IF EXISTS fk_gallery_albums_images;
+DROP INDEX IF EXISTS idx_galleries_album_id;
+ALTER TABLE galleries DROP COLUMN IF EXISTS album_id;
+DROP TABLE IF EXISTS gallery_albums;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS gallery_albums (
+    id bigserial PRIMARY KEY,
+    title varchar(255) NOT NULL,
+    description text,
+    cover varchar(500),
+    event_date date NOT NULL,
+    program_id bigint CONSTRAINT fk_gallery_albums_program REFERENCES programs (id),
+    sort_order int DEFAULT 0,
+    is_active boolean DEFAULT true,
+    is_deleted boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_gallery_albums_program_id ON gallery_albums (program_id);
+CREATE INDEX IF NOT EXISTS idx_gallery_albums_deleted_at ON gallery_albums (deleted_at);
+
+ALTER TABLE galleries ADD COLUMN IF NOT EXISTS album_id bigint;
+CREATE INDEX IF NOT EXISTS idx_galleries_album_id ON galleries (album_id);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_gallery_albums_images') THEN
+        ALTER TABLE galleries
+            ADD CONSTRAINT fk_gallery_albums_images FOREIGN KEY (album_id) REFERENCES gallery_albums (id);
+    END IF;
+END $$;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS program_faqs;
+DROP TABLE IF EXISTS program_instructors;
+DROP TABLE IF EXISTS instructors;
+DROP TABLE IF EXISTS curriculum_lessons;
+DROP TABLE IF EXISTS curriculum_modules;
@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS curriculum_modules (
+    id bigserial PRIMARY KEY,
+    program_id bigint NOT NULL CONSTRAINT fk_programs_curriculum REFERENCES programs (id),
+    title varchar(255) NOT NULL,
+    description text,
+    sort_order int DEFAULT 0,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_curriculum_modules_program_id ON curriculum_modules (program_id);
+
+CREATE TABLE IF NOT EXISTS curriculum_lessons (
+    id bigserial PRIMARY KEY,
+    module_id bigint NOT NULL CONSTRAINT fk_curriculum_modules_lessons REFERENCES curriculum_modules (id),
+    title varchar(255) NOT NULL,
+    description text,
+    duration varchar(50),
+    sort_order int DEFAULT 0,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_curriculum_lessons_module_id ON curriculum_lessons (module_id);
+
+CREATE TABLE IF NOT EXISTS instructors (
+    id bigserial PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    title varchar(255),
+    bio text,
+    photo varchar(500),
+    is_deleted boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS program_instructors (
+    program_id bigint CONSTRAINT fk_programs_instructors REFERENCES programs (id),
+    instructor_id bigint CONSTRAINT fk_program_instructors_instructor REFERENCES instructors (id),
+    sort_order int DEFAULT 0,
+    PRIMARY KEY (program_id, instructor_id)
+);
+
+CREATE TABLE IF NOT EXISTS program_faqs (
+    id bigserial PRIMARY KEY,
+    program_id bigint NOT NULL CONSTRAINT fk_programs_fa_qs REFERENCES programs (id),
+    question text NOT NULL,
+    answer text NOT NULL,
+    sort_order int DEFAULT 0,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_program_faqs_program_id ON program_faqs (program_id);
@@ -0,0 +1,25 @@
+DROP TABLE IF EXISTS slug_redirects;
+
+DROP INDEX IF EXISTS idx_galleries_slug;
+ALTER TABLE galleries
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS og_image,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS meta_title,
+    DROP COLUMN IF EXISTS slug;
+
+DROP INDEX IF EXISTS idx_services_slug;
+ALTER TABLE services
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS og_image,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS meta_title,
+    DROP COLUMN IF EXISTS slug;
+
+DROP INDEX IF EXISTS idx_programs_slug;
+ALTER TABLE programs
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS og_image,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS meta_title,
+    DROP COLUMN IF EXISTS slug;
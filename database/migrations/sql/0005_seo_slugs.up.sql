@@ -0,0 +1,33 @@
+ALTER TABLE programs
+    ADD COLUMN IF NOT EXISTS slug varchar(255),
+    ADD COLUMN IF NOT EXISTS meta_title varchar(255),
+    ADD COLUMN IF NOT EXISTS meta_description varchar(500),
+    ADD COLUMN IF NOT EXISTS og_image varchar(500),
+    ADD COLUMN IF NOT EXISTS canonical_url varchar(500);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_programs_slug ON programs (slug) WHERE slug <> '';
+
+ALTER TABLE services
+    ADD COLUMN IF NOT EXISTS slug varchar(255),
+    ADD COLUMN IF NOT EXISTS meta_title varchar(255),
+    ADD COLUMN IF NOT EXISTS meta_description varchar(500),
+    ADD COLUMN IF NOT EXISTS og_image varchar(500),
+    ADD COLUMN IF NOT EXISTS canonical_url varchar(500);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_services_slug ON services (slug) WHERE slug <> '';
+
+ALTER TABLE galleries
+    ADD COLUMN IF NOT EXISTS slug varchar(255),
+    ADD COLUMN IF NOT EXISTS meta_title varchar(255),
+    ADD COLUMN IF NOT EXISTS meta_description varchar(500),
+    ADD COLUMN IF NOT EXISTS og_image varchar(500),
+    ADD COLUMN IF NOT EXISTS canonical_url varchar(500);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_galleries_slug ON galleries (slug) WHERE slug <> '';
+
+CREATE TABLE IF NOT EXISTS slug_redirects (
+    id bigserial PRIMARY KEY,
+    entity_type varchar(50) NOT NULL,
+    slug varchar(255) NOT NULL,
+    entity_id bigint NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_slug_redirects_entity_slug ON slug_redirects (entity_type, slug);
+CREATE INDEX IF NOT EXISTS idx_slug_redirects_entity_id ON slug_redirects (entity_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS translations (
+    id bigserial PRIMARY KEY,
+    entity_type varchar(50) NOT NULL,
+    entity_id bigint NOT NULL,
+    locale varchar(10) NOT NULL,
+    field varchar(50) NOT NULL,
+    value text NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_translations_entity_locale_field ON translations (entity_type, entity_id, locale, field);
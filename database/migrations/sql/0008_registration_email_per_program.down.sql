@@ -0,0 +1,5 @@
+-- Gagal jika sudah ada email yang terdaftar di lebih dari satu program
+DROP INDEX IF EXISTS idx_registrations_email_program;
+ALTER TABLE registrations ADD CONSTRAINT uni_registrations_email UNIQUE (email);
+-- registration_duplicates sengaja tidak dihapus: registrasi di dalamnya tetap di-soft delete
+-- dan tabel ini satu-satunya catatan registrasi mana yang dihapus oleh migration
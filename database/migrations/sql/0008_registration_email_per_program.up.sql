@@ -0,0 +1,44 @@
+-- Email registrasi unik per program (bukan global), hanya untuk registrasi yang belum dihapus.
+-- Nama constraint lama tergantung versi GORM yang membuat tabel.
+ALTER TABLE registrations DROP CONSTRAINT IF EXISTS uni_registrations_email;
+ALTER TABLE registrations DROP CONSTRAINT IF EXISTS registrations_email_key;
+DROP INDEX IF EXISTS idx_registrations_email;
+
+UPDATE registrations SET email = lower(trim(email)) WHERE email <> lower(trim(email));
+
+-- Email yang hanya beda huruf besar/kecil atau spasi menjadi duplikat setelah dinormalisasi.
+-- Registrasi terbaru per (email, program) dipertahankan, sisanya di-soft delete supaya
+-- unique index di bawah bisa dibuat (datanya tetap ada dengan is_deleted = true).
+-- Setiap registrasi yang di-soft delete dicatat di registration_duplicates bersama registrasi
+-- yang dipertahankan, supaya operator bisa memeriksa atau memulihkannya.
+CREATE TABLE IF NOT EXISTS registration_duplicates (
+    registration_id bigint PRIMARY KEY,
+    kept_registration_id bigint NOT NULL,
+    email text,
+    program_id bigint,
+    removed_at timestamptz NOT NULL DEFAULT now()
+);
+
+WITH duplicates AS (
+    SELECT id, kept_id, email, program_id
+    FROM (
+        SELECT id, email, program_id,
+            first_value(id) OVER newest AS kept_id,
+            row_number() OVER newest AS position
+        FROM registrations
+        WHERE is_deleted = false AND email IS NOT NULL
+        WINDOW newest AS (PARTITION BY lower(email), program_id ORDER BY created_at DESC, id DESC)
+    ) ranked
+    WHERE position > 1
+), audit AS (
+    INSERT INTO registration_duplicates (registration_id, kept_registration_id, email, program_id)
+    SELECT id, kept_id, email, program_id FROM duplicates
+    ON CONFLICT (registration_id) DO NOTHING
+)
+UPDATE registrations SET is_deleted = true
+FROM duplicates
+WHERE registrations.id = duplicates.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registrations_email_program
+    ON registrations (lower(email), program_id)
+    WHERE is_deleted = false;
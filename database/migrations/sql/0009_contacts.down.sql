@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_registrations_contact_id;
+ALTER TABLE registrations DROP COLUMN IF EXISTS contact_id;
+DROP TABLE IF EXISTS contacts;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,65 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id bigserial PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    normalized_name varchar(255) NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organizations_normalized_name ON organizations (normalized_name);
+
+CREATE TABLE IF NOT EXISTS contacts (
+    id bigserial PRIMARY KEY,
+    name varchar(255),
+    email varchar(255),
+    phone varchar(50),
+    normalized_phone varchar(50),
+    position varchar(255),
+    organization_id bigint CONSTRAINT fk_contacts_organization REFERENCES organizations (id),
+    merged_into_id bigint,
+    is_deleted boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_contacts_email ON contacts (email);
+CREATE INDEX IF NOT EXISTS idx_contacts_normalized_phone ON contacts (normalized_phone);
+CREATE INDEX IF NOT EXISTS idx_contacts_organization_id ON contacts (organization_id);
+CREATE INDEX IF NOT EXISTS idx_contacts_merged_into_id ON contacts (merged_into_id);
+
+ALTER TABLE registrations
+    ADD COLUMN IF NOT EXISTS contact_id bigint CONSTRAINT fk_contacts_registrations REFERENCES contacts (id);
+CREATE INDEX IF NOT EXISTS idx_registrations_contact_id ON registrations (contact_id);
+
+-- Backfill: satu organization per nama perusahaan, satu contact per email
+-- (data contact diambil dari registrasi terbaru)
+INSERT INTO organizations (name, normalized_name, created_at, updated_at)
+SELECT DISTINCT ON (lower(regexp_replace(trim(company), '\s+', ' ', 'g')))
+       trim(company),
+       lower(regexp_replace(trim(company), '\s+', ' ', 'g')),
+       now(), now()
+FROM registrations
+WHERE trim(coalesce(company, '')) <> ''
+ORDER BY lower(regexp_replace(trim(company), '\s+', ' ', 'g')), created_at DESC
+ON CONFLICT (normalized_name) DO NOTHING;
+
+INSERT INTO contacts (name, email, phone, normalized_phone, position, organization_id, created_at, updated_at)
+SELECT DISTINCT ON (lower(trim(r.email)))
+       r.name,
+       lower(trim(r.email)),
+       r.phone,
+       regexp_replace(regexp_replace(coalesce(r.phone, ''), '\D', '', 'g'), '^0', '62'),
+       r.position,
+       o.id,
+       r.created_at,
+       now()
+FROM registrations r
+LEFT JOIN organizations o
+       ON o.normalized_name = lower(regexp_replace(trim(r.company), '\s+', ' ', 'g'))
+WHERE r.contact_id IS NULL AND trim(coalesce(r.email, '')) <> ''
+ORDER BY lower(trim(r.email)), r.created_at DESC;
+
+UPDATE registrations r
+SET contact_id = c.id
+FROM contacts c
+WHERE r.contact_id IS NULL
+  AND c.email = lower(trim(r.email))
+  AND c.is_deleted = false;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS attendees;
+ALTER TABLE programs DROP COLUMN IF EXISTS capacity;
@@ -0,0 +1,12 @@
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS capacity int DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS attendees (
+    id bigserial PRIMARY KEY,
+    registration_id bigint NOT NULL CONSTRAINT fk_registrations_attendees REFERENCES registrations (id),
+    name varchar(255) NOT NULL,
+    email varchar(255),
+    position varchar(255),
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_attendees_registration_id ON attendees (registration_id);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS jobs (
+    id bigserial PRIMARY KEY,
+    queue varchar(50) NOT NULL DEFAULT 'default',
+    type varchar(100) NOT NULL,
+    payload jsonb,
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    max_attempts integer NOT NULL DEFAULT 5,
+    run_at timestamptz NOT NULL DEFAULT now(),
+    locked_at timestamptz,
+    locked_by varchar(100),
+    last_error text,
+    completed_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_type ON jobs (type);
+-- Index untuk query worker: job pending yang sudah waktunya jalan
+CREATE INDEX IF NOT EXISTS idx_jobs_ready ON jobs (queue, run_at, id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs (status, updated_at);
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id bigserial PRIMARY KEY,
+    name varchar(255),
+    url varchar(2048) NOT NULL,
+    secret varchar(255) NOT NULL,
+    events text[],
+    is_active boolean DEFAULT true,
+    is_deleted boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id bigserial PRIMARY KEY,
+    subscription_id bigint NOT NULL CONSTRAINT fk_webhook_deliveries_subscription REFERENCES webhook_subscriptions (id),
+    event_id varchar(64),
+    event varchar(100) NOT NULL,
+    payload jsonb,
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    response_status integer,
+    error text,
+    duration_ms bigint,
+    delivered_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries (event_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS registration_reminders;
+DROP TABLE IF EXISTS reminder_templates;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS reminder_templates (
+    id bigserial PRIMARY KEY,
+    program_id bigint NOT NULL CONSTRAINT fk_reminder_templates_program REFERENCES programs (id),
+    days_before integer NOT NULL,
+    subject varchar(255) NOT NULL,
+    body text NOT NULL,
+    is_active boolean DEFAULT true,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reminder_templates_program_days ON reminder_templates (program_id, days_before);
+
+CREATE TABLE IF NOT EXISTS registration_reminders (
+    id bigserial PRIMARY KEY,
+    registration_id bigint NOT NULL CONSTRAINT fk_registration_reminders_registration REFERENCES registrations (id),
+    days_before integer NOT NULL,
+    preferred_date date NOT NULL,
+    sent_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registration_reminders_unique ON registration_reminders (registration_id, days_before, preferred_date);
@@ -0,0 +1,3 @@
+ALTER TABLE registrations
+    DROP COLUMN IF EXISTS ip_address,
+    DROP COLUMN IF EXISTS spam_reason;
@@ -0,0 +1,3 @@
+ALTER TABLE registrations
+    ADD COLUMN IF NOT EXISTS spam_reason varchar(255),
+    ADD COLUMN IF NOT EXISTS ip_address varchar(45);
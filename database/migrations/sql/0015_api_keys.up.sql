@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    id bigserial PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    prefix varchar(32) NOT NULL,
+    key_hash varchar(64) NOT NULL,
+    scopes text[],
+    allowed_ips text[],
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    last_used_ip varchar(45),
+    created_by bigint CONSTRAINT fk_api_keys_created_by REFERENCES users (id) ON DELETE SET NULL,
+    is_active boolean DEFAULT true,
+    is_deleted boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_prefix ON api_keys (prefix);
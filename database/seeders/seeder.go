@@ -1,7 +1,11 @@
 package seeders
 
-import "gorm.io/gorm"
+import (
+	"log/slog"
 
-func RunAllSeeder(db *gorm.DB){
-	SeederUsers(db)
-}
\ No newline at end of file
+	"gorm.io/gorm"
+)
+
+func RunAllSeeder(db *gorm.DB, logger *slog.Logger) {
+	SeederUsers(db, logger)
+}
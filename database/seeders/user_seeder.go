@@ -1,14 +1,15 @@
 package seeders
 
 import (
-	"log"
+	"errors"
+	"log/slog"
 
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/utils"
 	"gorm.io/gorm"
 )
 
-func SeederUsers(db *gorm.DB) {
+func SeederUsers(db *gorm.DB, logger *slog.Logger) {
 	users := []models.User{
 		{
 			Email:    "admin@learnova.com",
@@ -18,25 +19,31 @@ func SeederUsers(db *gorm.DB) {
 		},
 	}
 
-	log.Print("running seeders user")
+	logger.Info("running seeders user")
 
 	for _, user := range users {
-		log.Printf("email %s", user.Email)
 		var existingUsers models.User
+		err := db.Where("email = ?", user.Email).First(&existingUsers).Error
+		if err == nil {
+			logger.Info("user already exists", "email", user.Email)
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("failed to check seed user", "email", user.Email, "error", err)
+			continue
+		}
+
 		hashPassword, errHash := utils.HashPassword(user.Password)
 		if errHash != nil {
-			log.Printf("error hash password %v", errHash)
+			logger.Error("failed to hash seed user password", "email", user.Email, "error", errHash)
 			continue
 		}
 		user.Password = hashPassword
-		if err := db.Where("email = ?", user.Email).First(&existingUsers); err != nil {
-			if err := db.Create(&user).Error; err != nil {
-				log.Printf("Failed to seed user email = %s,%v", user.Password, err)
-			} else {
-				log.Printf("Success seed email %s", user.Password)
-			}
+
+		if err := db.Create(&user).Error; err != nil {
+			logger.Error("failed to seed user", "email", user.Email, "error", err)
 		} else {
-			log.Printf("User already exist %s", user.Password)
+			logger.Info("seeded user", "email", user.Email)
 		}
 	}
 }
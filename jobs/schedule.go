@@ -0,0 +1,27 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Every menjalankan fn segera lalu setiap interval sampai ctx selesai.
+// Dipakai untuk tugas periodik ringan (contoh: scheduler reminder) yang hasil kerjanya
+// di-enqueue sebagai job sehingga retry tetap ditangani Worker.
+func Every(ctx context.Context, logger *slog.Logger, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil {
+			logger.Error("periodic task failed", "task", name, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
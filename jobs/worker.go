@@ -0,0 +1,262 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/repositories"
+	"gorm.io/gorm"
+)
+
+// Handler memproses satu job. Return error membuat job di-retry dengan backoff,
+// bungkus dengan Permanent jika job tidak perlu dicoba lagi.
+type Handler func(ctx context.Context, job models.Job) error
+
+// permanentError error yang langsung memasukkan job ke dead-letter tanpa retry
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent tandai error sebagai permanen (contoh: payload tidak valid)
+func Permanent(err error) error {
+	return permanentError{err}
+}
+
+// IsPermanent cek apakah err (atau error yang dibungkus) ditandai Permanent
+func IsPermanent(err error) bool {
+	var permanent permanentError
+	return errors.As(err, &permanent)
+}
+
+// Options pengaturan worker
+type Options struct {
+	// Queues yang diproses worker ini
+	Queues      []string
+	Concurrency int
+	// PollInterval jeda saat antrean kosong
+	PollInterval time.Duration
+	// JobTimeout batas waktu satu job, lock dianggap kedaluwarsa setelah 2x JobTimeout
+	JobTimeout time.Duration
+	// Backoff retry: BaseBackoff * 2^(attempts-1), maksimal MaxBackoff
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Retention lama job completed disimpan sebelum dihapus
+	Retention time.Duration
+	// Logger default slog.Default()
+	Logger *slog.Logger
+}
+
+// DefaultOptions dipakai untuk field Options yang kosong
+func DefaultOptions() Options {
+	return Options{
+		Queues:       []string{DefaultQueue},
+		Concurrency:  2,
+		PollInterval: 2 * time.Second,
+		JobTimeout:   5 * time.Minute,
+		BaseBackoff:  10 * time.Second,
+		MaxBackoff:   time.Hour,
+		Retention:    7 * 24 * time.Hour,
+	}
+}
+
+// DefaultQueue nama queue jika job tidak menentukan queue
+const DefaultQueue = "default"
+
+// Worker mengambil job dari tabel jobs dan menjalankan handler sesuai Type
+type Worker struct {
+	repo     repositories.JobRepository
+	opts     Options
+	id       string
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+func NewWorker(repo repositories.JobRepository, opts Options) *Worker {
+	defaults := DefaultOptions()
+	if len(opts.Queues) == 0 {
+		opts.Queues = defaults.Queues
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaults.Concurrency
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaults.PollInterval
+	}
+	if opts.JobTimeout <= 0 {
+		opts.JobTimeout = defaults.JobTimeout
+	}
+	if opts.BaseBackoff <= 0 {
+		opts.BaseBackoff = defaults.BaseBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaults.MaxBackoff
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = defaults.Retention
+	}
+
+	hostname, _ := os.Hostname()
+
+	return &Worker{
+		repo:     repo,
+		opts:     opts,
+		id:       fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Register daftarkan handler untuk tipe job
+func (w *Worker) Register(jobType string, handler Handler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.handlers[jobType] = handler
+}
+
+// Run menjalankan worker sampai ctx selesai. Job yang sedang diproses ditunggu sampai selesai.
+func (w *Worker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < w.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func(slot int) {
+			defer wg.Done()
+			w.loop(ctx, fmt.Sprintf("%s-%d", w.id, slot))
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.maintain(ctx)
+	}()
+
+	wg.Wait()
+}
+
+// loop ambil dan proses job terus-menerus, tidur PollInterval jika antrean kosong
+func (w *Worker) loop(ctx context.Context, workerID string) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		job, err := w.repo.Claim(w.opts.Queues, workerID)
+		if err == nil {
+			w.process(job)
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			w.opts.Logger.Error("failed to claim job", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.opts.PollInterval):
+		}
+	}
+}
+
+// process menjalankan handler lalu menandai job completed, pending (retry) atau dead.
+// Handler memakai context sendiri supaya job yang sedang jalan tidak terputus saat shutdown.
+func (w *Worker) process(job models.Job) {
+	w.mu.RLock()
+	handler, ok := w.handlers[job.Type]
+	w.mu.RUnlock()
+
+	var err error
+	if !ok {
+		err = Permanent(fmt.Errorf("no handler registered for job type %q", job.Type))
+	} else {
+		err = w.run(handler, job)
+	}
+
+	if err == nil {
+		if err := w.repo.Complete(job); err != nil {
+			w.opts.Logger.Error("failed to mark job completed", "job_id", job.ID, "error", err)
+		}
+		return
+	}
+
+	var retryAt *time.Time
+	if !IsPermanent(err) && job.Attempts < job.MaxAttempts {
+		next := time.Now().Add(w.backoff(job.Attempts))
+		retryAt = &next
+		w.opts.Logger.Warn("job failed, retry scheduled",
+			"job_id", job.ID, "job_type", job.Type, "attempt", job.Attempts, "max_attempts", job.MaxAttempts,
+			"retry_at", next, "error", err)
+	} else {
+		w.opts.Logger.Error("job moved to dead-letter",
+			"job_id", job.ID, "job_type", job.Type, "attempt", job.Attempts, "error", err)
+	}
+
+	if err := w.repo.Fail(job, err.Error(), retryAt); err != nil {
+		w.opts.Logger.Error("failed to record job failure", "job_id", job.ID, "error", err)
+	}
+}
+
+// run panggil handler dengan timeout, panic dianggap error supaya worker tetap hidup
+func (w *Worker) run(handler Handler, job models.Job) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.opts.JobTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+}
+
+// backoff exponential dengan jitter ±20% supaya retry tidak serentak
+func (w *Worker) backoff(attempts int) time.Duration {
+	delay := w.opts.BaseBackoff
+	for i := 1; i < attempts && delay < w.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > w.opts.MaxBackoff {
+		delay = w.opts.MaxBackoff
+	}
+
+	jitter := time.Duration(rand.Int64N(int64(delay)/5+1)) - delay/10
+	return delay + jitter
+}
+
+// maintain secara berkala mengembalikan job yang lock-nya kedaluwarsa dan menghapus job completed lama
+func (w *Worker) maintain(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		if n, err := w.repo.RescueStale(time.Now().Add(-2 * w.opts.JobTimeout)); err != nil {
+			w.opts.Logger.Error("failed to rescue stale jobs", "error", err)
+		} else if n > 0 {
+			w.opts.Logger.Warn("rescued stale jobs", "count", n)
+		}
+
+		if _, err := w.repo.DeleteCompletedBefore(time.Now().Add(-w.opts.Retention)); err != nil {
+			w.opts.Logger.Error("failed to prune completed jobs", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
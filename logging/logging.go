@@ -0,0 +1,140 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Format output log yang didukung
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Redacted pengganti nilai field sensitif
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys potongan nama field yang nilainya tidak boleh muncul di log
+var sensitiveKeys = []string{"password", "secret", "token", "authorization", "cookie", "api_key", "apikey"}
+
+// New membuat logger dengan level (debug|info|warn|error) dan format (json|text).
+// Setiap record otomatis membawa request_id dari context dan field sensitif disamarkan.
+func New(w io.Writer, level string, format string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+
+	options := &slog.HandlerOptions{
+		Level:       lvl,
+		ReplaceAttr: redact,
+	}
+
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case FormatJSON, "":
+		handler = slog.NewJSONHandler(w, options)
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %q", format)
+	}
+
+	return slog.New(contextHandler{Handler: handler}), nil
+}
+
+// IsSensitive true jika nama field termasuk data rahasia
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func redact(_ []string, attr slog.Attr) slog.Attr {
+	if attr.Value.Kind() != slog.KindGroup && IsSensitive(attr.Key) {
+		return slog.String(attr.Key, Redacted)
+	}
+	return attr
+}
+
+// Nama field korelasi di log
+const (
+	RequestIDKey = "request_id"
+	TraceIDKey   = "trace_id"
+	SpanIDKey    = "span_id"
+)
+
+// contextHandler tambahkan request_id dari context ke setiap record,
+// kecuali logger sudah membawanya lewat With (logger dari FromContext),
+// serta trace_id/span_id jika ada span aktif di context
+type contextHandler struct {
+	slog.Handler
+	hasRequestID bool
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if !h.hasRequestID {
+		if id := RequestID(ctx); id != "" {
+			record.AddAttrs(slog.String(RequestIDKey, id))
+		}
+	}
+	// Hubungkan log dengan trace OpenTelemetry yang sedang berjalan
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String(TraceIDKey, spanContext.TraceID().String()),
+			slog.String(SpanIDKey, spanContext.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	hasRequestID := h.hasRequestID
+	for _, attr := range attrs {
+		if attr.Key == RequestIDKey {
+			hasRequestID = true
+		}
+	}
+	return contextHandler{h.Handler.WithAttrs(attrs), hasRequestID}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name), h.hasRequestID}
+}
+
+type requestIDKey struct{}
+
+type loggerKey struct{}
+
+// WithRequestID simpan request ID di context
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID request ID dari context, kosong jika tidak ada
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// NewContext simpan logger di context request
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext logger dari context request, fallback ke slog.Default()
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -1,66 +1,133 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
-	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
 	"github.com/tech-azim/be-learnova/config"
 	"github.com/tech-azim/be-learnova/controllers"
+	"github.com/tech-azim/be-learnova/cors"
+	"github.com/tech-azim/be-learnova/database/migrations"
 	"github.com/tech-azim/be-learnova/database/seeders"
+	"github.com/tech-azim/be-learnova/jobs"
+	"github.com/tech-azim/be-learnova/logging"
+	"github.com/tech-azim/be-learnova/metrics"
+	"github.com/tech-azim/be-learnova/middlewares"
+	"github.com/tech-azim/be-learnova/notifications"
+	"github.com/tech-azim/be-learnova/ratelimit"
 	"github.com/tech-azim/be-learnova/repositories"
 	"github.com/tech-azim/be-learnova/routes"
 	"github.com/tech-azim/be-learnova/services"
+	"github.com/tech-azim/be-learnova/spam"
+	"github.com/tech-azim/be-learnova/tracing"
 )
 
-func CORSMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		origin := c.Request.Header.Get("Origin")
-
-		fmt.Println("=== CORS Middleware ===")
-		fmt.Println("Origin:", origin)
-		fmt.Println("Method:", c.Request.Method)
-		fmt.Println("Path:", c.Request.URL.Path)
-
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
-
-		if c.Request.Method == "OPTIONS" {
-			fmt.Println("OPTIONS request - returning 204")
-			c.AbortWithStatus(http.StatusNoContent)
-			return
-		}
-
-		c.Next()
-	}
+// fatal catat error lalu hentikan proses
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
 }
 
 func main() {
+	// Konfigurasi dari config.yaml / .env / environment, aplikasi berhenti jika tidak valid
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	if err := godotenv.Load(); err != nil {
-		log.Fatal("env not load")
+	// Logger terstruktur, slog.SetDefault juga mengarahkan package log standar ke handler yang sama
+	logger, err := logging.New(os.Stdout, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		log.Fatal(err)
+	}
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		ServiceName: cfg.Tracing.ServiceName,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		fatal(logger, "Failed to setup tracing", err)
 	}
 
 	seedFlag := flag.Bool("seed", false, "Run database seeders")
 	flag.Parse()
 
 	r := gin.New()
+	// Tanpa ini gin percaya X-Forwarded-For dari semua client, IP bisa dipalsukan
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		fatal(logger, "Invalid TRUSTED_PROXIES", err)
+	}
 
-	r.Use(gin.Logger())
-	r.Use(gin.Recovery())
-	r.Use(CORSMiddleware())
+	r.Use(middlewares.RequestID(logger))
+	r.Use(middlewares.Tracing())
+	r.Use(middlewares.RequestLogger())
+	if cfg.Metrics.Enabled {
+		r.Use(middlewares.Metrics())
+	}
+	r.Use(middlewares.SecurityHeaders(cfg.Server.HSTSMaxAge))
+	r.Use(middlewares.ErrorResponses())
+	r.Use(middlewares.Recovery())
+	corsRouter, err := cors.NewRouter(cfg.CORS.Policies)
+	if err != nil {
+		fatal(logger, "Invalid CORS configuration", err)
+	}
+	r.Use(middlewares.CORS(corsRouter))
+	r.Use(middlewares.BodyLimit(cfg.Server.MaxBodySize, cfg.Server.MaxUploadSize))
+	r.Use(middlewares.Timeout(cfg.Server.RequestTimeout))
 
 	r.RedirectTrailingSlash = true
 
-	config.ConnectDB()
+	if err := config.ConnectDB(cfg.Database); err != nil {
+		fatal(logger, "Failed to connect database", err)
+	}
+	logger.Info("Successfully connected database")
+
+	if err := config.DB.Use(tracing.NewGormPlugin()); err != nil {
+		fatal(logger, "Failed to register database tracing", err)
+	}
+	if cfg.Metrics.Enabled {
+		if err := config.DB.Use(metrics.NewGormPlugin()); err != nil {
+			fatal(logger, "Failed to register database metrics", err)
+		}
+	}
+
+	// Subcommand: go run . migrate up|down [steps]|status
+	if flag.Arg(0) == "migrate" {
+		if err := migrations.RunCLI(config.DB, flag.Args()[1:], logger); err != nil {
+			fatal(logger, "Migration failed", err)
+		}
+		return
+	}
+
+	allMigrations, err := migrations.All()
+	if err != nil {
+		fatal(logger, "Failed to load migrations", err)
+	}
+	migrator := migrations.NewMigrator(config.DB, allMigrations, logger)
+
+	// Migrasi otomatis saat start, aman untuk banyak replica karena memakai advisory lock
+	if cfg.Database.AutoMigrate {
+		if _, err := migrator.Up(); err != nil {
+			fatal(logger, "Migration failed", err)
+		}
+	}
 
 	if *seedFlag {
-		seeders.RunAllSeeder(config.DB)
+		seeders.RunAllSeeder(config.DB, logger)
 		return
 	}
 
@@ -75,35 +142,153 @@ func main() {
 	galleryRepo := repositories.NewGalleryRepository(config.DB)
 	videoGalleryRepo := repositories.NewVideoGalleryRepository(config.DB)
 	flyerGalleryRepo := repositories.NewFlyerGalleryRepository(config.DB)
+	galleryAlbumRepo := repositories.NewGalleryAlbumRepository(config.DB)
+	curriculumRepo := repositories.NewCurriculumRepository(config.DB)
+	instructorRepo := repositories.NewInstructorRepository(config.DB)
+	programFAQRepo := repositories.NewProgramFAQRepository(config.DB)
 	dashboardRepo := repositories.NewDashboardRepository(config.DB)
+	slugRepo := repositories.NewSlugRepository(config.DB)
+	sitemapRepo := repositories.NewSitemapRepository(config.DB)
+	translationRepo := repositories.NewTranslationRepository(config.DB)
+	contactRepo := repositories.NewContactRepository(config.DB)
+	attendeeRepo := repositories.NewAttendeeRepository(config.DB)
+	jobRepo := repositories.NewJobRepository(config.DB)
+	webhookRepo := repositories.NewWebhookRepository(config.DB)
+	reminderRepo := repositories.NewReminderRepository(config.DB)
+	apiKeyRepo := repositories.NewAPIKeyRepository(config.DB)
+
+	// Email notification: driver smtp / outbox
+	mailer, err := notifications.NewMailer(notifications.MailerConfig{
+		Driver:       cfg.Mail.Driver,
+		SMTPHost:     cfg.Mail.SMTP.Host,
+		SMTPPort:     cfg.Mail.SMTP.Port,
+		SMTPUsername: cfg.Mail.SMTP.Username,
+		SMTPPassword: cfg.Mail.SMTP.Password,
+		OutboxDir:    cfg.Mail.OutboxDir,
+	})
+	if err != nil {
+		fatal(logger, "Failed to configure mailer", err)
+	}
+	mailRenderer, err := notifications.NewRenderer()
+	if err != nil {
+		fatal(logger, "Failed to parse email templates", err)
+	}
 
 	// Initialize Services
-	authService := services.NewAuthService(userRepo)
+	jobService := services.NewJobService(jobRepo)
+	webhookService := services.NewWebhookService(webhookRepo, jobService, logger.With("component", "webhooks"))
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, logger.With("component", "api_keys"))
+	slugService := services.NewSlugService(slugRepo)
+	translationService := services.NewTranslationService(translationRepo, webhookService)
+	contactService := services.NewContactService(contactRepo)
+	authService := services.NewAuthService(userRepo, cfg.JWT.Secret, cfg.JWT.TTL)
 	userService := services.NewUserService(userRepo) // NEW
-	heroService := services.NewHeroService(heroRepo)
-	programService := services.NewProgramService(programRepo)
-	registrationService := services.RegistrationService(registrationRepo)
-	serviceService := services.NewServiceService(serviceRepo)
-	portfolioService := services.NewPortfolioService(portolioRepo)
-	featureService := services.NewFeatureService(featureRepo)
-	galleryService := services.NewGalleryService(galleryRepo)
-	videoGalleryService := services.NewVideoGalleryService(videoGalleryRepo)
-	flyerGalleryService := services.NewFlyerGalleryService(flyerGalleryRepo)
+	heroService := services.NewHeroService(heroRepo, webhookService)
+	programService := services.NewProgramService(programRepo, slugService, webhookService)
+	notificationService := services.NewNotificationService(programRepo, jobService, mailRenderer, services.NotificationConfig{
+		From:        cfg.Mail.From,
+		AdminEmails: cfg.Mail.AdminEmails,
+		SiteName:    cfg.App.SiteName,
+		AdminURL:    cfg.App.AdminURL,
+	}, logger.With("component", "notifications"))
+	reminderService := services.NewReminderService(reminderRepo, notificationService, cfg.Jobs.ReminderDays, logger.With("component", "reminders"))
+	rateLimitStore, err := ratelimit.NewStore(cfg.RateLimit.Store, cfg.RateLimit.RedisURL)
+	if err != nil {
+		fatal(logger, "Failed to configure rate limit store", err)
+	}
+	captchaVerifier, err := spam.NewCaptchaVerifier(cfg.Spam.CaptchaProvider, cfg.Spam.CaptchaSecret)
+	if err != nil {
+		fatal(logger, "Failed to configure captcha", err)
+	}
+	spamOptions := services.DefaultSpamOptions()
+	spamOptions.IPLimit = cfg.Spam.RegistrationIPLimit
+	spamOptions.EmailLimit = cfg.Spam.RegistrationEmailLimit
+	spamService := services.NewSpamService(rateLimitStore, captchaVerifier, spam.NewDomainBlocklist(cfg.Spam.DisposableDomains), spamOptions, logger.With("component", "spam"))
+	registrationService := services.NewRegistrationService(registrationRepo, notificationService, webhookService)
+	attendeeService := services.NewAttendeeService(attendeeRepo)
+	serviceService := services.NewServiceService(serviceRepo, slugService, webhookService)
+	portfolioService := services.NewPortfolioService(portolioRepo, webhookService)
+	featureService := services.NewFeatureService(featureRepo, webhookService)
+	galleryService := services.NewGalleryService(galleryRepo, slugService, webhookService)
+	videoGalleryService := services.NewVideoGalleryService(videoGalleryRepo, webhookService)
+	flyerGalleryService := services.NewFlyerGalleryService(flyerGalleryRepo, webhookService)
+	galleryAlbumService := services.NewGalleryAlbumService(galleryAlbumRepo, webhookService)
+	curriculumService := services.NewCurriculumService(curriculumRepo, webhookService)
+	instructorService := services.NewInstructorService(instructorRepo, webhookService)
+	programFAQService := services.NewProgramFAQService(programFAQRepo, webhookService)
 	dashboardService := services.NewDashboardService(dashboardRepo)
+	healthService := services.NewHealthService(config.DB, migrator, storageDirs(cfg))
+	sitemapService := services.NewSitemapService(sitemapRepo)
 
 	// Initialize Controllers
 	authController := controllers.NewAuthController(authService)
 	userController := controllers.NewUserController(userService) // NEW
-	heroController := controllers.NewHeroController(heroService)
-	programController := controllers.NewProgramController(programService)
-	registrationController := controllers.NewRegistrationController(registrationService, programService)
-	serviceController := controllers.NewServiceController(serviceService)
-	portfolioController := controllers.NewPortfolioController(portfolioService)
-	featureController := controllers.NewFeatureController(featureService)
-	galleryController := controllers.NewGalleryController(galleryService)
-	videoGalleryController := controllers.NewVideoGalleryController(videoGalleryService)
-	flyerGalleryController := controllers.NewFlyerGalleryController(flyerGalleryService)
+	heroController := controllers.NewHeroController(heroService, translationService)
+	programController := controllers.NewProgramController(programService, translationService)
+	registrationController := controllers.NewRegistrationController(registrationService, programService, spamService)
+	serviceController := controllers.NewServiceController(serviceService, translationService)
+	portfolioController := controllers.NewPortfolioController(portfolioService, translationService)
+	featureController := controllers.NewFeatureController(featureService, translationService)
+	galleryController := controllers.NewGalleryController(galleryService, translationService)
+	videoGalleryController := controllers.NewVideoGalleryController(videoGalleryService, translationService)
+	flyerGalleryController := controllers.NewFlyerGalleryController(flyerGalleryService, translationService)
+	galleryAlbumController := controllers.NewGalleryAlbumController(galleryAlbumService, programService, translationService)
+	curriculumController := controllers.NewCurriculumController(curriculumService, programService)
+	instructorController := controllers.NewInstructorController(instructorService, programService)
+	programFAQController := controllers.NewProgramFAQController(programFAQService, programService)
 	dashboardController := controllers.NewDashboardController(dashboardService)
+	sitemapController := controllers.NewSitemapController(sitemapService, cfg.App.SiteURL)
+	translationController := controllers.NewTranslationController(translationService)
+	contactController := controllers.NewContactController(contactService)
+	attendeeController := controllers.NewAttendeeController(attendeeService, registrationService)
+	jobController := controllers.NewJobController(jobService)
+	webhookController := controllers.NewWebhookController(webhookService)
+	reminderController := controllers.NewReminderController(reminderService, programService)
+	apiKeyController := controllers.NewAPIKeyController(apiKeyService)
+	healthController := controllers.NewHealthController(healthService)
+
+	var metricsHandler gin.HandlerFunc
+	if cfg.Metrics.Enabled {
+		metrics.Registry.MustRegister(metrics.NewBusinessCollector(registrationRepo, jobRepo, logger.With("component", "metrics")))
+		metricsHandler = middlewares.MetricsHandler(cfg.Metrics.Token)
+	}
+
+	// ctx dibatalkan saat SIGINT/SIGTERM untuk memulai graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Worker & scheduler punya context sendiri supaya baru dihentikan setelah server selesai menerima request
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+
+	// Job worker (background), JOB_WORKERS=0 untuk mematikan worker di proses ini
+	// misalnya jika worker dijalankan terpisah di replica lain
+	if cfg.Jobs.Workers > 0 {
+		worker := jobs.NewWorker(jobRepo, jobs.Options{
+			Queues:      []string{jobs.DefaultQueue, services.JobQueueMail, services.JobQueueWebhooks},
+			Concurrency: cfg.Jobs.Workers,
+			Logger:      logger.With("component", "jobs"),
+		})
+		worker.Register(services.JobTypeSendEmail, services.SendEmailJobHandler(mailer))
+		worker.Register(services.JobTypeWebhookDelivery, services.WebhookDeliveryJobHandler(webhookService))
+		workers.Add(2)
+		go func() {
+			defer workers.Done()
+			worker.Run(workerCtx)
+		}()
+
+		// Scheduler reminder, interval dari REMINDER_INTERVAL (default 1 jam)
+		go func() {
+			defer workers.Done()
+			jobs.Every(workerCtx, logger.With("component", "jobs"), "reminders", cfg.Jobs.ReminderInterval, func(ctx context.Context) error {
+				sent, err := reminderService.SendDue(ctx, time.Now())
+				if sent > 0 {
+					logger.Info("queued reminder emails", "component", "reminders", "count", sent)
+				}
+				return err
+			})
+		}()
+	}
 
 	routes.Router(
 		r,
@@ -119,12 +304,93 @@ func main() {
 		flyerGalleryController,
 		dashboardController,
 		userController,
+		galleryAlbumController,
+		curriculumController,
+		instructorController,
+		programFAQController,
+		sitemapController,
+		translationController,
+		contactController,
+		attendeeController,
+		jobController,
+		webhookController,
+		reminderController,
+		apiKeyController,
+		healthController,
+		middlewares.NewRateLimiter(rateLimitStore, cfg.RateLimit.Policies, cfg.JWT.Secret),
+		middlewares.AuthMiddleware(cfg.JWT.Secret, apiKeyService),
+		metricsHandler,
 	)
 
 	for _, route := range r.Routes() {
-		fmt.Printf("Method: %s | Path: %s\n", route.Method, route.Path)
+		logger.Debug("route registered", "method", route.Method, "path", route.Path)
 	}
 
-	fmt.Println("\n🚀 Server starting on port 8080...")
-	r.Run(":8080")
+	server := &http.Server{
+		Addr:              ":" + cfg.App.Port,
+		Handler:           r,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info("Server starting", "port", cfg.App.Port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	select {
+	case err := <-serverErr:
+		fatal(logger, "Server failed", err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	// /readyz langsung gagal supaya load balancer berhenti mengirim request baru,
+	// beri waktu load balancer mendeteksinya (drain period) sambil tetap melayani request,
+	// lalu tunggu request yang sedang berjalan (termasuk upload) selesai
+	logger.Info("Shutting down server", "drain_period", cfg.Server.DrainPeriod)
+	healthService.Drain()
+	time.Sleep(cfg.Server.DrainPeriod)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Server forced to shutdown", "error", err)
+	}
+
+	stopWorkers()
+	workersDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workersDone)
+	}()
+	select {
+	case <-workersDone:
+	case <-shutdownCtx.Done():
+		logger.Warn("Timed out waiting for job workers")
+	}
+
+	if sqlDB, err := config.DB.DB(); err == nil {
+		sqlDB.Close()
+	}
+	// Kirim span yang masih di buffer exporter
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("Failed to flush traces", "error", err)
+	}
+	logger.Info("Server stopped")
+}
+
+// storageDirs folder yang dicek bisa ditulis oleh readiness probe
+func storageDirs(cfg config.Config) []string {
+	dirs := []string{"uploads"}
+	if strings.EqualFold(cfg.Mail.Driver, notifications.DriverOutbox) {
+		dirs = append(dirs, cfg.Mail.OutboxDir)
+	}
+	return dirs
 }
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tech-azim/be-learnova/repositories"
+)
+
+// registrationStatuses status registrasi yang selalu diekspor (nilai 0 jika kosong)
+// supaya series tidak hilang-muncul di dashboard
+var registrationStatuses = []string{"pending", "active", "completed", "cancelled", "rejected", "quarantined"}
+
+// collectTimeout batas waktu query saat scrape supaya /metrics tidak menggantung jika DB lambat
+const collectTimeout = 5 * time.Second
+
+var (
+	registrationsDesc = prometheus.NewDesc(
+		"learnova_registrations",
+		"Registrations (not deleted) by status.",
+		[]string{"status"}, nil,
+	)
+	pendingAgeDesc = prometheus.NewDesc(
+		"learnova_pending_registration_oldest_age_seconds",
+		"Age of the oldest pending registration, 0 when none is pending.",
+		nil, nil,
+	)
+	jobsDesc = prometheus.NewDesc(
+		"learnova_jobs",
+		"Background jobs by status.",
+		[]string{"status"}, nil,
+	)
+)
+
+// BusinessCollector gauge bisnis yang dihitung dari database setiap kali /metrics di-scrape
+type BusinessCollector struct {
+	registrationRepo repositories.RegistrationRepository
+	jobRepo          repositories.JobRepository
+	logger           *slog.Logger
+}
+
+func NewBusinessCollector(registrationRepo repositories.RegistrationRepository, jobRepo repositories.JobRepository, logger *slog.Logger) *BusinessCollector {
+	return &BusinessCollector{registrationRepo, jobRepo, logger}
+}
+
+// Describe implements prometheus.Collector.
+func (c *BusinessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- registrationsDesc
+	ch <- pendingAgeDesc
+	ch <- jobsDesc
+}
+
+// Collect implements prometheus.Collector.
+// Query yang gagal hanya dilewati (dan dicatat di log) supaya metric lain tetap terkirim
+func (c *BusinessCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	if counts, err := c.registrationRepo.CountByStatus(ctx); err != nil {
+		c.logger.Error("collect registration metrics failed", "error", err)
+	} else {
+		for _, status := range registrationStatuses {
+			if _, ok := counts[status]; !ok {
+				counts[status] = 0
+			}
+		}
+		for status, total := range counts {
+			ch <- prometheus.MustNewConstMetric(registrationsDesc, prometheus.GaugeValue, float64(total), status)
+		}
+	}
+
+	if oldest, err := c.registrationRepo.OldestPendingCreatedAt(ctx); err != nil {
+		c.logger.Error("collect pending registration age failed", "error", err)
+	} else {
+		age := 0.0
+		if oldest != nil {
+			age = time.Since(*oldest).Seconds()
+		}
+		ch <- prometheus.MustNewConstMetric(pendingAgeDesc, prometheus.GaugeValue, age)
+	}
+
+	if counts, err := c.jobRepo.CountByStatus(); err != nil {
+		c.logger.Error("collect job metrics failed", "error", err)
+	} else {
+		for status, total := range counts {
+			ch <- prometheus.MustNewConstMetric(jobsDesc, prometheus.GaugeValue, float64(total), status)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+)
+
+// UnmatchedRoute label route untuk request yang tidak cocok dengan route mana pun (404),
+// supaya URL acak tidak membuat label baru
+const UnmatchedRoute = "unmatched"
+
+// RequestStarted tambah gauge in-flight, panggil fungsi yang dikembalikan saat request selesai
+func RequestStarted() func() {
+	httpRequestsInFlight.Inc()
+	return httpRequestsInFlight.Dec
+}
+
+// ObserveRequest catat satu request HTTP
+func ObserveRequest(method string, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = UnmatchedRoute
+	}
+	labels := []string{method, route, strconv.Itoa(status)}
+
+	httpRequestsTotal.WithLabelValues(labels...).Inc()
+	httpRequestDuration.WithLabelValues(labels...).Observe(duration.Seconds())
+}
+
+// ObserveUpload catat ukuran satu file upload. Nama field form tidak dijadikan label
+// karena ditentukan client (bisa membuat label baru tanpa batas)
+func ObserveUpload(route string, size int64) {
+	if route == "" {
+		route = UnmatchedRoute
+	}
+	uploadSizeBytes.WithLabelValues(route).Observe(float64(size))
+}
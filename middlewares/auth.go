@@ -1,60 +1,99 @@
 package middlewares
 
 import (
+	"context"
 	"fmt"
 	"net/http"
-	"os"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/tech-azim/be-learnova/apikey"
+	"github.com/tech-azim/be-learnova/logging"
+	"github.com/tech-azim/be-learnova/models"
 )
 
+// APIKeyHeader header alternatif untuk API key selain "Authorization: Bearer <key>"
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator memvalidasi API key untuk route yang diminta
+// (diimplementasikan services.APIKeyService)
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key, ip, method, route string) (models.APIKey, error)
+}
+
 type ClaimStruct struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
 	jwt.RegisteredClaims
 }
 
+// parseToken parse header Authorization "Bearer <jwt>"
+func parseToken(authHeader string, jwtSecret []byte) (*jwt.Token, error) {
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	tokenString = strings.TrimSpace(tokenString)
+
+	return jwt.ParseWithClaims(tokenString, &ClaimStruct{}, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return jwtSecret, nil
+	})
+}
+
+// apiKeyFromRequest ambil API key dari header X-API-Key atau Authorization Bearer
+func apiKeyFromRequest(c *gin.Context) (string, bool) {
+	if key := strings.TrimSpace(c.GetHeader(APIKeyHeader)); key != "" {
+		return key, true
+	}
+	key := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+	return key, apikey.IsKey(key)
+}
 
+// AuthMiddleware menerima JWT admin atau API key (jika apiKeys tidak nil).
+// Request dengan API key hanya mendapat api_key_id di context, bukan user_id
+func AuthMiddleware(jwtSecret string, apiKeys APIKeyAuthenticator) gin.HandlerFunc {
+	secret := []byte(jwtSecret)
 
-func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if key, ok := apiKeyFromRequest(c); ok && apiKeys != nil {
+			data, err := apiKeys.Authenticate(c.Request.Context(), key, c.ClientIP(), c.Request.Method, c.FullPath())
+			if err != nil {
+				logging.FromContext(c.Request.Context()).Debug("api key rejected", "error", err)
+				c.Error(err)
+				c.Abort()
+				return
+			}
+
+			c.Set("api_key_id", data.ID)
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Authorization header required",
+				"message": "Authorization header required",
 			})
 			c.Abort()
 			return
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		tokenString = strings.TrimSpace(tokenString)
-
-		jwtSecret := []byte(os.Getenv("JWT_SECRET"))
-
-		token, err := jwt.ParseWithClaims(tokenString, &ClaimStruct{}, func(t *jwt.Token) (any, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-			}
-			return jwtSecret, nil
-		})
+		token, err := parseToken(authHeader, secret)
 
 		if err != nil {
-			fmt.Println("Parse error:", err)
+			logging.FromContext(c.Request.Context()).Debug("invalid bearer token", "error", err)
 			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":  "Invalid or expired token",
-				"detail": err.Error(),
+				"message": "Invalid or expired token",
 			})
 			c.Abort()
 			return
 		}
 
 		if !token.Valid {
-			fmt.Println("Token not valid")
+			logging.FromContext(c.Request.Context()).Debug("bearer token not valid")
 			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid or expired token",
+				"message": "Invalid or expired token",
 			})
 			c.Abort()
 			return
@@ -63,7 +102,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		claims, ok := token.Claims.(*ClaimStruct)
 		if !ok {
 			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Error invalid token claims",
+				"message": "Error invalid token claims",
 			})
 			c.Abort()
 			return
@@ -0,0 +1,84 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/cors"
+)
+
+// Method & header yang dipakai frontend, sama untuk semua policy
+var (
+	corsAllowedMethods = strings.Join([]string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions,
+	}, ", ")
+	corsAllowedHeaders = strings.Join([]string{
+		"Accept", "Accept-Encoding", "Authorization", "Cache-Control", "Content-Type", "Content-Length",
+		"Origin", "X-CSRF-Token", "X-Requested-With", RequestIDHeader, APIKeyHeader, "X-Captcha-Token", "traceparent", "tracestate",
+	}, ", ")
+	corsExposedHeaders = strings.Join([]string{
+		"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After",
+		RequestIDHeader, TraceIDHeader,
+	}, ", ")
+)
+
+// CORS terapkan policy CORS sesuai prefix path (lihat cors.Router).
+// Harus dipasang global (r.Use) karena preflight OPTIONS tidak cocok dengan route mana pun.
+// Preflight dari origin yang tidak diizinkan ditolak dengan 403, request biasa tetap diteruskan
+// tanpa header CORS sehingga browser yang memblokir response.
+func CORS(router *cors.Router) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		policy, match, ok := router.Resolve(c.Request.URL.Path, origin)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Add("Vary", "Origin")
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		switch match {
+		case cors.NotAllowed:
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		case cors.AllowedAny:
+			header.Set("Access-Control-Allow-Origin", cors.AnyOrigin)
+		case cors.AllowedExplicit:
+			header.Set("Access-Control-Allow-Origin", origin)
+			if policy.AllowCredentials {
+				header.Set("Access-Control-Allow-Credentials", "true")
+			}
+		}
+
+		if !preflight {
+			header.Set("Access-Control-Expose-Headers", corsExposedHeaders)
+			c.Next()
+			return
+		}
+
+		header.Set("Access-Control-Allow-Methods", corsAllowedMethods)
+		header.Set("Access-Control-Allow-Headers", corsAllowedHeaders)
+		if policy.MaxAge > 0 {
+			header.Set("Access-Control-Max-Age", strconv.Itoa(int(policy.MaxAge.Seconds())))
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,161 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/logging"
+)
+
+// errorCodes kode error yang stabil untuk client, dipilih dari status HTTP
+var errorCodes = map[int]string{
+	http.StatusBadRequest:            "bad_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusMethodNotAllowed:      "method_not_allowed",
+	http.StatusConflict:              "conflict",
+	http.StatusRequestEntityTooLarge: "payload_too_large",
+	http.StatusUnsupportedMediaType:  "unsupported_media_type",
+	http.StatusUnprocessableEntity:   "validation_failed",
+	http.StatusTooManyRequests:       "rate_limited",
+	http.StatusInternalServerError:   "internal_error",
+	http.StatusServiceUnavailable:    "service_unavailable",
+	http.StatusGatewayTimeout:        "timeout",
+}
+
+// ErrorCode kode error untuk status HTTP
+func ErrorCode(status int) string {
+	if code, ok := errorCodes[status]; ok {
+		return code
+	}
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
+
+// ErrorResponses handler error tunggal untuk semua route:
+//   - error yang diserahkan controller lewat c.Error (tanpa menulis response) diterjemahkan
+//     dari apperrors menjadi status & body {"code", "message", "fields"}
+//   - response error JSON yang ditulis controller sendiri mendapat field "code"
+//   - 500 dicatat lengkap di log, client hanya menerima pesan umum (tanpa error SQL dsb.);
+//     field "error" mentah di response 4xx juga hanya dicatat di log
+//   - error karena body melebihi batas menjadi 413, error karena timeout request menjadi 504
+func ErrorResponses() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &errorWriter{ResponseWriter: c.Writer, c: c}
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		writeAppError(c, c.Errors.Last().Err)
+	}
+}
+
+// writeAppError tulis response untuk error domain. Error yang bukan apperrors dianggap internal.
+func writeAppError(c *gin.Context, err error) {
+	appErr := apperrors.From(err)
+	status := apperrors.HTTPStatus(appErr.Kind)
+
+	body := gin.H{
+		"code":    appErr.PublicCode(),
+		"message": appErr.Message,
+	}
+	if len(appErr.Fields) > 0 {
+		body["fields"] = appErr.Fields
+	}
+	if status >= http.StatusInternalServerError {
+		// Detail error hanya di log, body disamarkan oleh errorWriter
+		body["error"] = err.Error()
+	}
+
+	c.JSON(status, body)
+}
+
+type errorWriter struct {
+	gin.ResponseWriter
+	c       *gin.Context
+	written bool
+}
+
+func (w *errorWriter) WriteHeader(code int) {
+	if code >= http.StatusBadRequest {
+		switch {
+		case w.c.GetBool(bodyTooLargeKey):
+			code = http.StatusRequestEntityTooLarge
+		case code >= http.StatusInternalServerError && errors.Is(w.c.Request.Context().Err(), context.DeadlineExceeded):
+			code = http.StatusGatewayTimeout
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *errorWriter) Write(data []byte) (int, error) {
+	status := w.Status()
+	if w.written || status < http.StatusBadRequest || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		return w.ResponseWriter.Write(data)
+	}
+	w.written = true
+
+	// UseNumber supaya angka (misalnya ID) tidak berubah format saat di-encode ulang
+	var body map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil || body == nil {
+		return w.ResponseWriter.Write(data)
+	}
+
+	switch status {
+	case http.StatusInternalServerError:
+		ctx := w.c.Request.Context()
+		if body["error"] == nil {
+			body = gin.H{"message": "Internal server error"}
+			break
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "internal error response",
+			"status", status,
+			"message", body["message"],
+			"error", body["error"],
+		)
+		body = gin.H{"message": "Internal server error"}
+	case http.StatusRequestEntityTooLarge:
+		body = gin.H{"message": "Request body too large"}
+	case http.StatusGatewayTimeout:
+		body = gin.H{"message": "Request timed out"}
+	default:
+		// Error mentah (pesan binding, SQL, dsb.) di response 4xx hanya dicatat di log
+		if raw, ok := body["error"]; ok {
+			ctx := w.c.Request.Context()
+			logging.FromContext(ctx).InfoContext(ctx, "client error response",
+				"status", status,
+				"message", body["message"],
+				"error", raw,
+			)
+			delete(body, "error")
+			if body["message"] == nil {
+				body["message"] = http.StatusText(status)
+			}
+		}
+	}
+	if _, ok := body["code"]; !ok {
+		body["code"] = ErrorCode(status)
+	}
+
+	sanitized, err := json.Marshal(body)
+	if err != nil {
+		return w.ResponseWriter.Write(data)
+	}
+	if _, err := w.ResponseWriter.Write(sanitized); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (w *errorWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
@@ -0,0 +1,73 @@
+package middlewares
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/logging"
+)
+
+// RequestLogger access log terstruktur, menggantikan gin.Logger.
+// Query string tidak dicatat karena bisa berisi token.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.String("user_agent", c.Request.UserAgent()),
+		}
+		if userID, ok := c.Get("user_id"); ok {
+			attrs = append(attrs, slog.Any("user_id", userID))
+		}
+		if keyID, ok := c.Get("api_key_id"); ok {
+			attrs = append(attrs, slog.Any("api_key_id", keyID))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).LogAttrs(ctx, level, "http request", attrs...)
+	}
+}
+
+// Recovery tangkap panic, catat stack trace, dan kembalikan 500 tanpa detail internal
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				ctx := c.Request.Context()
+				logging.FromContext(ctx).ErrorContext(ctx, "panic recovered",
+					"panic", recovered,
+					"stack", string(debug.Stack()),
+				)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+					"message": "Internal server error",
+				})
+			}
+		}()
+
+		c.Next()
+	}
+}
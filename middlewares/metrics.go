@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/metrics"
+)
+
+// Metrics catat jumlah, latency, dan ukuran upload setiap request ke Prometheus.
+// Label route memakai template route Gin (/programs/:id), bukan path asli, supaya cardinality terbatas.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		done := metrics.RequestStarted()
+		defer done()
+
+		c.Next()
+
+		route := c.FullPath()
+		metrics.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+
+		// Form multipart hanya ada jika handler sudah mem-parse-nya (FormFile / ShouldBind)
+		if form := c.Request.MultipartForm; form != nil {
+			for _, files := range form.File {
+				for _, file := range files {
+					metrics.ObserveUpload(route, file.Size)
+				}
+			}
+		}
+	}
+}
+
+// MetricsHandler handler /metrics. Jika token diisi, request wajib membawa
+// Authorization: Bearer <token>.
+func MetricsHandler(token string) gin.HandlerFunc {
+	handler := metrics.Handler()
+
+	return func(c *gin.Context) {
+		if token != "" {
+			provided := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized"})
+				return
+			}
+		}
+
+		handler.ServeHTTP(c.Writer, c.Request)
+	}
+}
@@ -0,0 +1,97 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tech-azim/be-learnova/ratelimit"
+)
+
+func newTestRateLimiter() *RateLimiter {
+	policies := map[string]ratelimit.Policy{
+		"auth": {
+			Anonymous:     ratelimit.Limit{Requests: 2, Period: time.Minute},
+			Authenticated: ratelimit.Limit{Requests: 100, Period: time.Minute},
+		},
+	}
+	return NewRateLimiter(ratelimit.NewMemoryStore(), policies, "test-secret")
+}
+
+func forgedAPIKey(t *testing.T) string {
+	t.Helper()
+	random := make([]byte, 36)
+	if _, err := rand.Read(random); err != nil {
+		t.Fatal(err)
+	}
+	return "lnv_" + hex.EncodeToString(random[:4]) + "_" + hex.EncodeToString(random[4:])
+}
+
+func TestRateLimiterForgedAPIKeysShareIPBucket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/login", newTestRateLimiter().Limit("auth"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		prefix string
+		ip     string
+	}{
+		{"x-api-key header", APIKeyHeader, "", "203.0.113.7:1234"},
+		{"bearer token", "Authorization", "Bearer ", "203.0.113.8:1234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var statuses []int
+			for i := 0; i < 4; i++ {
+				req := httptest.NewRequest(http.MethodPost, "/login", nil)
+				req.RemoteAddr = tt.ip
+				req.Header.Set(tt.header, tt.prefix+forgedAPIKey(t))
+
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				statuses = append(statuses, w.Code)
+			}
+
+			want := []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests}
+			for i := range want {
+				if statuses[i] != want[i] {
+					t.Fatalf("statuses = %v, want %v", statuses, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiterVerifiedAPIKeyBucket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/login", func(c *gin.Context) {
+		// Menggantikan AuthMiddleware yang sudah memverifikasi key
+		c.Set("api_key_id", uint(7))
+	}, newTestRateLimiter().Limit("auth"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for i := 0; i < 4; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = "203.0.113.9:1234"
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want %d", i+1, w.Code, http.StatusOK)
+		}
+		if got := w.Header().Get("RateLimit-Limit"); got != "100" {
+			t.Fatalf("request %d: RateLimit-Limit = %q, want %q", i+1, got, "100")
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tech-azim/be-learnova/logging"
+)
+
+// RequestIDHeader header yang diterima dari client/proxy dan dikembalikan di response
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID request ID dari luar hanya diterima jika pendek dan aman ditulis ke log
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID pakai X-Request-ID dari request atau buat baru, lalu simpan di context
+// bersama logger yang sudah membawa request_id. ID juga ditambahkan ke body JSON error.
+func RequestID(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		ctx := logging.WithRequestID(c.Request.Context(), id)
+		ctx = logging.NewContext(ctx, logger.With(logging.RequestIDKey, id))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Set("request_id", id)
+		c.Header(RequestIDHeader, id)
+		c.Writer = &requestIDWriter{ResponseWriter: c.Writer, id: id}
+
+		c.Next()
+	}
+}
+
+// requestIDWriter sisipkan "requestId" ke response JSON object dengan status >= 400
+type requestIDWriter struct {
+	gin.ResponseWriter
+	id      string
+	written bool
+}
+
+func (w *requestIDWriter) Write(data []byte) (int, error) {
+	if w.written || w.Status() < 400 || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		return w.ResponseWriter.Write(data)
+	}
+	w.written = true
+
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) < 2 || trimmed[0] != '{' {
+		return w.ResponseWriter.Write(data)
+	}
+
+	field, _ := json.Marshal(w.id)
+	body := make([]byte, 0, len(trimmed)+len(field)+16)
+	body = append(body, `{"requestId":`...)
+	body = append(body, field...)
+	if rest := bytes.TrimLeft(trimmed[1:], " \t\r\n"); len(rest) > 0 && rest[0] != '}' {
+		body = append(body, ',')
+	}
+	body = append(body, trimmed[1:]...)
+
+	if _, err := w.ResponseWriter.Write(body); err != nil {
+		return 0, err
+	}
+	// Caller hanya perlu tahu seluruh data miliknya sudah ditulis
+	return len(data), nil
+}
+
+func (w *requestIDWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// APIKey kredensial client mesin (static site generator, script laporan).
+// Key hanya disimpan sebagai hash, Prefix dipakai untuk mencari key dan ditampilkan di dashboard.
+type APIKey struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	Name       string         `json:"name" gorm:"type:varchar(255);not null"`
+	Prefix     string         `json:"prefix" gorm:"type:varchar(32);uniqueIndex;not null"`
+	KeyHash    string         `json:"-" gorm:"type:varchar(64);not null"`
+	Scopes     pq.StringArray `json:"scopes" gorm:"type:text[]"`
+	AllowedIPs pq.StringArray `json:"allowed_ips" gorm:"type:text[]"`
+	ExpiresAt  *time.Time     `json:"expires_at"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	LastUsedIP string         `json:"last_used_ip" gorm:"type:varchar(45)"`
+	CreatedBy  *uint          `json:"created_by"`
+	IsActive   bool           `json:"is_active"`
+	IsDeleted  bool           `json:"is_deleted" gorm:"default:false"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// Expired true jika key punya tanggal kedaluwarsa yang sudah lewat
+func (k APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Attendee peserta individu dalam satu registrasi (registrasi grup/perusahaan)
+type Attendee struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	RegistrationID uint      `json:"registrationId" gorm:"index;not null"`
+	Name           string    `json:"name" gorm:"type:varchar(255);not null"`
+	Email          string    `json:"email" gorm:"type:varchar(255)"`
+	Position       string    `json:"position" gorm:"type:varchar(255)"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
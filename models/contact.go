@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Organization perusahaan/instansi asal contact, dicocokkan dari nama yang dinormalisasi
+type Organization struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	Name           string    `json:"name" gorm:"type:varchar(255);not null"`
+	NormalizedName string    `json:"-" gorm:"type:varchar(255);not null;uniqueIndex"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Contact satu orang peserta, dipakai bersama oleh semua registrasinya.
+// Email disimpan lowercase, NormalizedPhone hanya berisi digit dengan prefix 62.
+type Contact struct {
+	ID                uint           `json:"id" gorm:"primaryKey"`
+	Name              string         `json:"name" gorm:"type:varchar(255)"`
+	Email             string         `json:"email" gorm:"type:varchar(255);index"`
+	Phone             string         `json:"phone" gorm:"type:varchar(50)"`
+	NormalizedPhone   string         `json:"-" gorm:"type:varchar(50);index"`
+	Position          string         `json:"position" gorm:"type:varchar(255)"`
+	OrganizationID    *uint          `json:"organization_id" gorm:"index"`
+	Organization      *Organization  `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"`
+	Registrations     []Registration `json:"registrations,omitempty" gorm:"foreignKey:ContactID"`
+	RegistrationCount int64          `json:"registration_count" gorm:"-"`
+	MergedIntoID      *uint          `json:"merged_into_id,omitempty" gorm:"index"`
+	IsDeleted         bool           `json:"is_deleted" gorm:"default:false"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type CurriculumModule struct {
+	ID          uint               `json:"id" gorm:"primaryKey"`
+	ProgramID   uint               `json:"program_id" gorm:"index;not null"`
+	Title       string             `json:"title" gorm:"type:varchar(255);not null"`
+	Description string             `json:"description" gorm:"type:text"`
+	SortOrder   int                `json:"sort_order" gorm:"type:int;default:0"`
+	Lessons     []CurriculumLesson `json:"lessons" gorm:"foreignKey:ModuleID"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+type CurriculumLesson struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	ModuleID    uint      `json:"module_id" gorm:"index;not null"`
+	Title       string    `json:"title" gorm:"type:varchar(255);not null"`
+	Description string    `json:"description" gorm:"type:text"`
+	Duration    string    `json:"duration" gorm:"type:varchar(50)"`
+	SortOrder   int       `json:"sort_order" gorm:"type:int;default:0"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -11,7 +11,7 @@ type Feature struct {
     Icon        string         `gorm:"type:varchar(255);not null" json:"icon"`
     Title       string         `gorm:"type:varchar(255);not null" json:"title"`
     Description string         `gorm:"type:text;not null" json:"description"`
-    SortOrder   int            `gorm:"type:int;default:0;column:sort_order" json:"order"`
+    SortOrder   int            `gorm:"type:int;default:0;column:sort_order" json:"sort_order"`
     IsActive    bool           `gorm:"default:true" json:"is_active"`
     IsDeleted   bool           `gorm:"default:false" json:"is_deleted"`
     CreatedAt   time.Time      `json:"created_at"`
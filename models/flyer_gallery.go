@@ -11,6 +11,7 @@ type FlyerGallery struct {
 	Title       string         `gorm:"type:varchar(255);not null" json:"title"`
 	Image       string         `gorm:"type:varchar(500);not null" json:"image"`
 	Description string         `gorm:"type:text" json:"description"`
+	SortOrder   int            `gorm:"type:int;default:0" json:"sort_order"`
 	IsActive    bool           `gorm:"default:true" json:"is_active"`
 	IsDeleted   bool           `gorm:"default:false" json:"is_deleted"`
 	CreatedAt   time.Time      `json:"created_at"`
@@ -9,9 +9,13 @@ import (
 type Gallery struct {
 	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
 	Title       string         `gorm:"type:varchar(255);not null" json:"title"`
+	Slug        string         `gorm:"type:varchar(255);uniqueIndex:idx_galleries_slug,where:slug <> ''" json:"slug"`
 	Description string         `gorm:"type:text" json:"description"`
 	URL         string         `gorm:"type:varchar(500);not null" json:"url"`
 	Date        time.Time      `gorm:"type:date;not null" json:"date"`
+	SortOrder   int            `gorm:"type:int;default:0" json:"sort_order"`
+	AlbumID     *uint          `gorm:"index" json:"album_id"`
+	SEO         SEO            `gorm:"embedded" json:"seo"`
 	IsActive    bool           `gorm:"default:true" json:"is_active"`
 	IsDeleted   bool           `gorm:"default:false" json:"is_deleted"`
 	CreatedAt   time.Time      `json:"created_at"`
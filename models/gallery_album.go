@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GalleryAlbum struct {
+	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Title       string         `gorm:"type:varchar(255);not null" json:"title"`
+	Description string         `gorm:"type:text" json:"description"`
+	Cover       string         `gorm:"type:varchar(500)" json:"cover"`
+	EventDate   time.Time      `gorm:"type:date;not null" json:"event_date"`
+	ProgramID   *uint          `gorm:"index" json:"program_id"`
+	Program     *Program       `gorm:"foreignKey:ProgramID" json:"program,omitempty"`
+	Images      []Gallery      `gorm:"foreignKey:AlbumID" json:"images"`
+	ImageCount  int64          `gorm:"-" json:"image_count"`
+	SortOrder   int            `gorm:"type:int;default:0" json:"sort_order"`
+	IsActive    bool           `json:"is_active"`
+	IsDeleted   bool           `gorm:"default:false" json:"is_deleted"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestGalleryAlbumCreateKeepsIsActive(t *testing.T) {
+	// DryRun hanya membangun SQL tanpa koneksi ke database
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		isActive bool
+	}{
+		{"inactive", false},
+		{"active", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			album := GalleryAlbum{Title: "Wisuda", EventDate: time.Now(), IsActive: tt.isActive}
+			stmt := db.Omit("Images", "Program").Create(&album).Statement
+
+			// Kolom INSERT berurutan sama dengan stmt.Vars
+			sql := stmt.SQL.String()
+			start, end := strings.Index(sql, "("), strings.Index(sql, ")")
+			columns := strings.Split(sql[start+1:end], ",")
+			index := slices.Index(columns, `"is_active"`)
+			if index < 0 {
+				t.Fatalf("INSERT tanpa kolom is_active: %s", sql)
+			}
+			if got := stmt.Vars[index]; got != tt.isActive {
+				t.Errorf("is_active = %v, want %v", got, tt.isActive)
+			}
+		})
+	}
+}
@@ -6,4 +6,5 @@ type Hero struct {
 	ALT string `json:"alt"`
 	Title string `json:"title"`
 	Description string `json:"description"`
+	SortOrder int `json:"sort_order" gorm:"type:int;default:0"`
 }
\ No newline at end of file
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type Instructor struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"type:varchar(255);not null"`
+	Title     string    `json:"title" gorm:"type:varchar(255)"`
+	Bio       string    `json:"bio" gorm:"type:text"`
+	Photo     string    `json:"photo" gorm:"type:varchar(500)"`
+	IsDeleted bool      `json:"is_deleted" gorm:"default:false"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProgramInstructor tabel relasi program <-> instructor dengan urutan tampil
+type ProgramInstructor struct {
+	ProgramID    uint       `json:"program_id" gorm:"primaryKey"`
+	InstructorID uint       `json:"instructor_id" gorm:"primaryKey"`
+	SortOrder    int        `json:"sort_order" gorm:"type:int;default:0"`
+	Instructor   Instructor `json:"instructor" gorm:"foreignKey:InstructorID"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Status job di antrean
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	// JobStatusDead gagal sampai MaxAttempts (dead-letter), bisa di-retry manual oleh admin
+	JobStatusDead = "dead"
+)
+
+// Job pekerjaan background yang disimpan di PostgreSQL dan diambil worker dengan SKIP LOCKED
+type Job struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	Queue       string     `json:"queue" gorm:"type:varchar(50);not null;default:'default'"`
+	Type        string     `json:"type" gorm:"type:varchar(100);not null;index"`
+	Payload     JSON       `json:"payload"`
+	Status      string     `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	Attempts    int        `json:"attempts" gorm:"not null;default:0"`
+	MaxAttempts int        `json:"max_attempts" gorm:"not null;default:5"`
+	RunAt       time.Time  `json:"run_at" gorm:"not null"`
+	LockedAt    *time.Time `json:"locked_at"`
+	LockedBy    string     `json:"locked_by" gorm:"type:varchar(100)"`
+	LastError   string     `json:"last_error" gorm:"type:text"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
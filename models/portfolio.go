@@ -11,6 +11,7 @@ type Portfolio struct {
 	Title       string         `gorm:"type:varchar(255);not null" json:"title"`
 	Count       string         `gorm:"type:varchar(50);not null" json:"count"`
 	Description string         `gorm:"type:text;not null" json:"description"`
+	SortOrder   int            `gorm:"type:int;default:0" json:"sort_order"`
 	CreatedAt   time.Time      `json:"created_at"` // ✅ Gunakan time.Time
 	UpdatedAt   time.Time      `json:"updated_at"` // ✅ Gunakan time.Time
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"` // ✅ Ini sudah benar
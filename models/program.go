@@ -9,14 +9,21 @@ type Program struct {
 	ID           uint           `json:"id" gorm:"primaryKey"`
 	Icon         string         `json:"icon" gorm:"type:varchar(100)"`
 	Title        string         `json:"title" gorm:"type:varchar(255)"`
+	Slug         string         `json:"slug" gorm:"type:varchar(255);uniqueIndex:idx_programs_slug,where:slug <> ''"`
 	Duration     string         `json:"duration" gorm:"type:varchar(50)"`
 	Participants string         `json:"participants" gorm:"type:varchar(100)"`
 	Level        string         `json:"level" gorm:"type:varchar(50)"`
 	Description  string         `json:"description" gorm:"type:text"`
 	Benefits     pq.StringArray `json:"benefits" gorm:"type:text[]"`
 	Image        string         `json:"image" gorm:"type:varchar(255)"`
+	Capacity     int            `json:"capacity" gorm:"type:int;default:0"` // 0 = tanpa batas
+	SeatsTaken   int64          `json:"seats_taken" gorm:"-"`
+	SEO          SEO            `json:"seo" gorm:"embedded"`
 	IsDeleted    bool           `json:"is_deleted" gorm:"default:false"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	Registration []Registration `json:"registration" gorm:"foreignKey:ProgramID"`
+	Curriculum   []CurriculumModule  `json:"curriculum,omitempty" gorm:"foreignKey:ProgramID"`
+	Instructors  []ProgramInstructor `json:"instructors,omitempty" gorm:"foreignKey:ProgramID"`
+	FAQs         []ProgramFAQ        `json:"faqs,omitempty" gorm:"foreignKey:ProgramID"`
 }
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type ProgramFAQ struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ProgramID uint      `json:"program_id" gorm:"index;not null"`
+	Question  string    `json:"question" gorm:"type:text;not null"`
+	Answer    string    `json:"answer" gorm:"type:text;not null"`
+	SortOrder int       `json:"sort_order" gorm:"type:int;default:0"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -3,23 +3,30 @@ package models
 import "time"
 
 type Registration struct {
-	ID            uint      `json:"id" gorm:"primaryKey"`
-	Name          string    `json:"name"`
-	Email         string    `json:"email" gorm:"unique"`
-	Phone         string    `json:"phone"`
-	Company       string    `json:"company"`
-	Position      string    `json:"position"`
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	Name     string `json:"name"`
+	Email    string `json:"email" gorm:"uniqueIndex:idx_registrations_email_program,expression:lower(email),where:is_deleted = false"`
+	Phone    string `json:"phone"`
+	Company  string `json:"company"`
+	Position string `json:"position"`
 
-	ProgramID uint    `json:"programId" gorm:"index"`
-	Program   Program `json:"program" gorm:"foreignKey:ProgramID"`
+	ProgramID uint     `json:"programId" gorm:"index;uniqueIndex:idx_registrations_email_program,where:is_deleted = false"`
+	Program   Program  `json:"program" gorm:"foreignKey:ProgramID"`
+	ContactID *uint    `json:"contactId" gorm:"index"`
+	Contact   *Contact `json:"contact,omitempty" gorm:"foreignKey:ContactID"`
 
-	Participants  int       `json:"participants" gorm:"type:int"`
-	PreferredDate time.Time `json:"preferredDate" gorm:"type:date"`
-	Message       string    `json:"message" gorm:"type:text"`
+	Participants  int        `json:"participants" gorm:"type:int"`
+	PreferredDate time.Time  `json:"preferredDate" gorm:"type:date"`
+	Message       string     `json:"message" gorm:"type:text"`
+	Attendees     []Attendee `json:"attendees,omitempty" gorm:"foreignKey:RegistrationID"`
 
 	Status    string `json:"status" gorm:"type:varchar(20);default:'pending'"`
 	IsDeleted bool   `json:"is_deleted" gorm:"default:false"`
 
+	// SpamReason alasan registrasi dikarantina (status quarantined)
+	SpamReason string `json:"spamReason,omitempty" gorm:"type:varchar(255)"`
+	IPAddress  string `json:"ipAddress,omitempty" gorm:"type:varchar(45)"`
+
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
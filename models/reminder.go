@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ReminderTemplate pengingat email H-n sebelum PreferredDate untuk satu program.
+// Subject dan Body memakai sintaks Go template, contoh: {{.Name}}, {{.Program}}, {{.Date}}, {{.DaysLeft}}
+type ReminderTemplate struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	ProgramID  uint      `json:"program_id" gorm:"not null;uniqueIndex:idx_reminder_templates_program_days"`
+	DaysBefore int       `json:"days_before" gorm:"not null;uniqueIndex:idx_reminder_templates_program_days"`
+	Subject    string    `json:"subject" gorm:"type:varchar(255);not null"`
+	Body       string    `json:"body" gorm:"type:text;not null"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// RegistrationReminder catatan reminder yang sudah dikirim, mencegah email ganda setelah restart.
+// PreferredDate ikut disimpan supaya reminder dikirim ulang jika jadwal registrasi berubah.
+type RegistrationReminder struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	RegistrationID uint      `json:"registration_id" gorm:"not null;uniqueIndex:idx_registration_reminders_unique"`
+	DaysBefore     int       `json:"days_before" gorm:"not null;uniqueIndex:idx_registration_reminders_unique"`
+	PreferredDate  time.Time `json:"preferred_date" gorm:"type:date;not null;uniqueIndex:idx_registration_reminders_unique"`
+	SentAt         time.Time `json:"sent_at"`
+}
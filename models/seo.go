@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// SEO metadata per record, di-embed ke model yang punya halaman publik
+type SEO struct {
+	MetaTitle       string `json:"meta_title" gorm:"type:varchar(255)"`
+	MetaDescription string `json:"meta_description" gorm:"type:varchar(500)"`
+	OGImage         string `json:"og_image" gorm:"type:varchar(500)"`
+	CanonicalURL    string `json:"canonical_url" gorm:"type:varchar(500)"`
+}
+
+// SlugRedirect riwayat slug lama supaya URL lama tetap bisa diarahkan ke slug baru
+type SlugRedirect struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	EntityType string    `json:"entity_type" gorm:"type:varchar(50);not null;uniqueIndex:idx_slug_redirects_entity_slug"`
+	Slug       string    `json:"slug" gorm:"type:varchar(255);not null;uniqueIndex:idx_slug_redirects_entity_slug"`
+	EntityID   uint      `json:"entity_id" gorm:"not null;index"`
+	CreatedAt  time.Time `json:"created_at"`
+}
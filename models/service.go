@@ -10,8 +10,11 @@ type Service struct {
 	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
 	Icon        string         `gorm:"type:varchar(255);not null" json:"icon"`        
 	Title       string         `gorm:"type:varchar(255);not null" json:"title"`     
+	Slug        string         `gorm:"type:varchar(255);uniqueIndex:idx_services_slug,where:slug <> ''" json:"slug"`
 	Description string         `gorm:"type:text;not null" json:"description"`       
 	Color       string         `gorm:"type:varchar(50);not null" json:"color"`       
+	SortOrder   int            `gorm:"type:int;default:0" json:"sort_order"`
+	SEO         SEO            `gorm:"embedded" json:"seo"`
 	CreatedAt   time.Time      `json:"created_at"`                                  
 	UpdatedAt   time.Time      `json:"updated_at"`                                   
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`          
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Translation terjemahan satu field konten untuk satu locale.
+// Konten asli pada tabel entity adalah bahasa default (id), terjemahan
+// locale lain disimpan per field di tabel ini.
+type Translation struct {
+	ID         uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	EntityType string    `json:"entity_type" gorm:"type:varchar(50);not null;uniqueIndex:idx_translations_entity_locale_field"`
+	EntityID   uint      `json:"entity_id" gorm:"not null;uniqueIndex:idx_translations_entity_locale_field"`
+	Locale     string    `json:"locale" gorm:"type:varchar(10);not null;uniqueIndex:idx_translations_entity_locale_field"`
+	Field      string    `json:"field" gorm:"type:varchar(50);not null;uniqueIndex:idx_translations_entity_locale_field"`
+	Value      string    `json:"value" gorm:"type:text;not null"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
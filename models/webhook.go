@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// WebhookSubscription endpoint eksternal yang menerima event (CRM, chat, static-site builder)
+type WebhookSubscription struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	Name      string         `json:"name" gorm:"type:varchar(255)"`
+	URL       string         `json:"url" gorm:"type:varchar(2048);not null"`
+	Secret    string         `json:"-" gorm:"type:varchar(255);not null"`
+	Events    pq.StringArray `json:"events" gorm:"type:text[]"`
+	IsActive  bool           `json:"is_active"`
+	IsDeleted bool           `json:"is_deleted" gorm:"default:false"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// Status pengiriman webhook
+const (
+	WebhookDeliveryPending  = "pending"
+	WebhookDeliverySuccess  = "success"
+	WebhookDeliveryRetrying = "retrying"
+	WebhookDeliveryFailed   = "failed"
+)
+
+// WebhookDelivery log pengiriman satu event ke satu subscription
+type WebhookDelivery struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	SubscriptionID uint       `json:"subscription_id" gorm:"index;not null"`
+	EventID        string     `json:"event_id" gorm:"type:varchar(64);index"`
+	Event          string     `json:"event" gorm:"type:varchar(100);not null"`
+	Payload        JSON       `json:"payload"`
+	Status         string     `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	ResponseStatus int        `json:"response_status"`
+	Error          string     `json:"error" gorm:"type:text"`
+	DurationMs     int64      `json:"duration_ms"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,54 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Mailer driver pengiriman email
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Driver yang tersedia untuk MAIL_DRIVER
+const (
+	DriverSMTP   = "smtp"
+	DriverOutbox = "outbox"
+)
+
+// MailerConfig konfigurasi driver email
+type MailerConfig struct {
+	Driver       string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	// OutboxDir folder file .eml untuk driver outbox
+	OutboxDir string
+}
+
+// NewMailer membuat mailer sesuai driver (default outbox untuk development).
+func NewMailer(config MailerConfig) (Mailer, error) {
+	driver := strings.ToLower(config.Driver)
+
+	switch driver {
+	case DriverSMTP:
+		port := config.SMTPPort
+		if port == "" {
+			port = "587"
+		}
+		if config.SMTPHost == "" {
+			return nil, fmt.Errorf("smtp host is required for the smtp mail driver")
+		}
+		return NewSMTPMailer(config.SMTPHost, port, config.SMTPUsername, config.SMTPPassword), nil
+	case DriverOutbox, "":
+		dir := config.OutboxDir
+		if dir == "" {
+			dir = "storage/outbox"
+		}
+		return NewOutboxMailer(dir), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", driver)
+	}
+}
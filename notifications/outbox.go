@@ -0,0 +1,66 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// OutboxMailer menyimpan email sebagai file .eml di folder lokal,
+// dipakai untuk development dan test tanpa server SMTP
+type OutboxMailer struct {
+	dir     string
+	counter atomic.Uint64
+}
+
+func NewOutboxMailer(dir string) *OutboxMailer {
+	return &OutboxMailer{dir: dir}
+}
+
+// Send implements Mailer.
+func (m *OutboxMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	body, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return err
+	}
+
+	recipient := "unknown"
+	if len(msg.To) > 0 {
+		recipient = sanitizeFilename(envelopeAddress(msg.To[0]))
+	}
+	name := fmt.Sprintf("%s_%04d_%s.eml", time.Now().Format("20060102T150405.000"), m.counter.Add(1)%10000, recipient)
+
+	return os.WriteFile(filepath.Join(m.dir, name), body, 0644)
+}
+
+func parseAddress(address string) (string, error) {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Address, nil
+}
+
+func sanitizeFilename(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_', r == '@':
+			return r
+		default:
+			return '_'
+		}
+	}, value)
+}
@@ -0,0 +1,145 @@
+package notifications
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// smtpTimeout batas waktu satu pengiriman jika ctx tidak punya deadline,
+// supaya server SMTP yang macet tidak menahan worker selamanya
+const smtpTimeout = time.Minute
+
+// SMTPMailer kirim email lewat server SMTP.
+// STARTTLS dipakai otomatis jika server mendukung.
+type SMTPMailer struct {
+	addr string
+	host string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(host, port, username, password string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, port),
+		host: host,
+		auth: auth,
+	}
+}
+
+// Send implements Mailer.
+// Koneksi dibuka dengan DialContext dan diberi deadline dari ctx (atau smtpTimeout),
+// pembatalan ctx di tengah pengiriman langsung memutus koneksi.
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) (err error) {
+	body, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	from := envelopeAddress(msg.From)
+	to := envelopeAddresses(msg.To)
+	for _, address := range append([]string{from}, to...) {
+		if strings.ContainsAny(address, "\r\n") {
+			return errors.New("smtp: address contains CR or LF")
+		}
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+
+	dialer := net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+	defer func() {
+		// Error I/O karena ctx dibatalkan dilaporkan sebagai error ctx
+		if !stop() && err != nil {
+			err = ctx.Err()
+		}
+	}()
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	return m.send(client, from, to, body)
+}
+
+// send jalankan perintah SMTP seperti smtp.SendMail di atas koneksi yang sudah dibuka
+func (m *SMTPMailer) send(client *smtp.Client, from string, to []string, body []byte) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+
+	if m.auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := client.Auth(m.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, address := range to {
+		if err := client.Rcpt(address); err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(body); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// envelopeAddress ambil alamat email saja dari format "Nama <email@domain>"
+func envelopeAddress(address string) string {
+	parsed, err := parseAddress(address)
+	if err != nil {
+		return address
+	}
+	return parsed
+}
+
+func envelopeAddresses(addresses []string) []string {
+	result := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, envelopeAddress(address))
+	}
+	return result
+}
@@ -0,0 +1,172 @@
+package notifications
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*.html templates/*.txt
+var templateFS embed.FS
+
+// Nama template email. Masing-masing punya file <nama>.txt (berisi block "subject")
+// dan <nama>.html (berisi block "content" yang dibungkus layout.html)
+const (
+	TemplateRegistrationConfirmation = "registration_confirmation"
+	TemplateAdminNewRegistration     = "admin_new_registration"
+	TemplateRegistrationStatus       = "registration_status"
+	TemplateRegistrationReminder     = "registration_reminder"
+)
+
+var templateNames = []string{
+	TemplateRegistrationConfirmation,
+	TemplateAdminNewRegistration,
+	TemplateRegistrationStatus,
+	TemplateRegistrationReminder,
+}
+
+var templateFuncs = map[string]any{
+	"date": formatDate,
+	"inc":  func(i int) int { return i + 1 },
+}
+
+type emailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// Renderer render template email dari file embedded
+type Renderer struct {
+	templates map[string]emailTemplate
+	custom    *htmltemplate.Template
+}
+
+// customEmailData data layout untuk email dengan template dari database
+type customEmailData struct {
+	SiteName string
+	Title    string
+	Body     htmltemplate.HTML
+}
+
+// NewRenderer parse semua template saat start supaya error template ketahuan lebih awal
+func NewRenderer() (*Renderer, error) {
+	templates := make(map[string]emailTemplate, len(templateNames))
+	for _, name := range templateNames {
+		text, err := texttemplate.New(name+".txt").Funcs(templateFuncs).ParseFS(templateFS, "templates/"+name+".txt")
+		if err != nil {
+			return nil, err
+		}
+
+		html, err := htmltemplate.New("layout.html").Funcs(templateFuncs).ParseFS(templateFS, "templates/layout.html", "templates/"+name+".html")
+		if err != nil {
+			return nil, err
+		}
+
+		templates[name] = emailTemplate{text: text, html: html}
+	}
+
+	custom, err := htmltemplate.New("layout.html").Funcs(templateFuncs).ParseFS(templateFS, "templates/layout.html", "templates/custom.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Renderer{templates: templates, custom: custom}, nil
+}
+
+// Render menghasilkan subject, body HTML dan body text untuk template name
+func (r *Renderer) Render(name string, data any) (Message, error) {
+	tmpl, ok := r.templates[name]
+	if !ok {
+		return Message{}, fmt.Errorf("email template %q not found", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.text.Execute(&text, data); err != nil {
+		return Message{}, err
+	}
+	if err := tmpl.html.Execute(&html, data); err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+// RenderCustom render template text dari database (contoh: reminder per program).
+// Versi HTML dibuat dari hasil text yang di-escape lalu dibungkus layout.
+func (r *Renderer) RenderCustom(siteName, subjectTemplate, bodyTemplate string, data any) (Message, error) {
+	subject, err := executeText("subject", subjectTemplate, data)
+	if err != nil {
+		return Message{}, err
+	}
+	text, err := executeText("body", bodyTemplate, data)
+	if err != nil {
+		return Message{}, err
+	}
+	subject = strings.Join(strings.Fields(subject), " ")
+	text = strings.TrimSpace(text)
+
+	var html bytes.Buffer
+	err = r.custom.Execute(&html, customEmailData{
+		SiteName: siteName,
+		Title:    subject,
+		Body:     textToHTML(text),
+	})
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		Subject: subject,
+		Text:    text + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+func executeText(name, source string, data any) (string, error) {
+	tmpl, err := texttemplate.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// textToHTML paragraf dipisah baris kosong, baris baru menjadi <br>
+func textToHTML(text string) htmltemplate.HTML {
+	var buf strings.Builder
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+
+		lines := strings.Split(paragraph, "\n")
+		for i := range lines {
+			lines[i] = htmltemplate.HTMLEscapeString(lines[i])
+		}
+		buf.WriteString("<p>" + strings.Join(lines, "<br>") + "</p>\n")
+	}
+	return htmltemplate.HTML(buf.String())
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("02 January 2006")
+}
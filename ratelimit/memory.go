@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore store in-memory untuk satu proses. Untuk beberapa replica
+// gunakan store bersama supaya limit tidak terbagi per instance.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]time.Time
+	now     func() time.Time
+	// sweepAt waktu pembersihan key kedaluwarsa berikutnya
+	sweepAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+// Take implements Store.
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	tat, result := take(s.buckets[key], now, limit)
+	s.buckets[key] = tat
+
+	return result, nil
+}
+
+// sweep hapus bucket yang sudah penuh kembali (tat lewat) supaya map tidak tumbuh terus
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Before(s.sweepAt) {
+		return
+	}
+	s.sweepAt = now.Add(time.Minute)
+
+	for key, tat := range s.buckets {
+		if tat.Before(now) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit token bucket: maksimal Requests request per Period, bucket penuh berisi Requests token
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseLimit parse format "<jumlah>/<durasi>", contoh "5/10m" atau "100/1m"
+func ParseLimit(value string) (Limit, error) {
+	requests, period, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, expected <requests>/<duration>", value)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit requests in %q", value)
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit period in %q", value)
+	}
+
+	return Limit{Requests: n, Period: d}, nil
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Period)
+}
+
+// interval waktu isi ulang satu token
+func (l Limit) interval() time.Duration {
+	return l.Period / time.Duration(l.Requests)
+}
+
+// Result hasil pengambilan token
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter waktu tunggu sampai 1 token tersedia (0 jika Allowed)
+	RetryAfter time.Duration
+	// Reset waktu sampai bucket penuh kembali
+	Reset time.Duration
+}
+
+// Store menyimpan state bucket per key
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// take hitung token bucket berdasarkan "theoretical arrival time" (GCRA):
+// tat adalah waktu ketika bucket dianggap penuh kembali. Dipakai bersama oleh semua store.
+func take(tat, now time.Time, limit Limit) (time.Time, Result) {
+	interval := limit.interval()
+	burst := time.Duration(limit.Requests) * interval
+
+	if tat.Before(now) {
+		tat = now
+	}
+
+	newTat := tat.Add(interval)
+	allowAt := newTat.Add(-burst)
+
+	if now.Before(allowAt) {
+		return tat, Result{
+			Allowed:    false,
+			Limit:      limit.Requests,
+			Remaining:  0,
+			RetryAfter: allowAt.Sub(now),
+			Reset:      tat.Sub(now),
+		}
+	}
+
+	remaining := int(math.Floor(float64(burst-newTat.Sub(now)) / float64(interval)))
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return newTat, Result{
+		Allowed:   true,
+		Limit:     limit.Requests,
+		Remaining: remaining,
+		Reset:     newTat.Sub(now),
+	}
+}
+
+// Policy limit untuk satu route group. Anonymous dihitung per IP, Authenticated per user ID / API key.
+// Limit dengan Requests 0 berarti tidak dibatasi.
+type Policy struct {
+	Anonymous     Limit
+	Authenticated Limit
+}
+
+// ClientKey key bucket untuk IP client: IPv4 per alamat, IPv6 per /64 karena satu client
+// biasanya memegang seluruh /64 dan bisa berganti alamat di setiap request.
+// ip harus IP yang sudah diverifikasi (gin ClientIP dengan trusted proxies), bukan header mentah.
+func ClientKey(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "ip:" + ip
+	}
+	addr = addr.Unmap()
+	if addr.Is6() {
+		prefix, _ := addr.Prefix(64)
+		return "ip6:" + prefix.String()
+	}
+	return "ip:" + addr.String()
+}
+
+// ParsePolicy parse "<anonymous>[,<authenticated>]", contoh "60/1m,300/1m".
+// Tanpa bagian authenticated, limit anonymous dipakai untuk keduanya.
+func ParsePolicy(value string) (Policy, error) {
+	anonymous, authenticated, hasAuthenticated := strings.Cut(value, ",")
+
+	var policy Policy
+	var err error
+	if policy.Anonymous, err = ParseLimit(anonymous); err != nil {
+		return Policy{}, err
+	}
+	policy.Authenticated = policy.Anonymous
+	if hasAuthenticated {
+		if policy.Authenticated, err = ParseLimit(authenticated); err != nil {
+			return Policy{}, err
+		}
+	}
+
+	return policy, nil
+}
+
+// Store yang tersedia untuk RATE_LIMIT_STORE
+const (
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+)
+
+// NewStore membuat store sesuai driver (memory|redis). Store redis memakai redisURL,
+// contoh redis://localhost:6379/0.
+func NewStore(driver string, redisURL string) (Store, error) {
+	switch driver {
+	case StoreMemory, "":
+		return NewMemoryStore(), nil
+	case StoreRedis:
+		if redisURL == "" {
+			return nil, fmt.Errorf("redis url is required for the redis rate limit store")
+		}
+		return NewRedisStoreFromURL(redisURL, "learnova:")
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", driver)
+	}
+}
+
+// UnmarshalText supaya Limit bisa dibaca langsung dari file konfigurasi
+func (l *Limit) UnmarshalText(text []byte) error {
+	parsed, err := ParseLimit(string(text))
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+// UnmarshalText supaya Policy bisa dibaca langsung dari file konfigurasi
+func (p *Policy) UnmarshalText(text []byte) error {
+	parsed, err := ParsePolicy(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	FindAll(params utils.PaginationParams) ([]models.APIKey, int64, error)
+	FindByID(id uint) (models.APIKey, error)
+	FindByPrefix(ctx context.Context, prefix string) (models.APIKey, error)
+	Create(key models.APIKey) (models.APIKey, error)
+	Update(key models.APIKey) (models.APIKey, error)
+	Delete(id uint) error
+	TouchLastUsed(ctx context.Context, id uint, usedAt time.Time, ip string) error
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db}
+}
+
+// FindAll implements APIKeyRepository.
+func (r *apiKeyRepository) FindAll(params utils.PaginationParams) ([]models.APIKey, int64, error) {
+	offset := (params.Page - 1) * params.Limit
+
+	var keys []models.APIKey
+	var total int64
+
+	query := r.db.Model(&models.APIKey{}).Where("is_deleted = ?", false)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id ASC").Offset(offset).Limit(params.Limit).Find(&keys).Error
+
+	return keys, total, err
+}
+
+// FindByID implements APIKeyRepository.
+func (r *apiKeyRepository) FindByID(id uint) (models.APIKey, error) {
+	var key models.APIKey
+
+	err := r.db.Where("id = ? AND is_deleted = ?", id, false).First(&key).Error
+
+	return key, notFound(err, "API key")
+}
+
+// FindByPrefix implements APIKeyRepository.
+// Dipakai AuthMiddleware di setiap request, status aktif & expiry dicek di service
+func (r *apiKeyRepository) FindByPrefix(ctx context.Context, prefix string) (models.APIKey, error) {
+	var key models.APIKey
+
+	err := r.db.WithContext(ctx).Where("prefix = ? AND is_deleted = ?", prefix, false).First(&key).Error
+
+	return key, notFound(err, "API key")
+}
+
+// Create implements APIKeyRepository.
+func (r *apiKeyRepository) Create(key models.APIKey) (models.APIKey, error) {
+	err := r.db.Create(&key).Error
+
+	return key, err
+}
+
+// Update implements APIKeyRepository.
+func (r *apiKeyRepository) Update(key models.APIKey) (models.APIKey, error) {
+	err := r.db.Save(&key).Error
+
+	return key, err
+}
+
+// Delete implements APIKeyRepository.
+// Soft delete, key langsung tidak bisa dipakai lagi
+func (r *apiKeyRepository) Delete(id uint) error {
+	return r.db.Model(&models.APIKey{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"is_deleted": true, "is_active": false}).Error
+}
+
+// TouchLastUsed implements APIKeyRepository.
+// UpdateColumns supaya updated_at tidak ikut berubah karena pemakaian key
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uint, usedAt time.Time, ip string) error {
+	return r.db.WithContext(ctx).Model(&models.APIKey{}).Where("id = ?", id).UpdateColumns(map[string]any{
+		"last_used_at": usedAt,
+		"last_used_ip": ip,
+	}).Error
+}
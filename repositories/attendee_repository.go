@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAttendeeLimit jumlah attendee sudah sama dengan jumlah participants registrasi
+var ErrAttendeeLimit = apperrors.Conflict("attendee_limit_reached", "attendee list already matches participant count")
+
+type AttendeeRepository interface {
+	FindByRegistrationID(ctx context.Context, registrationID uint) ([]models.Attendee, error)
+	FindByID(ctx context.Context, registrationID uint, id uint) (models.Attendee, error)
+	Create(ctx context.Context, attendee models.Attendee) (models.Attendee, error)
+	Update(ctx context.Context, attendee models.Attendee) (models.Attendee, error)
+	Delete(ctx context.Context, registrationID uint, id uint) error
+	Replace(ctx context.Context, registration models.Registration, attendees []models.Attendee) ([]models.Attendee, error)
+}
+
+type attendeeRepository struct {
+	db *gorm.DB
+}
+
+func NewAttendeeRepository(db *gorm.DB) AttendeeRepository {
+	return &attendeeRepository{db}
+}
+
+// orderedAttendees preload attendee sesuai urutan input
+func orderedAttendees(db *gorm.DB) *gorm.DB {
+	return db.Order("id ASC")
+}
+
+// FindByRegistrationID implements AttendeeRepository.
+func (r *attendeeRepository) FindByRegistrationID(ctx context.Context, registrationID uint) ([]models.Attendee, error) {
+	var attendees []models.Attendee
+
+	err := orderedAttendees(r.db.WithContext(ctx)).Where("registration_id = ?", registrationID).Find(&attendees).Error
+
+	return attendees, err
+}
+
+// FindByID implements AttendeeRepository.
+func (r *attendeeRepository) FindByID(ctx context.Context, registrationID uint, id uint) (models.Attendee, error) {
+	var attendee models.Attendee
+
+	err := r.db.WithContext(ctx).Where("id = ? AND registration_id = ?", id, registrationID).First(&attendee).Error
+
+	return attendee, notFound(err, "Attendee")
+}
+
+// Create implements AttendeeRepository.
+// Registrasi di-lock supaya jumlah attendee tidak melebihi participants
+func (r *attendeeRepository) Create(ctx context.Context, attendee models.Attendee) (models.Attendee, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var registration models.Registration
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "participants").
+			Where("id = ? AND is_deleted = ?", attendee.RegistrationID, false).
+			First(&registration).Error
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.Attendee{}).Where("registration_id = ?", attendee.RegistrationID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= int64(registration.Participants) {
+			return ErrAttendeeLimit
+		}
+
+		return tx.Create(&attendee).Error
+	})
+
+	return attendee, err
+}
+
+// Update implements AttendeeRepository.
+func (r *attendeeRepository) Update(ctx context.Context, attendee models.Attendee) (models.Attendee, error) {
+	err := r.db.WithContext(ctx).Save(&attendee).Error
+
+	return attendee, err
+}
+
+// Delete implements AttendeeRepository.
+func (r *attendeeRepository) Delete(ctx context.Context, registrationID uint, id uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND registration_id = ?", id, registrationID).Delete(&models.Attendee{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Replace implements AttendeeRepository.
+// Mengganti seluruh attendee dan menyesuaikan participants = jumlah attendee (cek kapasitas program)
+func (r *attendeeRepository) Replace(ctx context.Context, registration models.Registration, attendees []models.Attendee) ([]models.Attendee, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		registration.Participants = len(attendees)
+		if err := checkCapacity(tx, registration); err != nil {
+			return err
+		}
+
+		if err := tx.Where("registration_id = ?", registration.ID).Delete(&models.Attendee{}).Error; err != nil {
+			return err
+		}
+
+		for i := range attendees {
+			attendees[i].ID = 0
+			attendees[i].RegistrationID = registration.ID
+		}
+		if err := tx.Create(&attendees).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Registration{}).
+			Where("id = ?", registration.ID).
+			Update("participants", registration.Participants).Error
+	})
+
+	return attendees, err
+}
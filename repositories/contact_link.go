@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
+)
+
+// findContact cari contact berdasarkan email, lalu nomor telepon. found false jika tidak ada
+func findContact(tx *gorm.DB, email string, normalizedPhone string) (models.Contact, bool, error) {
+	lookups := []struct {
+		column string
+		value  string
+	}{
+		{"email", email},
+		{"normalized_phone", normalizedPhone},
+	}
+
+	for _, lookup := range lookups {
+		if lookup.value == "" {
+			continue
+		}
+
+		var contact models.Contact
+		err := tx.Where(lookup.column+" = ? AND is_deleted = ?", lookup.value, false).Order("id ASC").First(&contact).Error
+		if err == nil {
+			return contact, true, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Contact{}, false, err
+		}
+	}
+
+	return models.Contact{}, false, nil
+}
+
+// linkContact hubungkan registrasi ke contact yang sama (email/phone yang dinormalisasi)
+// atau buat contact baru, lalu isi registration.ContactID.
+// Data registrasi berasal dari form publik sehingga hanya mengisi field contact yang masih kosong,
+// data yang sudah ada (hasil edit / merge admin) tidak ditimpa.
+// Harus dipanggil di dalam transaksi registrasi supaya contact tidak berubah jika registrasi gagal.
+func linkContact(tx *gorm.DB, registration *models.Registration) error {
+	email := utils.NormalizeEmail(registration.Email)
+	phone := utils.NormalizePhone(registration.Phone)
+
+	contact, found, err := findContact(tx, email, phone)
+	if err != nil {
+		return err
+	}
+
+	var organizationID *uint
+	if company := strings.TrimSpace(registration.Company); company != "" && (!found || contact.OrganizationID == nil) {
+		organization := models.Organization{
+			Name:           company,
+			NormalizedName: utils.NormalizeName(company),
+		}
+		if err := tx.Where(models.Organization{NormalizedName: organization.NormalizedName}).FirstOrCreate(&organization).Error; err != nil {
+			return err
+		}
+		organizationID = &organization.ID
+	}
+
+	if !found {
+		contact = models.Contact{
+			Name:            strings.TrimSpace(registration.Name),
+			Email:           email,
+			Phone:           strings.TrimSpace(registration.Phone),
+			NormalizedPhone: phone,
+			Position:        strings.TrimSpace(registration.Position),
+			OrganizationID:  organizationID,
+		}
+		if err := tx.Omit("Organization", "Registrations").Create(&contact).Error; err != nil {
+			return err
+		}
+		registration.ContactID = &contact.ID
+		return nil
+	}
+
+	updates := map[string]any{}
+	if contact.Name == "" && strings.TrimSpace(registration.Name) != "" {
+		updates["name"] = strings.TrimSpace(registration.Name)
+	}
+	if contact.Email == "" && email != "" {
+		updates["email"] = email
+	}
+	if contact.NormalizedPhone == "" && phone != "" {
+		updates["phone"] = strings.TrimSpace(registration.Phone)
+		updates["normalized_phone"] = phone
+	}
+	if contact.Position == "" && strings.TrimSpace(registration.Position) != "" {
+		updates["position"] = strings.TrimSpace(registration.Position)
+	}
+	if organizationID != nil {
+		updates["organization_id"] = *organizationID
+	}
+	if len(updates) > 0 {
+		if err := tx.Model(&models.Contact{}).Where("id = ?", contact.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+
+	registration.ContactID = &contact.ID
+	return nil
+}
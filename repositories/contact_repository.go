@@ -0,0 +1,217 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
+)
+
+// DuplicateContactGroup kumpulan contact yang kemungkinan orang yang sama
+type DuplicateContactGroup struct {
+	Reason   string           `json:"reason"`
+	Key      string           `json:"key"`
+	Contacts []models.Contact `json:"contacts"`
+}
+
+type ContactRepository interface {
+	FindAll(ctx context.Context, params utils.PaginationParams, search string) ([]models.Contact, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Contact, error)
+	FindDetailByID(ctx context.Context, id uint) (models.Contact, error)
+	FindDuplicates(ctx context.Context) ([]DuplicateContactGroup, error)
+	Create(ctx context.Context, contact models.Contact) (models.Contact, error)
+	Update(ctx context.Context, contact models.Contact) (models.Contact, error)
+	Merge(ctx context.Context, target models.Contact, sourceIDs []uint) error
+	FindOrCreateOrganization(ctx context.Context, name string) (models.Organization, error)
+}
+
+type contactRepository struct {
+	db *gorm.DB
+}
+
+func NewContactRepository(db *gorm.DB) ContactRepository {
+	return &contactRepository{db}
+}
+
+// withRegistrationCount mengisi RegistrationCount untuk listing contact
+func (r *contactRepository) withRegistrationCount(ctx context.Context, contacts []models.Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(contacts))
+	for _, contact := range contacts {
+		ids = append(ids, contact.ID)
+	}
+
+	var rows []struct {
+		ContactID uint
+		Total     int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.Registration{}).
+		Select("contact_id, COUNT(*) AS total").
+		Where("contact_id IN ? AND is_deleted = ?", ids, false).
+		Group("contact_id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ContactID] = row.Total
+	}
+	for i := range contacts {
+		contacts[i].RegistrationCount = counts[contacts[i].ID]
+	}
+	return nil
+}
+
+// FindAll implements ContactRepository.
+// search mencocokkan nama, email, phone atau nama organisasi
+func (r *contactRepository) FindAll(ctx context.Context, params utils.PaginationParams, search string) ([]models.Contact, int64, error) {
+	offset := (params.Page - 1) * params.Limit
+
+	var contacts []models.Contact
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Contact{}).Where("contacts.is_deleted = ?", false)
+	if search != "" {
+		like := "%" + search + "%"
+		query = query.Joins("LEFT JOIN organizations ON organizations.id = contacts.organization_id").
+			Where("contacts.name ILIKE ? OR contacts.email ILIKE ? OR contacts.phone ILIKE ? OR organizations.name ILIKE ?", like, like, like, like)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Organization").Order("contacts.updated_at DESC").Offset(offset).Limit(params.Limit).Find(&contacts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return contacts, total, r.withRegistrationCount(ctx, contacts)
+}
+
+// FindByID implements ContactRepository.
+func (r *contactRepository) FindByID(ctx context.Context, id uint) (models.Contact, error) {
+	var contact models.Contact
+
+	err := r.db.WithContext(ctx).Preload("Organization").Where("id = ? AND is_deleted = ?", id, false).First(&contact).Error
+
+	return contact, notFound(err, "Contact")
+}
+
+// FindDetailByID implements ContactRepository.
+// Termasuk semua registrasi contact di seluruh program
+func (r *contactRepository) FindDetailByID(ctx context.Context, id uint) (models.Contact, error) {
+	var contact models.Contact
+
+	err := r.db.WithContext(ctx).Preload("Organization").
+		Preload("Registrations", func(db *gorm.DB) *gorm.DB {
+			return db.Where("is_deleted = ?", false).Order("created_at DESC")
+		}).
+		Preload("Registrations.Program").
+		Where("id = ? AND is_deleted = ?", id, false).
+		First(&contact).Error
+	contact.RegistrationCount = int64(len(contact.Registrations))
+
+	return contact, notFound(err, "Contact")
+}
+
+// FindDuplicates implements ContactRepository.
+// Kandidat duplikat: nomor telepon sama atau nama sama persis (tanpa beda huruf besar/kecil)
+func (r *contactRepository) FindDuplicates(ctx context.Context) ([]DuplicateContactGroup, error) {
+	checks := []struct {
+		reason string
+		expr   string
+	}{
+		{"phone", "normalized_phone"},
+		{"name", "lower(trim(name))"},
+	}
+
+	groups := []DuplicateContactGroup{}
+	for _, check := range checks {
+		var keys []string
+		err := r.db.WithContext(ctx).Model(&models.Contact{}).
+			Select(check.expr).
+			Where("is_deleted = ? AND "+check.expr+" <> ''", false).
+			Group(check.expr).
+			Having("COUNT(*) > 1").
+			Order(check.expr).
+			Pluck(check.expr, &keys).Error
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			var contacts []models.Contact
+			err := r.db.WithContext(ctx).Preload("Organization").
+				Where("is_deleted = ? AND "+check.expr+" = ?", false, key).
+				Order("id ASC").
+				Find(&contacts).Error
+			if err != nil {
+				return nil, err
+			}
+			if err := r.withRegistrationCount(ctx, contacts); err != nil {
+				return nil, err
+			}
+
+			groups = append(groups, DuplicateContactGroup{Reason: check.reason, Key: key, Contacts: contacts})
+		}
+	}
+
+	return groups, nil
+}
+
+// Create implements ContactRepository.
+func (r *contactRepository) Create(ctx context.Context, contact models.Contact) (models.Contact, error) {
+	err := r.db.WithContext(ctx).Omit("Organization", "Registrations").Create(&contact).Error
+	return contact, err
+}
+
+// Update implements ContactRepository.
+func (r *contactRepository) Update(ctx context.Context, contact models.Contact) (models.Contact, error) {
+	err := r.db.WithContext(ctx).Omit("Organization", "Registrations").Save(&contact).Error
+	return contact, err
+}
+
+// Merge implements ContactRepository.
+// Registrasi contact sumber dipindah ke target, contact sumber ditandai merged & dihapus (soft delete)
+func (r *contactRepository) Merge(ctx context.Context, target models.Contact, sourceIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Organization", "Registrations").Save(&target).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Registration{}).Where("contact_id IN ?", sourceIDs).Update("contact_id", target.ID).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Contact{}).
+			Where("id IN ? AND is_deleted = ?", sourceIDs, false).
+			Updates(map[string]any{"is_deleted": true, "merged_into_id": target.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(sourceIDs)) {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// FindOrCreateOrganization implements ContactRepository.
+func (r *contactRepository) FindOrCreateOrganization(ctx context.Context, name string) (models.Organization, error) {
+	organization := models.Organization{
+		Name:           name,
+		NormalizedName: utils.NormalizeName(name),
+	}
+
+	err := r.db.WithContext(ctx).Where(models.Organization{NormalizedName: organization.NormalizedName}).
+		FirstOrCreate(&organization).Error
+
+	return organization, err
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/models"
+	"gorm.io/gorm"
+)
+
+type CurriculumRepository interface {
+	FindModulesByProgramID(ctx context.Context, programID uint) ([]models.CurriculumModule, error)
+	FindModuleByID(ctx context.Context, programID uint, moduleID uint) (models.CurriculumModule, error)
+	CreateModule(ctx context.Context, module models.CurriculumModule) (models.CurriculumModule, error)
+	UpdateModule(ctx context.Context, module models.CurriculumModule) (models.CurriculumModule, error)
+	DeleteModule(ctx context.Context, id uint) error
+	ReorderModules(ctx context.Context, programID uint, ids []uint) error
+	FindLessonByID(ctx context.Context, moduleID uint, lessonID uint) (models.CurriculumLesson, error)
+	CreateLesson(ctx context.Context, lesson models.CurriculumLesson) (models.CurriculumLesson, error)
+	UpdateLesson(ctx context.Context, lesson models.CurriculumLesson) (models.CurriculumLesson, error)
+	DeleteLesson(ctx context.Context, id uint) error
+	ReorderLessons(ctx context.Context, moduleID uint, ids []uint) error
+}
+
+type curriculumRepository struct {
+	db *gorm.DB
+}
+
+func NewCurriculumRepository(db *gorm.DB) CurriculumRepository {
+	return &curriculumRepository{db}
+}
+
+// orderedLessons preload lesson sesuai urutan tampil
+func orderedLessons(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC, id ASC")
+}
+
+// FindModulesByProgramID implements CurriculumRepository.
+func (r *curriculumRepository) FindModulesByProgramID(ctx context.Context, programID uint) ([]models.CurriculumModule, error) {
+	var modules []models.CurriculumModule
+
+	err := r.db.WithContext(ctx).Preload("Lessons", orderedLessons).
+		Where("program_id = ?", programID).
+		Order("sort_order ASC, id ASC").
+		Find(&modules).Error
+
+	return modules, err
+}
+
+// FindModuleByID implements CurriculumRepository.
+func (r *curriculumRepository) FindModuleByID(ctx context.Context, programID uint, moduleID uint) (models.CurriculumModule, error) {
+	var module models.CurriculumModule
+
+	err := r.db.WithContext(ctx).Preload("Lessons", orderedLessons).
+		Where("id = ? AND program_id = ?", moduleID, programID).
+		First(&module).Error
+
+	return module, notFound(err, "Module")
+}
+
+// CreateModule implements CurriculumRepository.
+func (r *curriculumRepository) CreateModule(ctx context.Context, module models.CurriculumModule) (models.CurriculumModule, error) {
+	if module.SortOrder == 0 {
+		next, err := nextSortOrder(r.db.WithContext(ctx).Where("program_id = ?", module.ProgramID), &models.CurriculumModule{})
+		if err != nil {
+			return module, err
+		}
+		module.SortOrder = next
+	}
+
+	err := r.db.WithContext(ctx).Create(&module).Error
+	return module, err
+}
+
+// UpdateModule implements CurriculumRepository.
+func (r *curriculumRepository) UpdateModule(ctx context.Context, module models.CurriculumModule) (models.CurriculumModule, error) {
+	err := r.db.WithContext(ctx).Omit("Lessons").Save(&module).Error
+
+	return module, err
+}
+
+// DeleteModule implements CurriculumRepository.
+// Lesson di dalam module ikut terhapus
+func (r *curriculumRepository) DeleteModule(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("module_id = ?", id).Delete(&models.CurriculumLesson{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.CurriculumModule{}, id).Error
+	})
+}
+
+// ReorderModules implements CurriculumRepository.
+func (r *curriculumRepository) ReorderModules(ctx context.Context, programID uint, ids []uint) error {
+	return reorder(r.db.WithContext(ctx), &models.CurriculumModule{}, ids, func(db *gorm.DB) *gorm.DB {
+		return db.Where("program_id = ?", programID)
+	})
+}
+
+// FindLessonByID implements CurriculumRepository.
+func (r *curriculumRepository) FindLessonByID(ctx context.Context, moduleID uint, lessonID uint) (models.CurriculumLesson, error) {
+	var lesson models.CurriculumLesson
+
+	err := r.db.WithContext(ctx).Where("id = ? AND module_id = ?", lessonID, moduleID).First(&lesson).Error
+
+	return lesson, notFound(err, "Lesson")
+}
+
+// CreateLesson implements CurriculumRepository.
+func (r *curriculumRepository) CreateLesson(ctx context.Context, lesson models.CurriculumLesson) (models.CurriculumLesson, error) {
+	if lesson.SortOrder == 0 {
+		next, err := nextSortOrder(r.db.WithContext(ctx).Where("module_id = ?", lesson.ModuleID), &models.CurriculumLesson{})
+		if err != nil {
+			return lesson, err
+		}
+		lesson.SortOrder = next
+	}
+
+	err := r.db.WithContext(ctx).Create(&lesson).Error
+	return lesson, err
+}
+
+// UpdateLesson implements CurriculumRepository.
+func (r *curriculumRepository) UpdateLesson(ctx context.Context, lesson models.CurriculumLesson) (models.CurriculumLesson, error) {
+	err := r.db.WithContext(ctx).Save(&lesson).Error
+
+	return lesson, err
+}
+
+// DeleteLesson implements CurriculumRepository.
+func (r *curriculumRepository) DeleteLesson(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.CurriculumLesson{}, id).Error
+}
+
+// ReorderLessons implements CurriculumRepository.
+func (r *curriculumRepository) ReorderLessons(ctx context.Context, moduleID uint, ids []uint) error {
+	return reorder(r.db.WithContext(ctx), &models.CurriculumLesson{}, ids, func(db *gorm.DB) *gorm.DB {
+		return db.Where("module_id = ?", moduleID)
+	})
+}
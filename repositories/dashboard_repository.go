@@ -1,18 +1,20 @@
 package repositories
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"gorm.io/gorm"
 )
 
 type DashboardRepository interface {
-	GetTotalProgram() (int64, error)
-	GetTotalRegistration() (int64, error)
-	GetActiveParticipants() (int64, error)
-	GetPendingParticipants() (int64, error)
-	GetLatestRegistrations(limit int) ([]models.Registration, error)
-	GetRecentActivities(limit int) ([]models.Registration, error)
-	GetPopularPrograms(limit int) ([]PopularProgram, error)
+	GetTotalProgram(ctx context.Context) (int64, error)
+	GetTotalRegistration(ctx context.Context) (int64, error)
+	GetActiveParticipants(ctx context.Context) (int64, error)
+	GetPendingParticipants(ctx context.Context) (int64, error)
+	GetLatestRegistrations(ctx context.Context, limit int) ([]models.Registration, error)
+	GetRecentActivities(ctx context.Context, limit int) ([]models.Registration, error)
+	GetPopularPrograms(ctx context.Context, limit int) ([]PopularProgram, error)
 }
 
 type PopularProgram struct {
@@ -21,6 +23,7 @@ type PopularProgram struct {
 	Icon              string `json:"icon"`
 	Level             string `json:"level"`
 	TotalRegistration int64  `json:"total_registration"`
+	TotalAttendees    int64  `json:"total_attendees"`
 }
 
 type dashboardRepository struct {
@@ -31,37 +34,41 @@ func NewDashboardRepository(db *gorm.DB) DashboardRepository {
 	return &dashboardRepository{db}
 }
 
-func (r *dashboardRepository) GetTotalProgram() (int64, error) {
+func (r *dashboardRepository) GetTotalProgram(ctx context.Context) (int64, error) {
 	var total int64
-	err := r.db.Model(&models.Program{}).Where("is_deleted = ?", false).Count(&total).Error
+	err := r.db.WithContext(ctx).Model(&models.Program{}).Where("is_deleted = ?", false).Count(&total).Error
 	return total, err
 }
 
-func (r *dashboardRepository) GetTotalRegistration() (int64, error) {
+func (r *dashboardRepository) GetTotalRegistration(ctx context.Context) (int64, error) {
 	var total int64
-	err := r.db.Model(&models.Registration{}).Where("is_deleted = ?", false).Count(&total).Error
+	err := r.db.WithContext(ctx).Model(&models.Registration{}).Where("is_deleted = ?", false).Count(&total).Error
 	return total, err
 }
 
-func (r *dashboardRepository) GetActiveParticipants() (int64, error) {
+// GetActiveParticipants menjumlahkan participants (registrasi grup dihitung per orang)
+func (r *dashboardRepository) GetActiveParticipants(ctx context.Context) (int64, error) {
 	var total int64
-	err := r.db.Model(&models.Registration{}).
+	err := r.db.WithContext(ctx).Model(&models.Registration{}).
+		Select("COALESCE(SUM(participants), 0)").
 		Where("status = ? AND is_deleted = ?", "active", false).
-		Count(&total).Error
+		Scan(&total).Error
 	return total, err
 }
 
-func (r *dashboardRepository) GetPendingParticipants() (int64, error) {
+// GetPendingParticipants menjumlahkan participants (registrasi grup dihitung per orang)
+func (r *dashboardRepository) GetPendingParticipants(ctx context.Context) (int64, error) {
 	var total int64
-	err := r.db.Model(&models.Registration{}).
+	err := r.db.WithContext(ctx).Model(&models.Registration{}).
+		Select("COALESCE(SUM(participants), 0)").
 		Where("status = ? AND is_deleted = ?", "pending", false).
-		Count(&total).Error
+		Scan(&total).Error
 	return total, err
 }
 
-func (r *dashboardRepository) GetLatestRegistrations(limit int) ([]models.Registration, error) {
+func (r *dashboardRepository) GetLatestRegistrations(ctx context.Context, limit int) ([]models.Registration, error) {
 	var registrations []models.Registration
-	err := r.db.Preload("Program").
+	err := r.db.WithContext(ctx).Preload("Program").
 		Where("is_deleted = ?", false).
 		Order("created_at DESC").
 		Limit(limit).
@@ -69,9 +76,9 @@ func (r *dashboardRepository) GetLatestRegistrations(limit int) ([]models.Regist
 	return registrations, err
 }
 
-func (r *dashboardRepository) GetRecentActivities(limit int) ([]models.Registration, error) {
+func (r *dashboardRepository) GetRecentActivities(ctx context.Context, limit int) ([]models.Registration, error) {
 	var registrations []models.Registration
-	err := r.db.Preload("Program").
+	err := r.db.WithContext(ctx).Preload("Program").
 		Where("is_deleted = ?", false).
 		Order("updated_at DESC").
 		Limit(limit).
@@ -79,14 +86,14 @@ func (r *dashboardRepository) GetRecentActivities(limit int) ([]models.Registrat
 	return registrations, err
 }
 
-func (r *dashboardRepository) GetPopularPrograms(limit int) ([]PopularProgram, error) {
+func (r *dashboardRepository) GetPopularPrograms(ctx context.Context, limit int) ([]PopularProgram, error) {
 	var programs []PopularProgram
-	err := r.db.Table("programs").
-		Select("programs.id, programs.title, programs.icon, programs.level, COUNT(registrations.id) as total_registration").
+	err := r.db.WithContext(ctx).Table("programs").
+		Select("programs.id, programs.title, programs.icon, programs.level, COUNT(registrations.id) as total_registration, COALESCE(SUM(registrations.participants), 0) as total_attendees").
 		Joins("LEFT JOIN registrations ON registrations.program_id = programs.id AND registrations.is_deleted = false").
 		Where("programs.is_deleted = ?", false).
 		Group("programs.id, programs.title, programs.icon, programs.level").
-		Order("total_registration DESC").
+		Order("total_attendees DESC, total_registration DESC").
 		Limit(limit).
 		Scan(&programs).Error
 	return programs, err
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/tech-azim/be-learnova/apperrors"
+	"gorm.io/gorm"
+)
+
+// pgUniqueViolation kode error Postgres untuk pelanggaran unique constraint
+const pgUniqueViolation = "23505"
+
+// isUniqueViolation cek apakah err berasal dari unique constraint/index tertentu
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == constraint
+}
+
+// notFound ubah gorm.ErrRecordNotFound menjadi error NotFound dengan kode <resource>_not_found.
+// Error lain (koneksi, query) dikembalikan apa adanya; errors.Is(err, gorm.ErrRecordNotFound) tetap berlaku.
+func notFound(err error, resource string) error {
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return &apperrors.Error{
+		Kind:    apperrors.KindNotFound,
+		Code:    strings.ReplaceAll(strings.ToLower(resource), " ", "_") + "_not_found",
+		Message: resource + " not found",
+		Err:     err,
+	}
+}
@@ -1,18 +1,21 @@
 package repositories
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/utils"
 	"gorm.io/gorm"
 )
 
 type FeatureRepository interface {
-	FindAll(param utils.PaginationParams) ([]models.Feature, int64, error)
-	FindByID(id uint) (models.Feature, error)
-	Create(feature models.Feature) (models.Feature, error)
-	Update(feature models.Feature) (models.Feature, error)
-	Delete(id uint) error
-	FindAllActive() ([]models.Feature, error)
+	FindAll(ctx context.Context, param utils.PaginationParams) ([]models.Feature, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Feature, error)
+	Create(ctx context.Context, feature models.Feature) (models.Feature, error)
+	Update(ctx context.Context, feature models.Feature) (models.Feature, error)
+	Delete(ctx context.Context, id uint) error
+	FindAllActive(ctx context.Context) ([]models.Feature, error)
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type featureRepository struct {
@@ -24,58 +27,71 @@ func NewFeatureRepository(db *gorm.DB) FeatureRepository {
 }
 
 // Create implements FeatureRepository.
-func (r *featureRepository) Create(feature models.Feature) (models.Feature, error) {
-	err := r.db.Create(&feature).Error
+func (r *featureRepository) Create(ctx context.Context, feature models.Feature) (models.Feature, error) {
+	if feature.SortOrder == 0 {
+		next, err := nextSortOrder(r.db.WithContext(ctx), &models.Feature{})
+		if err != nil {
+			return feature, err
+		}
+		feature.SortOrder = next
+	}
+
+	err := r.db.WithContext(ctx).Create(&feature).Error
 	return feature, err
 }
 
 // Delete implements FeatureRepository.
-func (r *featureRepository) Delete(id uint) error {
-	err := r.db.Model(&models.Feature{}).Where("id = ?", id).Update("is_deleted", true).Error
+func (r *featureRepository) Delete(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Model(&models.Feature{}).Where("id = ?", id).Update("is_deleted", true).Error
 	return err
 }
 
 // FindAll implements FeatureRepository.
-func (r *featureRepository) FindAll(params utils.PaginationParams) ([]models.Feature, int64, error) {
+func (r *featureRepository) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Feature, int64, error) {
 	offset := (params.Page - 1) * params.Limit
 
 	var features []models.Feature
 	var total int64
 
-	query := r.db.Model(&models.Feature{}).Where("is_deleted = ?", false)
+	query := r.db.WithContext(ctx).Model(&models.Feature{}).Where("is_deleted = ?", false)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := query.Order("created_at ASC").Offset(offset).Limit(params.Limit).Find(&features).Error
+	err := query.Order("sort_order ASC, created_at ASC").Offset(offset).Limit(params.Limit).Find(&features).Error
 
 	return features, total, err
 }
 
 // FindByID implements FeatureRepository.
-func (r *featureRepository) FindByID(id uint) (models.Feature, error) {
+func (r *featureRepository) FindByID(ctx context.Context, id uint) (models.Feature, error) {
 	var feature models.Feature
 
-	err := r.db.Where("id = ? AND is_deleted = ?", id, false).First(&feature).Error
+	err := r.db.WithContext(ctx).Where("id = ? AND is_deleted = ?", id, false).First(&feature).Error
 
-	return feature, err
+	return feature, notFound(err, "Feature")
 }
 
 // Update implements FeatureRepository.
-func (r *featureRepository) Update(feature models.Feature) (models.Feature, error) {
-	err := r.db.Save(&feature).Error
+func (r *featureRepository) Update(ctx context.Context, feature models.Feature) (models.Feature, error) {
+	err := r.db.WithContext(ctx).Save(&feature).Error
 
 	return feature, err
 }
 
 // FindAllActive implements FeatureRepository.
-func (r *featureRepository) FindAllActive() ([]models.Feature, error) {
+func (r *featureRepository) FindAllActive(ctx context.Context) ([]models.Feature, error) {
 	var features []models.Feature
 
-	err := r.db.Where("is_deleted = ? AND is_active = ?", false, true).
-		Order("created_at DESC").
+	err := r.db.WithContext(ctx).Where("is_deleted = ? AND is_active = ?", false, true).
+		Order("sort_order ASC, created_at DESC").
 		Find(&features).Error
 
 	return features, err
-}
\ No newline at end of file
+}
+
+// Reorder implements FeatureRepository.
+func (r *featureRepository) Reorder(ctx context.Context, ids []uint) error {
+	return reorder(r.db.WithContext(ctx), &models.Feature{}, ids, notDeleted)
+}
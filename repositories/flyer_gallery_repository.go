@@ -1,18 +1,21 @@
 package repositories
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/utils"
 	"gorm.io/gorm"
 )
 
 type FlyerGalleryRepository interface {
-	FindAll(param utils.PaginationParams) ([]models.FlyerGallery, int64, error)
-	FindByID(id uint) (models.FlyerGallery, error)
-	Create(flyerGallery models.FlyerGallery) (models.FlyerGallery, error)
-	Update(flyerGallery models.FlyerGallery) (models.FlyerGallery, error)
-	Delete(id uint) error
-	FindAllActive() ([]models.FlyerGallery, error)
+	FindAll(ctx context.Context, param utils.PaginationParams) ([]models.FlyerGallery, int64, error)
+	FindByID(ctx context.Context, id uint) (models.FlyerGallery, error)
+	Create(ctx context.Context, flyerGallery models.FlyerGallery) (models.FlyerGallery, error)
+	Update(ctx context.Context, flyerGallery models.FlyerGallery) (models.FlyerGallery, error)
+	Delete(ctx context.Context, id uint) error
+	FindAllActive(ctx context.Context) ([]models.FlyerGallery, error)
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type flyerGalleryRepository struct {
@@ -24,58 +27,71 @@ func NewFlyerGalleryRepository(db *gorm.DB) FlyerGalleryRepository {
 }
 
 // Create implements FlyerGalleryRepository.
-func (r *flyerGalleryRepository) Create(flyerGallery models.FlyerGallery) (models.FlyerGallery, error) {
-	err := r.db.Create(&flyerGallery).Error
+func (r *flyerGalleryRepository) Create(ctx context.Context, flyerGallery models.FlyerGallery) (models.FlyerGallery, error) {
+	if flyerGallery.SortOrder == 0 {
+		next, err := nextSortOrder(r.db.WithContext(ctx), &models.FlyerGallery{})
+		if err != nil {
+			return flyerGallery, err
+		}
+		flyerGallery.SortOrder = next
+	}
+
+	err := r.db.WithContext(ctx).Create(&flyerGallery).Error
 	return flyerGallery, err
 }
 
 // Delete implements FlyerGalleryRepository.
-func (r *flyerGalleryRepository) Delete(id uint) error {
-	err := r.db.Model(&models.FlyerGallery{}).Where("id = ?", id).Update("is_deleted", true).Error
+func (r *flyerGalleryRepository) Delete(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Model(&models.FlyerGallery{}).Where("id = ?", id).Update("is_deleted", true).Error
 	return err
 }
 
 // FindAll implements FlyerGalleryRepository.
-func (r *flyerGalleryRepository) FindAll(params utils.PaginationParams) ([]models.FlyerGallery, int64, error) {
+func (r *flyerGalleryRepository) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.FlyerGallery, int64, error) {
 	offset := (params.Page - 1) * params.Limit
 
 	var flyerGalleries []models.FlyerGallery
 	var total int64
 
-	query := r.db.Model(&models.FlyerGallery{}).Where("is_deleted = ?", false)
+	query := r.db.WithContext(ctx).Model(&models.FlyerGallery{}).Where("is_deleted = ?", false)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := query.Order("created_at DESC").Offset(offset).Limit(params.Limit).Find(&flyerGalleries).Error
+	err := query.Order("sort_order ASC, created_at DESC").Offset(offset).Limit(params.Limit).Find(&flyerGalleries).Error
 
 	return flyerGalleries, total, err
 }
 
 // FindByID implements FlyerGalleryRepository.
-func (r *flyerGalleryRepository) FindByID(id uint) (models.FlyerGallery, error) {
+func (r *flyerGalleryRepository) FindByID(ctx context.Context, id uint) (models.FlyerGallery, error) {
 	var flyerGallery models.FlyerGallery
 
-	err := r.db.Where("id = ? AND is_deleted = ?", id, false).First(&flyerGallery).Error
+	err := r.db.WithContext(ctx).Where("id = ? AND is_deleted = ?", id, false).First(&flyerGallery).Error
 
-	return flyerGallery, err
+	return flyerGallery, notFound(err, "Flyer gallery")
 }
 
 // Update implements FlyerGalleryRepository.
-func (r *flyerGalleryRepository) Update(flyerGallery models.FlyerGallery) (models.FlyerGallery, error) {
-	err := r.db.Save(&flyerGallery).Error
+func (r *flyerGalleryRepository) Update(ctx context.Context, flyerGallery models.FlyerGallery) (models.FlyerGallery, error) {
+	err := r.db.WithContext(ctx).Save(&flyerGallery).Error
 
 	return flyerGallery, err
 }
 
 // FindAllActive implements FlyerGalleryRepository.
-func (r *flyerGalleryRepository) FindAllActive() ([]models.FlyerGallery, error) {
+func (r *flyerGalleryRepository) FindAllActive(ctx context.Context) ([]models.FlyerGallery, error) {
 	var flyerGalleries []models.FlyerGallery
 
-	err := r.db.Where("is_deleted = ? AND is_active = ?", false, true).
-		Order("created_at DESC").
+	err := r.db.WithContext(ctx).Where("is_deleted = ? AND is_active = ?", false, true).
+		Order("sort_order ASC, created_at DESC").
 		Find(&flyerGalleries).Error
 
 	return flyerGalleries, err
-}
\ No newline at end of file
+}
+
+// Reorder implements FlyerGalleryRepository.
+func (r *flyerGalleryRepository) Reorder(ctx context.Context, ids []uint) error {
+	return reorder(r.db.WithContext(ctx), &models.FlyerGallery{}, ids, notDeleted)
+}
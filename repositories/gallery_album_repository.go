@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
+)
+
+type GalleryAlbumRepository interface {
+	FindAll(ctx context.Context, param utils.PaginationParams) ([]models.GalleryAlbum, int64, error)
+	FindAllActive(ctx context.Context, param utils.PaginationParams) ([]models.GalleryAlbum, int64, error)
+	FindByID(ctx context.Context, id uint) (models.GalleryAlbum, error)
+	FindActiveByID(ctx context.Context, id uint) (models.GalleryAlbum, error)
+	Create(ctx context.Context, album models.GalleryAlbum, images []models.Gallery) (models.GalleryAlbum, error)
+	Update(ctx context.Context, album models.GalleryAlbum) (models.GalleryAlbum, error)
+	Delete(ctx context.Context, id uint) error
+	AddImages(ctx context.Context, albumID uint, images []models.Gallery) ([]models.Gallery, error)
+	Reorder(ctx context.Context, ids []uint) error
+}
+
+type galleryAlbumRepository struct {
+	db *gorm.DB
+}
+
+func NewGalleryAlbumRepository(db *gorm.DB) GalleryAlbumRepository {
+	return &galleryAlbumRepository{db}
+}
+
+// activeImages preload gambar album yang belum dihapus sesuai urutan tampil
+func activeImages(db *gorm.DB) *gorm.DB {
+	return db.Where("is_deleted = ?", false).Order("sort_order ASC, id ASC")
+}
+
+// withImageCount mengisi ImageCount untuk listing album tanpa preload semua gambar
+func (r *galleryAlbumRepository) withImageCount(ctx context.Context, albums []models.GalleryAlbum) error {
+	if len(albums) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(albums))
+	for _, album := range albums {
+		ids = append(ids, album.ID)
+	}
+
+	var rows []struct {
+		AlbumID uint
+		Total   int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.Gallery{}).
+		Select("album_id, COUNT(*) AS total").
+		Where("album_id IN ? AND is_deleted = ?", ids, false).
+		Group("album_id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.AlbumID] = row.Total
+	}
+	for i := range albums {
+		albums[i].ImageCount = counts[albums[i].ID]
+	}
+	return nil
+}
+
+// Create implements GalleryAlbumRepository.
+// Album dan seluruh gambar disimpan dalam satu transaksi
+func (r *galleryAlbumRepository) Create(ctx context.Context, album models.GalleryAlbum, images []models.Gallery) (models.GalleryAlbum, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if album.SortOrder == 0 {
+			next, err := nextSortOrder(tx, &models.GalleryAlbum{})
+			if err != nil {
+				return err
+			}
+			album.SortOrder = next
+		}
+
+		if err := tx.Omit("Images", "Program").Create(&album).Error; err != nil {
+			return err
+		}
+
+		for i := range images {
+			images[i].AlbumID = &album.ID
+			images[i].SortOrder = i + 1
+		}
+		if len(images) > 0 {
+			if err := tx.Create(&images).Error; err != nil {
+				return err
+			}
+		}
+
+		album.Images = images
+		album.ImageCount = int64(len(images))
+		return nil
+	})
+
+	return album, err
+}
+
+// Delete implements GalleryAlbumRepository.
+// Gambar di dalam album ikut di-soft delete
+func (r *galleryAlbumRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Gallery{}).Where("album_id = ?", id).Update("is_deleted", true).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.GalleryAlbum{}).Where("id = ?", id).Update("is_deleted", true).Error
+	})
+}
+
+// FindAll implements GalleryAlbumRepository.
+func (r *galleryAlbumRepository) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.GalleryAlbum, int64, error) {
+	offset := (params.Page - 1) * params.Limit
+
+	var albums []models.GalleryAlbum
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.GalleryAlbum{}).Where("is_deleted = ?", false)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Program").Order("sort_order ASC, event_date DESC").Offset(offset).Limit(params.Limit).Find(&albums).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return albums, total, r.withImageCount(ctx, albums)
+}
+
+// FindAllActive implements GalleryAlbumRepository.
+func (r *galleryAlbumRepository) FindAllActive(ctx context.Context, params utils.PaginationParams) ([]models.GalleryAlbum, int64, error) {
+	offset := (params.Page - 1) * params.Limit
+
+	var albums []models.GalleryAlbum
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.GalleryAlbum{}).Where("is_deleted = ? AND is_active = ?", false, true)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Program").Order("sort_order ASC, event_date DESC").Offset(offset).Limit(params.Limit).Find(&albums).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return albums, total, r.withImageCount(ctx, albums)
+}
+
+// FindByID implements GalleryAlbumRepository.
+func (r *galleryAlbumRepository) FindByID(ctx context.Context, id uint) (models.GalleryAlbum, error) {
+	var album models.GalleryAlbum
+
+	err := r.db.WithContext(ctx).Preload("Program").Preload("Images", activeImages).
+		Where("id = ? AND is_deleted = ?", id, false).
+		First(&album).Error
+	album.ImageCount = int64(len(album.Images))
+
+	return album, notFound(err, "Gallery album")
+}
+
+// FindActiveByID implements GalleryAlbumRepository.
+// Untuk halaman publik: album dan gambar harus aktif
+func (r *galleryAlbumRepository) FindActiveByID(ctx context.Context, id uint) (models.GalleryAlbum, error) {
+	var album models.GalleryAlbum
+
+	err := r.db.WithContext(ctx).Preload("Program").
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return activeImages(db).Where("is_active = ?", true)
+		}).
+		Where("id = ? AND is_deleted = ? AND is_active = ?", id, false, true).
+		First(&album).Error
+	album.ImageCount = int64(len(album.Images))
+
+	return album, notFound(err, "Gallery album")
+}
+
+// Update implements GalleryAlbumRepository.
+func (r *galleryAlbumRepository) Update(ctx context.Context, album models.GalleryAlbum) (models.GalleryAlbum, error) {
+	err := r.db.WithContext(ctx).Omit("Images", "Program").Save(&album).Error
+
+	return album, err
+}
+
+// AddImages implements GalleryAlbumRepository.
+// Gambar baru ditambahkan di urutan paling akhir album
+func (r *galleryAlbumRepository) AddImages(ctx context.Context, albumID uint, images []models.Gallery) ([]models.Gallery, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		next, err := nextSortOrder(tx.Where("album_id = ?", albumID), &models.Gallery{})
+		if err != nil {
+			return err
+		}
+
+		for i := range images {
+			images[i].AlbumID = &albumID
+			images[i].SortOrder = next + i
+		}
+
+		return tx.Create(&images).Error
+	})
+
+	return images, err
+}
+
+// Reorder implements GalleryAlbumRepository.
+func (r *galleryAlbumRepository) Reorder(ctx context.Context, ids []uint) error {
+	return reorder(r.db.WithContext(ctx), &models.GalleryAlbum{}, ids, notDeleted)
+}
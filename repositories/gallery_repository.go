@@ -1,18 +1,22 @@
 package repositories
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/utils"
 	"gorm.io/gorm"
 )
 
 type GalleryRepository interface {
-	FindAll(param utils.PaginationParams) ([]models.Gallery, int64, error)
-	FindByID(id uint) (models.Gallery, error)
-	Create(gallery models.Gallery) (models.Gallery, error)
-	Update(gallery models.Gallery) (models.Gallery, error)
-	Delete(id uint) error
-	FindAllActive() ([]models.Gallery, error)
+	FindAll(ctx context.Context, param utils.PaginationParams) ([]models.Gallery, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Gallery, error)
+	FindBySlug(ctx context.Context, slug string) (models.Gallery, error)
+	Create(ctx context.Context, gallery models.Gallery) (models.Gallery, error)
+	Update(ctx context.Context, gallery models.Gallery) (models.Gallery, error)
+	Delete(ctx context.Context, id uint) error
+	FindAllActive(ctx context.Context) ([]models.Gallery, error)
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type galleryRepository struct {
@@ -23,59 +27,87 @@ func NewGalleryRepository(db *gorm.DB) GalleryRepository {
 	return &galleryRepository{db}
 }
 
+// standaloneGallery scope gambar gallery yang bukan bagian album,
+// gambar album dikelola lewat GalleryAlbumRepository dengan urutan sendiri
+func standaloneGallery(db *gorm.DB) *gorm.DB {
+	return db.Where("album_id IS NULL")
+}
+
 // Create implements GalleryRepository.
-func (r *galleryRepository) Create(gallery models.Gallery) (models.Gallery, error) {
-	err := r.db.Create(&gallery).Error
+func (r *galleryRepository) Create(ctx context.Context, gallery models.Gallery) (models.Gallery, error) {
+	if gallery.SortOrder == 0 {
+		next, err := nextSortOrder(r.db.WithContext(ctx).Scopes(standaloneGallery), &models.Gallery{})
+		if err != nil {
+			return gallery, err
+		}
+		gallery.SortOrder = next
+	}
+
+	err := r.db.WithContext(ctx).Create(&gallery).Error
 	return gallery, err
 }
 
 // Delete implements GalleryRepository.
-func (r *galleryRepository) Delete(id uint) error {
-	err := r.db.Model(&models.Gallery{}).Where("id = ?", id).Update("is_deleted", true).Error
+func (r *galleryRepository) Delete(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Model(&models.Gallery{}).Where("id = ?", id).Update("is_deleted", true).Error
 	return err
 }
 
 // FindAll implements GalleryRepository.
-func (r *galleryRepository) FindAll(params utils.PaginationParams) ([]models.Gallery, int64, error) {
+func (r *galleryRepository) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Gallery, int64, error) {
 	offset := (params.Page - 1) * params.Limit
 
 	var galleries []models.Gallery
 	var total int64
 
-	query := r.db.Model(&models.Gallery{}).Where("is_deleted = ?", false)
+	query := r.db.WithContext(ctx).Model(&models.Gallery{}).Scopes(standaloneGallery).Where("is_deleted = ?", false)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := query.Order("date DESC").Offset(offset).Limit(params.Limit).Find(&galleries).Error
+	err := query.Order("sort_order ASC, date DESC").Offset(offset).Limit(params.Limit).Find(&galleries).Error
 
 	return galleries, total, err
 }
 
 // FindByID implements GalleryRepository.
-func (r *galleryRepository) FindByID(id uint) (models.Gallery, error) {
+func (r *galleryRepository) FindByID(ctx context.Context, id uint) (models.Gallery, error) {
 	var gallery models.Gallery
 
-	err := r.db.Where("id = ? AND is_deleted = ?", id, false).First(&gallery).Error
+	err := r.db.WithContext(ctx).Where("id = ? AND is_deleted = ?", id, false).First(&gallery).Error
 
-	return gallery, err
+	return gallery, notFound(err, "Gallery")
 }
 
 // Update implements GalleryRepository.
-func (r *galleryRepository) Update(gallery models.Gallery) (models.Gallery, error) {
-	err := r.db.Save(&gallery).Error
+func (r *galleryRepository) Update(ctx context.Context, gallery models.Gallery) (models.Gallery, error) {
+	err := r.db.WithContext(ctx).Save(&gallery).Error
 
 	return gallery, err
 }
 
 // FindAllActive implements GalleryRepository.
-func (r *galleryRepository) FindAllActive() ([]models.Gallery, error) {
+func (r *galleryRepository) FindAllActive(ctx context.Context) ([]models.Gallery, error) {
 	var galleries []models.Gallery
 
-	err := r.db.Where("is_deleted = ? AND is_active = ?", false, true).
-		Order("date DESC").
+	err := r.db.WithContext(ctx).Scopes(standaloneGallery).Where("is_deleted = ? AND is_active = ?", false, true).
+		Order("sort_order ASC, date DESC").
 		Find(&galleries).Error
 
 	return galleries, err
-}
\ No newline at end of file
+}
+
+// Reorder implements GalleryRepository.
+func (r *galleryRepository) Reorder(ctx context.Context, ids []uint) error {
+	return reorder(r.db.WithContext(ctx), &models.Gallery{}, ids, notDeleted, standaloneGallery)
+}
+
+// FindBySlug implements GalleryRepository.
+func (r *galleryRepository) FindBySlug(ctx context.Context, slug string) (models.Gallery, error) {
+	var gallery models.Gallery
+
+	err := r.db.WithContext(ctx).Scopes(standaloneGallery).Where("slug = ? AND is_deleted = ?", slug, false).First(&gallery).Error
+
+	return gallery, notFound(err, "Gallery")
+}
@@ -1,17 +1,20 @@
 package repositories
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/utils"
 	"gorm.io/gorm"
 )
 
 type HeroRepository interface {
-	FindAll(param utils.PaginationParams) ([]models.Hero,int64, error)
-	FindByID(id uint) (models.Hero, error)
-	Create(hero models.Hero) (models.Hero, error)
-	Update(hero models.Hero) (models.Hero, error)
-	Delete(id uint) error
+	FindAll(ctx context.Context, param utils.PaginationParams) ([]models.Hero,int64, error)
+	FindByID(ctx context.Context, id uint) (models.Hero, error)
+	Create(ctx context.Context, hero models.Hero) (models.Hero, error)
+	Update(ctx context.Context, hero models.Hero) (models.Hero, error)
+	Delete(ctx context.Context, id uint) error
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type heroRepository struct {
@@ -23,46 +26,58 @@ func NewHeroRepository(db *gorm.DB) HeroRepository {
 }
 
 // Create implements [HeroRepository].
-func (h *heroRepository) Create(hero models.Hero) (models.Hero, error) {
-	err := h.db.Create(&hero).Error
+func (h *heroRepository) Create(ctx context.Context, hero models.Hero) (models.Hero, error) {
+	if hero.SortOrder == 0 {
+		next, err := nextSortOrder(h.db.WithContext(ctx), &models.Hero{})
+		if err != nil {
+			return hero, err
+		}
+		hero.SortOrder = next
+	}
+
+	err := h.db.WithContext(ctx).Create(&hero).Error
 	return hero, err
 }
 
 // Delete implements [HeroRepository].
-func (h *heroRepository) Delete(id uint) error {
-	err := h.db.Delete(&models.Hero{}, id).Error
+func (h *heroRepository) Delete(ctx context.Context, id uint) error {
+	err := h.db.WithContext(ctx).Delete(&models.Hero{}, id).Error
 	return err
 }
 
 // FindAll implements [HeroRepository].
-func (h *heroRepository) FindAll(params utils.PaginationParams) ([]models.Hero,int64, error) {
+func (h *heroRepository) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Hero,int64, error) {
 	offset := (params.Page - 1) * params.Limit
 
 	var heroes []models.Hero
 	var total int64
 
-	if err := h.db.Model(&models.Hero{}).Count(&total).Error; err != nil {
+	if err := h.db.WithContext(ctx).Model(&models.Hero{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := h.db.Offset(offset).Limit(params.Limit).Find(&heroes).Error
+	err := h.db.WithContext(ctx).Order("sort_order ASC, id ASC").Offset(offset).Limit(params.Limit).Find(&heroes).Error
 
 	return heroes,total,err
 }
 
 // FindByID implements [HeroRepository].
-func (h *heroRepository) FindByID(id uint) (models.Hero, error) {
+func (h *heroRepository) FindByID(ctx context.Context, id uint) (models.Hero, error) {
 	var hero models.Hero
 	
-	err := h.db.Where("id = ?", id).First(&hero).Error
+	err := h.db.WithContext(ctx).Where("id = ?", id).First(&hero).Error
 	
-	return hero, err
+	return hero, notFound(err, "Hero")
 }
 
 // Update implements [HeroRepository].
-func (h *heroRepository) Update(hero models.Hero) (models.Hero, error) {
-	err := h.db.Save(&hero).Error
+func (h *heroRepository) Update(ctx context.Context, hero models.Hero) (models.Hero, error) {
+	err := h.db.WithContext(ctx).Save(&hero).Error
 
 	return hero, err
 }
 
+// Reorder implements [HeroRepository].
+func (h *heroRepository) Reorder(ctx context.Context, ids []uint) error {
+	return reorder(h.db.WithContext(ctx), &models.Hero{}, ids)
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
+)
+
+type InstructorRepository interface {
+	FindAll(ctx context.Context, param utils.PaginationParams) ([]models.Instructor, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Instructor, error)
+	Create(ctx context.Context, instructor models.Instructor) (models.Instructor, error)
+	Update(ctx context.Context, instructor models.Instructor) (models.Instructor, error)
+	Delete(ctx context.Context, id uint) error
+	FindByProgramID(ctx context.Context, programID uint) ([]models.ProgramInstructor, error)
+	SetProgramInstructors(ctx context.Context, programID uint, instructorIDs []uint) error
+}
+
+type instructorRepository struct {
+	db *gorm.DB
+}
+
+func NewInstructorRepository(db *gorm.DB) InstructorRepository {
+	return &instructorRepository{db}
+}
+
+// Create implements InstructorRepository.
+func (r *instructorRepository) Create(ctx context.Context, instructor models.Instructor) (models.Instructor, error) {
+	err := r.db.WithContext(ctx).Create(&instructor).Error
+	return instructor, err
+}
+
+// Delete implements InstructorRepository.
+// Instructor di-soft delete dan dilepas dari semua program
+func (r *instructorRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("instructor_id = ?", id).Delete(&models.ProgramInstructor{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Instructor{}).Where("id = ?", id).Update("is_deleted", true).Error
+	})
+}
+
+// FindAll implements InstructorRepository.
+func (r *instructorRepository) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Instructor, int64, error) {
+	offset := (params.Page - 1) * params.Limit
+
+	var instructors []models.Instructor
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.Instructor{}).Where("is_deleted = ?", false)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("name ASC").Offset(offset).Limit(params.Limit).Find(&instructors).Error
+
+	return instructors, total, err
+}
+
+// FindByID implements InstructorRepository.
+func (r *instructorRepository) FindByID(ctx context.Context, id uint) (models.Instructor, error) {
+	var instructor models.Instructor
+
+	err := r.db.WithContext(ctx).Where("id = ? AND is_deleted = ?", id, false).First(&instructor).Error
+
+	return instructor, notFound(err, "Instructor")
+}
+
+// Update implements InstructorRepository.
+func (r *instructorRepository) Update(ctx context.Context, instructor models.Instructor) (models.Instructor, error) {
+	err := r.db.WithContext(ctx).Save(&instructor).Error
+
+	return instructor, err
+}
+
+// FindByProgramID implements InstructorRepository.
+func (r *instructorRepository) FindByProgramID(ctx context.Context, programID uint) ([]models.ProgramInstructor, error) {
+	var instructors []models.ProgramInstructor
+
+	err := r.db.WithContext(ctx).Preload("Instructor").
+		Joins("JOIN instructors ON instructors.id = program_instructors.instructor_id AND instructors.is_deleted = ?", false).
+		Where("program_instructors.program_id = ?", programID).
+		Order("program_instructors.sort_order ASC").
+		Find(&instructors).Error
+
+	return instructors, err
+}
+
+// SetProgramInstructors implements InstructorRepository.
+// Mengganti seluruh daftar instructor program sesuai urutan ID (atomic)
+func (r *instructorRepository) SetProgramInstructors(ctx context.Context, programID uint, instructorIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(instructorIDs) > 0 {
+			var count int64
+			err := tx.Model(&models.Instructor{}).
+				Where("id IN ? AND is_deleted = ?", instructorIDs, false).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count != int64(len(instructorIDs)) {
+				return gorm.ErrRecordNotFound
+			}
+		}
+
+		if err := tx.Where("program_id = ?", programID).Delete(&models.ProgramInstructor{}).Error; err != nil {
+			return err
+		}
+
+		if len(instructorIDs) == 0 {
+			return nil
+		}
+
+		rows := make([]models.ProgramInstructor, 0, len(instructorIDs))
+		for i, id := range instructorIDs {
+			rows = append(rows, models.ProgramInstructor{
+				ProgramID:    programID,
+				InstructorID: id,
+				SortOrder:    i + 1,
+			})
+		}
+		return tx.Omit("Instructor").Create(&rows).Error
+	})
+}
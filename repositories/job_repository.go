@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
+)
+
+// ErrJobNotRetryable hanya job dead (dead-letter) yang bisa di-retry manual
+var ErrJobNotRetryable = apperrors.Conflict("job_not_retryable", "only dead jobs can be retried")
+
+// JobFilter filter listing job untuk admin
+type JobFilter struct {
+	Status string
+	Type   string
+	Queue  string
+}
+
+type JobRepository interface {
+	Create(ctx context.Context, job models.Job) (models.Job, error)
+	Claim(queues []string, workerID string) (models.Job, error)
+	Complete(job models.Job) error
+	Fail(job models.Job, message string, retryAt *time.Time) error
+	RescueStale(lockedBefore time.Time) (int64, error)
+	DeleteCompletedBefore(before time.Time) (int64, error)
+	FindAll(params utils.PaginationParams, filter JobFilter) ([]models.Job, int64, error)
+	FindByID(id uint) (models.Job, error)
+	Retry(id uint) (models.Job, error)
+	CountByStatus() (map[string]int64, error)
+}
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{db}
+}
+
+// Create implements JobRepository.
+func (r *jobRepository) Create(ctx context.Context, job models.Job) (models.Job, error) {
+	err := r.db.WithContext(ctx).Create(&job).Error
+
+	return job, err
+}
+
+// Claim implements JobRepository.
+// Ambil satu job pending yang sudah waktunya jalan. FOR UPDATE SKIP LOCKED membuat
+// beberapa worker (juga di replica lain) tidak pernah mengambil job yang sama.
+func (r *jobRepository) Claim(queues []string, workerID string) (models.Job, error) {
+	var job models.Job
+
+	result := r.db.Raw(`
+		UPDATE jobs
+		SET status = ?, attempts = attempts + 1, locked_at = now(), locked_by = ?, updated_at = now()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE status = ? AND run_at <= now() AND queue IN ?
+			ORDER BY run_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		models.JobStatusRunning, workerID, models.JobStatusPending, queues,
+	).Scan(&job)
+	if result.Error != nil {
+		return job, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return job, gorm.ErrRecordNotFound
+	}
+
+	return job, nil
+}
+
+// Complete implements JobRepository.
+func (r *jobRepository) Complete(job models.Job) error {
+	return r.db.Model(&models.Job{}).
+		Where("id = ? AND status = ? AND locked_by = ?", job.ID, models.JobStatusRunning, job.LockedBy).
+		Updates(map[string]any{
+			"status":       models.JobStatusCompleted,
+			"completed_at": time.Now(),
+			"locked_at":    nil,
+			"locked_by":    "",
+			"last_error":   "",
+		}).Error
+}
+
+// Fail implements JobRepository.
+// retryAt nil berarti job masuk dead-letter (status dead)
+func (r *jobRepository) Fail(job models.Job, message string, retryAt *time.Time) error {
+	updates := map[string]any{
+		"status":     models.JobStatusDead,
+		"locked_at":  nil,
+		"locked_by":  "",
+		"last_error": message,
+	}
+	if retryAt != nil {
+		updates["status"] = models.JobStatusPending
+		updates["run_at"] = *retryAt
+	}
+
+	return r.db.Model(&models.Job{}).
+		Where("id = ? AND status = ? AND locked_by = ?", job.ID, models.JobStatusRunning, job.LockedBy).
+		Updates(updates).Error
+}
+
+// RescueStale implements JobRepository.
+// Job running yang lock-nya kedaluwarsa (worker mati di tengah jalan) dikembalikan ke pending,
+// atau ke dead jika percobaan sudah habis
+func (r *jobRepository) RescueStale(lockedBefore time.Time) (int64, error) {
+	result := r.db.Exec(`
+		UPDATE jobs
+		SET status = CASE WHEN attempts >= max_attempts THEN ? ELSE ? END,
+			locked_at = NULL, locked_by = '', run_at = now(), updated_at = now(),
+			last_error = 'worker lock expired'
+		WHERE status = ? AND locked_at < ?`,
+		models.JobStatusDead, models.JobStatusPending, models.JobStatusRunning, lockedBefore,
+	)
+
+	return result.RowsAffected, result.Error
+}
+
+// DeleteCompletedBefore implements JobRepository.
+func (r *jobRepository) DeleteCompletedBefore(before time.Time) (int64, error) {
+	result := r.db.Where("status = ? AND completed_at < ?", models.JobStatusCompleted, before).Delete(&models.Job{})
+
+	return result.RowsAffected, result.Error
+}
+
+// FindAll implements JobRepository.
+func (r *jobRepository) FindAll(params utils.PaginationParams, filter JobFilter) ([]models.Job, int64, error) {
+	offset := (params.Page - 1) * params.Limit
+
+	var jobs []models.Job
+	var total int64
+
+	query := r.db.Model(&models.Job{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Queue != "" {
+		query = query.Where("queue = ?", filter.Queue)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id DESC").Offset(offset).Limit(params.Limit).Find(&jobs).Error
+
+	return jobs, total, err
+}
+
+// FindByID implements JobRepository.
+func (r *jobRepository) FindByID(id uint) (models.Job, error) {
+	var job models.Job
+
+	err := r.db.First(&job, id).Error
+
+	return job, notFound(err, "Job")
+}
+
+// Retry implements JobRepository.
+// Job dead dijalankan ulang dari awal (attempts di-reset), last_error tetap disimpan sebagai riwayat
+func (r *jobRepository) Retry(id uint) (models.Job, error) {
+	job, err := r.FindByID(id)
+	if err != nil {
+		return job, err
+	}
+
+	result := r.db.Model(&models.Job{}).
+		Where("id = ? AND status = ?", id, models.JobStatusDead).
+		Updates(map[string]any{
+			"status":   models.JobStatusPending,
+			"attempts": 0,
+			"run_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return job, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return job, ErrJobNotRetryable
+	}
+
+	return r.FindByID(id)
+}
+
+// CountByStatus implements JobRepository.
+func (r *jobRepository) CountByStatus() (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Total  int64
+	}
+	err := r.db.Model(&models.Job{}).
+		Select("status, COUNT(*) AS total").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int64{
+		models.JobStatusPending:   0,
+		models.JobStatusRunning:   0,
+		models.JobStatusCompleted: 0,
+		models.JobStatusDead:      0,
+	}
+	for _, row := range rows {
+		counts[row.Status] = row.Total
+	}
+	return counts, nil
+}
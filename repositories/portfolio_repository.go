@@ -1,7 +1,7 @@
 package repositories
 
 import (
-	"log"
+	"context"
 
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/utils"
@@ -9,11 +9,12 @@ import (
 )
 
 type PortfolioRepository interface {
-	FindAll(param utils.PaginationParams) ([]models.Portfolio, int64, error)
-	FindByID(id uint) (models.Portfolio, error)
-	Create(portfolio models.Portfolio) (models.Portfolio, error)
-	Update(portfolio models.Portfolio) (models.Portfolio, error)
-	Delete(id uint) error
+	FindAll(ctx context.Context, param utils.PaginationParams) ([]models.Portfolio, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Portfolio, error)
+	Create(ctx context.Context, portfolio models.Portfolio) (models.Portfolio, error)
+	Update(ctx context.Context, portfolio models.Portfolio) (models.Portfolio, error)
+	Delete(ctx context.Context, id uint) error
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type portfolioRepository struct {
@@ -25,62 +26,62 @@ func NewPortfolioRepository(db *gorm.DB) PortfolioRepository {
 }
 
 // Create implements PortfolioRepository.
-func (p *portfolioRepository) Create(portfolio models.Portfolio) (models.Portfolio, error) {
-	err := p.db.Create(&portfolio).Error
+func (p *portfolioRepository) Create(ctx context.Context, portfolio models.Portfolio) (models.Portfolio, error) {
+	if portfolio.SortOrder == 0 {
+		next, err := nextSortOrder(p.db.WithContext(ctx), &models.Portfolio{})
+		if err != nil {
+			return portfolio, err
+		}
+		portfolio.SortOrder = next
+	}
+
+	err := p.db.WithContext(ctx).Create(&portfolio).Error
 	return portfolio, err
 }
 
 // Delete implements PortfolioRepository.
-func (p *portfolioRepository) Delete(id uint) error {
-	err := p.db.Model(&models.Portfolio{}).Where("id = ?", id).Update("is_deleted", true).Error
+func (p *portfolioRepository) Delete(ctx context.Context, id uint) error {
+	err := p.db.WithContext(ctx).Model(&models.Portfolio{}).Where("id = ?", id).Update("is_deleted", true).Error
 	return err
 }
 
 // FindAll implements PortfolioRepository.
-func (p *portfolioRepository) FindAll(params utils.PaginationParams) ([]models.Portfolio, int64, error) {
+func (p *portfolioRepository) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Portfolio, int64, error) {
     offset := (params.Page - 1) * params.Limit
 
     var portfolios []models.Portfolio
     var total int64
 
-    // ✅ Debug: Log pagination params
-    log.Printf("=== FindAll Debug ===")
-    log.Printf("Params: Page=%d, Limit=%d, Offset=%d", params.Page, params.Limit, offset)
-
-    query := p.db.Model(&models.Portfolio{}).Where("is_deleted = ?", false)
-
-    // ✅ Debug: Enable SQL logging
-    query = query.Debug()
+    query := p.db.WithContext(ctx).Model(&models.Portfolio{}).Where("is_deleted = ?", false)
 
     if err := query.Count(&total).Error; err != nil {
-        log.Printf("Error counting portfolios: %v", err)
         return nil, 0, err
     }
-    log.Printf("Total count: %d", total)
 
-    err := query.Offset(offset).Limit(params.Limit).Find(&portfolios).Error
+    err := query.Order("sort_order ASC, created_at ASC").Offset(offset).Limit(params.Limit).Find(&portfolios).Error
     if err != nil {
-        log.Printf("Error finding portfolios: %v", err)
         return nil, 0, err
     }
 
-    log.Printf("Found %d portfolios in result", len(portfolios))
-    log.Printf("Portfolios data: %+v", portfolios)
-
     return portfolios, total, nil
 }
 // FindByID implements PortfolioRepository.
-func (p *portfolioRepository) FindByID(id uint) (models.Portfolio, error) {
+func (p *portfolioRepository) FindByID(ctx context.Context, id uint) (models.Portfolio, error) {
 	var portfolio models.Portfolio
 
-	err := p.db.Where("id = ? AND is_deleted = ?", id, false).First(&portfolio).Error
+	err := p.db.WithContext(ctx).Where("id = ? AND is_deleted = ?", id, false).First(&portfolio).Error
 
-	return portfolio, err
+	return portfolio, notFound(err, "Portfolio")
 }
 
 // Update implements PortfolioRepository.
-func (p *portfolioRepository) Update(portfolio models.Portfolio) (models.Portfolio, error) {
-	err := p.db.Save(&portfolio).Error
+func (p *portfolioRepository) Update(ctx context.Context, portfolio models.Portfolio) (models.Portfolio, error) {
+	err := p.db.WithContext(ctx).Save(&portfolio).Error
 
 	return portfolio, err
-}
\ No newline at end of file
+}
+
+// Reorder implements PortfolioRepository.
+func (p *portfolioRepository) Reorder(ctx context.Context, ids []uint) error {
+	return reorder(p.db.WithContext(ctx), &models.Portfolio{}, ids, notDeleted)
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/models"
+	"gorm.io/gorm"
+)
+
+type ProgramFAQRepository interface {
+	FindByProgramID(ctx context.Context, programID uint) ([]models.ProgramFAQ, error)
+	FindByID(ctx context.Context, programID uint, id uint) (models.ProgramFAQ, error)
+	Create(ctx context.Context, faq models.ProgramFAQ) (models.ProgramFAQ, error)
+	Update(ctx context.Context, faq models.ProgramFAQ) (models.ProgramFAQ, error)
+	Delete(ctx context.Context, id uint) error
+	Reorder(ctx context.Context, programID uint, ids []uint) error
+}
+
+type programFAQRepository struct {
+	db *gorm.DB
+}
+
+func NewProgramFAQRepository(db *gorm.DB) ProgramFAQRepository {
+	return &programFAQRepository{db}
+}
+
+// FindByProgramID implements ProgramFAQRepository.
+func (r *programFAQRepository) FindByProgramID(ctx context.Context, programID uint) ([]models.ProgramFAQ, error) {
+	var faqs []models.ProgramFAQ
+
+	err := r.db.WithContext(ctx).Where("program_id = ?", programID).Order("sort_order ASC, id ASC").Find(&faqs).Error
+
+	return faqs, err
+}
+
+// FindByID implements ProgramFAQRepository.
+func (r *programFAQRepository) FindByID(ctx context.Context, programID uint, id uint) (models.ProgramFAQ, error) {
+	var faq models.ProgramFAQ
+
+	err := r.db.WithContext(ctx).Where("id = ? AND program_id = ?", id, programID).First(&faq).Error
+
+	return faq, notFound(err, "FAQ")
+}
+
+// Create implements ProgramFAQRepository.
+func (r *programFAQRepository) Create(ctx context.Context, faq models.ProgramFAQ) (models.ProgramFAQ, error) {
+	if faq.SortOrder == 0 {
+		next, err := nextSortOrder(r.db.WithContext(ctx).Where("program_id = ?", faq.ProgramID), &models.ProgramFAQ{})
+		if err != nil {
+			return faq, err
+		}
+		faq.SortOrder = next
+	}
+
+	err := r.db.WithContext(ctx).Create(&faq).Error
+	return faq, err
+}
+
+// Update implements ProgramFAQRepository.
+func (r *programFAQRepository) Update(ctx context.Context, faq models.ProgramFAQ) (models.ProgramFAQ, error) {
+	err := r.db.WithContext(ctx).Save(&faq).Error
+
+	return faq, err
+}
+
+// Delete implements ProgramFAQRepository.
+func (r *programFAQRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.ProgramFAQ{}, id).Error
+}
+
+// Reorder implements ProgramFAQRepository.
+func (r *programFAQRepository) Reorder(ctx context.Context, programID uint, ids []uint) error {
+	return reorder(r.db.WithContext(ctx), &models.ProgramFAQ{}, ids, func(db *gorm.DB) *gorm.DB {
+		return db.Where("program_id = ?", programID)
+	})
+}
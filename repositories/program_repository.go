@@ -1,17 +1,21 @@
 package repositories
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/utils"
 	"gorm.io/gorm"
 )
 
 type ProgramRepository interface {
-	FindAll(param utils.PaginationParams) ([]models.Program, int64, error)
-	FindByID(id uint) (models.Program, error)
-	Create(program models.Program) (models.Program, error)
-	Update(program models.Program) (models.Program, error)
-	Delete(id uint) error
+	FindAll(ctx context.Context, param utils.PaginationParams) ([]models.Program, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Program, error)
+	FindDetailByID(ctx context.Context, id uint) (models.Program, error)
+	FindDetailBySlug(ctx context.Context, slug string) (models.Program, error)
+	Create(ctx context.Context, program models.Program) (models.Program, error)
+	Update(ctx context.Context, program models.Program) (models.Program, error)
+	Delete(ctx context.Context, id uint) error
 }
 
 type programRepository struct {
@@ -23,25 +27,25 @@ func NewProgramRepository(db *gorm.DB) ProgramRepository {
 }
 
 // Create implements ProgramRepository.
-func (p *programRepository) Create(program models.Program) (models.Program, error) {
-	err := p.db.Create(&program).Error
+func (p *programRepository) Create(ctx context.Context, program models.Program) (models.Program, error) {
+	err := p.db.WithContext(ctx).Create(&program).Error
 	return program, err
 }
 
 // Delete implements ProgramRepository.
-func (p *programRepository) Delete(id uint) error {
-	err := p.db.Model(&models.Program{}).Where("id = ?", id).Update("is_deleted", true).Error
+func (p *programRepository) Delete(ctx context.Context, id uint) error {
+	err := p.db.WithContext(ctx).Model(&models.Program{}).Where("id = ?", id).Update("is_deleted", true).Error
 	return err
 }
 
 // FindAll implements ProgramRepository.
-func (p *programRepository) FindAll(params utils.PaginationParams) ([]models.Program, int64, error) {
+func (p *programRepository) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Program, int64, error) {
 	offset := (params.Page - 1) * params.Limit
 
 	var programs []models.Program
 	var total int64
 
-	query := p.db.Model(&models.Program{}).Where("is_deleted = ?", false)
+	query := p.db.WithContext(ctx).Model(&models.Program{}).Where("is_deleted = ?", false)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -53,17 +57,62 @@ func (p *programRepository) FindAll(params utils.PaginationParams) ([]models.Pro
 }
 
 // FindByID implements ProgramRepository.
-func (p *programRepository) FindByID(id uint) (models.Program, error) {
+func (p *programRepository) FindByID(ctx context.Context, id uint) (models.Program, error) {
 	var program models.Program
 
-	err := p.db.Where("id = ? AND is_deleted = ?", id, false).First(&program).Error
+	err := p.db.WithContext(ctx).Where("id = ? AND is_deleted = ?", id, false).First(&program).Error
 
-	return program, err
+	return program, notFound(err, "Program")
 }
 
 // Update implements ProgramRepository.
-func (p *programRepository) Update(program models.Program) (models.Program, error) {
-	err := p.db.Save(&program).Error
+func (p *programRepository) Update(ctx context.Context, program models.Program) (models.Program, error) {
+	err := p.db.WithContext(ctx).Save(&program).Error
+
+	return program, err
+}
+
+// detailQuery preload curriculum (module + lesson), instructor, dan FAQ untuk halaman detail program
+func (p *programRepository) detailQuery(ctx context.Context) *gorm.DB {
+	return p.db.WithContext(ctx).
+		Preload("Curriculum", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC, id ASC")
+		}).
+		Preload("Curriculum.Lessons", orderedLessons).
+		Preload("Instructors", func(db *gorm.DB) *gorm.DB {
+			return db.Joins("JOIN instructors ON instructors.id = program_instructors.instructor_id AND instructors.is_deleted = ?", false).
+				Order("program_instructors.sort_order ASC")
+		}).
+		Preload("Instructors.Instructor").
+		Preload("FAQs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC, id ASC")
+		})
+}
+
+// FindDetailByID implements ProgramRepository.
+func (p *programRepository) FindDetailByID(ctx context.Context, id uint) (models.Program, error) {
+	var program models.Program
+
+	err := p.detailQuery(ctx).Where("id = ? AND is_deleted = ?", id, false).First(&program).Error
+	if err != nil {
+		return program, notFound(err, "Program")
+	}
+
+	program.SeatsTaken, err = seatsTaken(p.db.WithContext(ctx), program.ID, 0)
+
+	return program, err
+}
+
+// FindDetailBySlug implements ProgramRepository.
+func (p *programRepository) FindDetailBySlug(ctx context.Context, slug string) (models.Program, error) {
+	var program models.Program
+
+	err := p.detailQuery(ctx).Where("slug = ? AND is_deleted = ?", slug, false).First(&program).Error
+	if err != nil {
+		return program, notFound(err, "Program")
+	}
+
+	program.SeatsTaken, err = seatsTaken(p.db.WithContext(ctx), program.ID, 0)
 
 	return program, err
 }
@@ -1,20 +1,33 @@
 package repositories
 
 import (
+	"context"
+	"time"
+
+	"github.com/tech-azim/be-learnova/apperrors"
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/utils"
 	"gorm.io/gorm"
 )
 
+// ErrRegistrationExists email sudah terdaftar (belum dihapus) di program yang sama
+var ErrRegistrationExists = apperrors.Conflict("registration_exists", "email already registered for this program")
+
+// registrationEmailProgramIndex unique index (lower(email), program_id) untuk registrasi yang belum dihapus
+const registrationEmailProgramIndex = "idx_registrations_email_program"
+
 type RegistrationRepository interface {
-	FindAll(param utils.PaginationParams) ([]models.Registration, int64, error)
-	FindByID(id uint) (models.Registration, error)
-	FindByProgramID(programID uint, params utils.PaginationParams) ([]models.Registration, int64, error)
-	FindByEmail(email string) (models.Registration, error)
-	Create(registration models.Registration) (models.Registration, error)
-	Update(registration models.Registration) (models.Registration, error)
-	Delete(id uint) error
-	CheckEmailExists(email string, programID uint) (bool, error)
+	FindAll(ctx context.Context, param utils.PaginationParams) ([]models.Registration, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Registration, error)
+	FindByProgramID(ctx context.Context, programID uint, params utils.PaginationParams) ([]models.Registration, int64, error)
+	FindByEmail(ctx context.Context, email string) ([]models.Registration, error)
+	// Create & Update dengan withContact true menghubungkan registrasi ke contact di transaksi yang sama
+	Create(ctx context.Context, registration models.Registration, withContact bool) (models.Registration, error)
+	Update(ctx context.Context, registration models.Registration, withContact bool) (models.Registration, error)
+	Delete(ctx context.Context, id uint) error
+	CheckEmailExists(ctx context.Context, email string, programID uint) (bool, error)
+	CountByStatus(ctx context.Context) (map[string]int64, error)
+	OldestPendingCreatedAt(ctx context.Context) (*time.Time, error)
 }
 
 type registrationRepository struct {
@@ -26,25 +39,39 @@ func NewRegistrationRepository(db *gorm.DB) RegistrationRepository {
 }
 
 // Create implements RegistrationRepository.
-func (r *registrationRepository) Create(registration models.Registration) (models.Registration, error) {
-	err := r.db.Create(&registration).Error
+// Registrasi, contact dan attendee disimpan dalam satu transaksi setelah cek kapasitas program
+func (r *registrationRepository) Create(ctx context.Context, registration models.Registration, withContact bool) (models.Registration, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkCapacity(tx, registration); err != nil {
+			return err
+		}
+		if withContact {
+			if err := linkContact(tx, &registration); err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Program", "Contact").Create(&registration).Error
+	})
+	if isUniqueViolation(err, registrationEmailProgramIndex) {
+		return registration, ErrRegistrationExists
+	}
 	return registration, err
 }
 
 // Delete implements RegistrationRepository.
-func (r *registrationRepository) Delete(id uint) error {
-	err := r.db.Model(&models.Registration{}).Where("id = ?", id).Update("is_deleted", true).Error
+func (r *registrationRepository) Delete(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Model(&models.Registration{}).Where("id = ?", id).Update("is_deleted", true).Error
 	return err
 }
 
 // FindAll implements RegistrationRepository.
-func (r *registrationRepository) FindAll(params utils.PaginationParams) ([]models.Registration, int64, error) {
+func (r *registrationRepository) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Registration, int64, error) {
 	offset := (params.Page - 1) * params.Limit
 
 	var registrations []models.Registration
 	var total int64
 
-	query := r.db.Model(&models.Registration{}).Where("is_deleted = ?", false)
+	query := r.db.WithContext(ctx).Model(&models.Registration{}).Where("is_deleted = ?", false)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -56,22 +83,24 @@ func (r *registrationRepository) FindAll(params utils.PaginationParams) ([]model
 }
 
 // FindByID implements RegistrationRepository.
-func (r *registrationRepository) FindByID(id uint) (models.Registration, error) {
+func (r *registrationRepository) FindByID(ctx context.Context, id uint) (models.Registration, error) {
 	var registration models.Registration
 
-	err := r.db.Preload("Program").Where("id = ? AND is_deleted = ?", id, false).First(&registration).Error
+	err := r.db.WithContext(ctx).Preload("Program").Preload("Contact.Organization").Preload("Attendees", orderedAttendees).
+		Where("id = ? AND is_deleted = ?", id, false).
+		First(&registration).Error
 
-	return registration, err
+	return registration, notFound(err, "Registration")
 }
 
 // FindByProgramID implements RegistrationRepository.
-func (r *registrationRepository) FindByProgramID(programID uint, params utils.PaginationParams) ([]models.Registration, int64, error) {
+func (r *registrationRepository) FindByProgramID(ctx context.Context, programID uint, params utils.PaginationParams) ([]models.Registration, int64, error) {
 	offset := (params.Page - 1) * params.Limit
 
 	var registrations []models.Registration
 	var total int64
 
-	query := r.db.Model(&models.Registration{}).Where("program_id = ? AND is_deleted = ?", programID, false)
+	query := r.db.WithContext(ctx).Model(&models.Registration{}).Where("program_id = ? AND is_deleted = ?", programID, false)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -83,28 +112,79 @@ func (r *registrationRepository) FindByProgramID(programID uint, params utils.Pa
 }
 
 // FindByEmail implements RegistrationRepository.
-func (r *registrationRepository) FindByEmail(email string) (models.Registration, error) {
-	var registration models.Registration
+// Satu email bisa terdaftar di beberapa program
+func (r *registrationRepository) FindByEmail(ctx context.Context, email string) ([]models.Registration, error) {
+	var registrations []models.Registration
 
-	err := r.db.Preload("Program").Where("email = ? AND is_deleted = ?", email, false).First(&registration).Error
+	err := r.db.WithContext(ctx).Preload("Program").
+		Where("lower(email) = lower(?) AND is_deleted = ?", email, false).
+		Order("created_at DESC").
+		Find(&registrations).Error
 
-	return registration, err
+	return registrations, err
 }
 
 // Update implements RegistrationRepository.
-func (r *registrationRepository) Update(registration models.Registration) (models.Registration, error) {
-	err := r.db.Save(&registration).Error
+func (r *registrationRepository) Update(ctx context.Context, registration models.Registration, withContact bool) (models.Registration, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkCapacity(tx, registration); err != nil {
+			return err
+		}
+		if withContact {
+			if err := linkContact(tx, &registration); err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Program", "Contact", "Attendees").Save(&registration).Error
+	})
+	if isUniqueViolation(err, registrationEmailProgramIndex) {
+		return registration, ErrRegistrationExists
+	}
 
 	return registration, err
 }
 
 // CheckEmailExists implements RegistrationRepository.
-func (r *registrationRepository) CheckEmailExists(email string, programID uint) (bool, error) {
+func (r *registrationRepository) CheckEmailExists(ctx context.Context, email string, programID uint) (bool, error) {
 	var count int64
 
-	err := r.db.Model(&models.Registration{}).
-		Where("email = ? AND program_id = ? AND is_deleted = ?", email, programID, false).
+	err := r.db.WithContext(ctx).Model(&models.Registration{}).
+		Where("lower(email) = lower(?) AND program_id = ? AND is_deleted = ?", email, programID, false).
 		Count(&count).Error
 
 	return count > 0, err
 }
+
+// CountByStatus implements RegistrationRepository.
+func (r *registrationRepository) CountByStatus(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Total  int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.Registration{}).
+		Select("status, COUNT(*) AS total").
+		Where("is_deleted = ?", false).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Total
+	}
+	return counts, nil
+}
+
+// OldestPendingCreatedAt implements RegistrationRepository.
+// nil jika tidak ada registrasi pending
+func (r *registrationRepository) OldestPendingCreatedAt(ctx context.Context) (*time.Time, error) {
+	var oldest *time.Time
+	err := r.db.WithContext(ctx).Model(&models.Registration{}).
+		Select("MIN(created_at)").
+		Where("status = ? AND is_deleted = ?", "pending", false).
+		Scan(&oldest).Error
+
+	return oldest, err
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrReminderTemplateExists program sudah punya template untuk jumlah hari yang sama
+var ErrReminderTemplateExists = apperrors.Conflict("reminder_template_exists", "reminder template for this day already exists")
+
+const reminderTemplateProgramDaysIndex = "idx_reminder_templates_program_days"
+
+type ReminderRepository interface {
+	FindTemplatesByProgramID(ctx context.Context, programID uint) ([]models.ReminderTemplate, error)
+	FindActiveTemplates(ctx context.Context, programIDs []uint) ([]models.ReminderTemplate, error)
+	FindTemplateByID(ctx context.Context, programID uint, id uint) (models.ReminderTemplate, error)
+	CreateTemplate(ctx context.Context, template models.ReminderTemplate) (models.ReminderTemplate, error)
+	UpdateTemplate(ctx context.Context, template models.ReminderTemplate) (models.ReminderTemplate, error)
+	DeleteTemplate(ctx context.Context, programID uint, id uint) error
+	FindUpcomingRegistrations(ctx context.Context, from, to time.Time) ([]models.Registration, error)
+	FindSent(ctx context.Context, registrationIDs []uint) ([]models.RegistrationReminder, error)
+	Claim(ctx context.Context, reminder models.RegistrationReminder) (bool, error)
+	Release(ctx context.Context, reminder models.RegistrationReminder) error
+}
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) ReminderRepository {
+	return &reminderRepository{db}
+}
+
+// FindTemplatesByProgramID implements ReminderRepository.
+func (r *reminderRepository) FindTemplatesByProgramID(ctx context.Context, programID uint) ([]models.ReminderTemplate, error) {
+	var templates []models.ReminderTemplate
+
+	err := r.db.WithContext(ctx).Where("program_id = ?", programID).Order("days_before DESC").Find(&templates).Error
+
+	return templates, err
+}
+
+// FindActiveTemplates implements ReminderRepository.
+func (r *reminderRepository) FindActiveTemplates(ctx context.Context, programIDs []uint) ([]models.ReminderTemplate, error) {
+	var templates []models.ReminderTemplate
+	if len(programIDs) == 0 {
+		return templates, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("program_id IN ? AND is_active = ?", programIDs, true).Find(&templates).Error
+
+	return templates, err
+}
+
+// FindTemplateByID implements ReminderRepository.
+func (r *reminderRepository) FindTemplateByID(ctx context.Context, programID uint, id uint) (models.ReminderTemplate, error) {
+	var template models.ReminderTemplate
+
+	err := r.db.WithContext(ctx).Where("id = ? AND program_id = ?", id, programID).First(&template).Error
+
+	return template, notFound(err, "Reminder")
+}
+
+// CreateTemplate implements ReminderRepository.
+func (r *reminderRepository) CreateTemplate(ctx context.Context, template models.ReminderTemplate) (models.ReminderTemplate, error) {
+	err := r.db.WithContext(ctx).Create(&template).Error
+	if isUniqueViolation(err, reminderTemplateProgramDaysIndex) {
+		return template, ErrReminderTemplateExists
+	}
+
+	return template, err
+}
+
+// UpdateTemplate implements ReminderRepository.
+func (r *reminderRepository) UpdateTemplate(ctx context.Context, template models.ReminderTemplate) (models.ReminderTemplate, error) {
+	err := r.db.WithContext(ctx).Save(&template).Error
+	if isUniqueViolation(err, reminderTemplateProgramDaysIndex) {
+		return template, ErrReminderTemplateExists
+	}
+
+	return template, err
+}
+
+// DeleteTemplate implements ReminderRepository.
+func (r *reminderRepository) DeleteTemplate(ctx context.Context, programID uint, id uint) error {
+	return r.db.WithContext(ctx).Where("id = ? AND program_id = ?", id, programID).Delete(&models.ReminderTemplate{}).Error
+}
+
+// FindUpcomingRegistrations implements ReminderRepository.
+// Hanya registrasi terkonfirmasi (status active) dengan PreferredDate di rentang [from, to]
+func (r *reminderRepository) FindUpcomingRegistrations(ctx context.Context, from, to time.Time) ([]models.Registration, error) {
+	var registrations []models.Registration
+
+	err := r.db.WithContext(ctx).Preload("Program").
+		Where("status = ? AND is_deleted = ?", "active", false).
+		Where("preferred_date BETWEEN ? AND ?", from.Format(time.DateOnly), to.Format(time.DateOnly)).
+		Find(&registrations).Error
+
+	return registrations, err
+}
+
+// FindSent implements ReminderRepository.
+func (r *reminderRepository) FindSent(ctx context.Context, registrationIDs []uint) ([]models.RegistrationReminder, error) {
+	var reminders []models.RegistrationReminder
+	if len(registrationIDs) == 0 {
+		return reminders, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("registration_id IN ?", registrationIDs).Find(&reminders).Error
+
+	return reminders, err
+}
+
+// Claim implements ReminderRepository.
+// Insert catatan reminder, false jika sudah pernah dikirim (juga oleh replica lain)
+func (r *reminderRepository) Claim(ctx context.Context, reminder models.RegistrationReminder) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&reminder)
+
+	return result.RowsAffected == 1, result.Error
+}
+
+// Release implements ReminderRepository.
+// Hapus catatan reminder jika email gagal di-enqueue supaya dicoba lagi di putaran berikutnya
+func (r *reminderRepository) Release(ctx context.Context, reminder models.RegistrationReminder) error {
+	return r.db.WithContext(ctx).
+		Where("registration_id = ? AND days_before = ? AND preferred_date = ?", reminder.RegistrationID, reminder.DaysBefore, reminder.PreferredDate.Format(time.DateOnly)).
+		Delete(&models.RegistrationReminder{}).Error
+}
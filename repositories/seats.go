@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrProgramFull jumlah peserta melebihi kapasitas program
+var ErrProgramFull = apperrors.Conflict("program_full", "program capacity exceeded")
+
+// seatFreeStatuses status registrasi yang tidak memakai kursi.
+// Registrasi quarantined baru memakai kursi setelah direview admin.
+var seatFreeStatuses = []string{"cancelled", "rejected", "quarantined"}
+
+// seatsTaken total peserta (bukan jumlah registrasi) yang memakai kursi program.
+// excludeRegistrationID dipakai saat update agar registrasi itu sendiri tidak terhitung.
+func seatsTaken(db *gorm.DB, programID uint, excludeRegistrationID uint) (int64, error) {
+	var total int64
+
+	err := db.Model(&models.Registration{}).
+		Select("COALESCE(SUM(participants), 0)").
+		Where("program_id = ? AND is_deleted = ? AND status NOT IN ? AND id <> ?", programID, false, seatFreeStatuses, excludeRegistrationID).
+		Scan(&total).Error
+
+	return total, err
+}
+
+// checkCapacity lock baris program (FOR UPDATE) lalu pastikan peserta baru masih muat.
+// Harus dipanggil di dalam transaksi supaya registrasi bersamaan tidak melebihi kapasitas.
+func checkCapacity(tx *gorm.DB, registration models.Registration) error {
+	var program models.Program
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "capacity").
+		Where("id = ?", registration.ProgramID).
+		First(&program).Error
+	if err != nil {
+		return err
+	}
+
+	if program.Capacity <= 0 || !takesSeat(registration.Status) {
+		return nil
+	}
+
+	taken, err := seatsTaken(tx, registration.ProgramID, registration.ID)
+	if err != nil {
+		return err
+	}
+	if taken+int64(registration.Participants) > int64(program.Capacity) {
+		return ErrProgramFull
+	}
+	return nil
+}
+
+func takesSeat(status string) bool {
+	for _, free := range seatFreeStatuses {
+		if status == free {
+			return false
+		}
+	}
+	return true
+}
@@ -1,17 +1,21 @@
 package repositories
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/utils"
 	"gorm.io/gorm"
 )
 
 type ServiceRepository interface {
-	FindAll(param utils.PaginationParams) ([]models.Service, int64, error)
-	FindByID(id uint) (models.Service, error)
-	Create(service models.Service) (models.Service, error)
-	Update(service models.Service) (models.Service, error)
-	Delete(id uint) error
+	FindAll(ctx context.Context, param utils.PaginationParams) ([]models.Service, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Service, error)
+	FindBySlug(ctx context.Context, slug string) (models.Service, error)
+	Create(ctx context.Context, service models.Service) (models.Service, error)
+	Update(ctx context.Context, service models.Service) (models.Service, error)
+	Delete(ctx context.Context, id uint) error
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type serviceRepository struct {
@@ -23,47 +27,69 @@ func NewServiceRepository(db *gorm.DB) ServiceRepository {
 }
 
 // Create implements ServiceRepository.
-func (s *serviceRepository) Create(service models.Service) (models.Service, error) {
-	err := s.db.Create(&service).Error
+func (s *serviceRepository) Create(ctx context.Context, service models.Service) (models.Service, error) {
+	if service.SortOrder == 0 {
+		next, err := nextSortOrder(s.db.WithContext(ctx), &models.Service{})
+		if err != nil {
+			return service, err
+		}
+		service.SortOrder = next
+	}
+
+	err := s.db.WithContext(ctx).Create(&service).Error
 	return service, err
 }
 
 // Delete implements ServiceRepository.
-func (s *serviceRepository) Delete(id uint) error {
-	err := s.db.Model(&models.Service{}).Where("id = ?", id).Update("is_deleted", true).Error
+func (s *serviceRepository) Delete(ctx context.Context, id uint) error {
+	err := s.db.WithContext(ctx).Model(&models.Service{}).Where("id = ?", id).Update("is_deleted", true).Error
 	return err
 }
 
 // FindAll implements ServiceRepository.
-func (s *serviceRepository) FindAll(params utils.PaginationParams) ([]models.Service, int64, error) {
+func (s *serviceRepository) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Service, int64, error) {
 	offset := (params.Page - 1) * params.Limit
 
 	var services []models.Service
 	var total int64
 
-	query := s.db.Model(&models.Service{}).Where("is_deleted = ?", false)
+	query := s.db.WithContext(ctx).Model(&models.Service{}).Where("is_deleted = ?", false)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := query.Offset(offset).Limit(params.Limit).Find(&services).Error
+	err := query.Order("sort_order ASC, created_at ASC").Offset(offset).Limit(params.Limit).Find(&services).Error
 
 	return services, total, err
 }
 
 // FindByID implements ServiceRepository.
-func (s *serviceRepository) FindByID(id uint) (models.Service, error) {
+func (s *serviceRepository) FindByID(ctx context.Context, id uint) (models.Service, error) {
 	var service models.Service
 
-	err := s.db.Where("id = ? AND is_deleted = ?", id, false).First(&service).Error
+	err := s.db.WithContext(ctx).Where("id = ? AND is_deleted = ?", id, false).First(&service).Error
 
-	return service, err
+	return service, notFound(err, "Service")
 }
 
 // Update implements ServiceRepository.
-func (s *serviceRepository) Update(service models.Service) (models.Service, error) {
-	err := s.db.Save(&service).Error
+func (s *serviceRepository) Update(ctx context.Context, service models.Service) (models.Service, error) {
+	err := s.db.WithContext(ctx).Save(&service).Error
 
 	return service, err
-}
\ No newline at end of file
+}
+
+// Reorder implements ServiceRepository.
+func (s *serviceRepository) Reorder(ctx context.Context, ids []uint) error {
+	return reorder(s.db.WithContext(ctx), &models.Service{}, ids, notDeleted)
+}
+
+// FindBySlug implements ServiceRepository.
+func (s *serviceRepository) FindBySlug(ctx context.Context, slug string) (models.Service, error) {
+	var service models.Service
+
+	err := s.db.WithContext(ctx).Where("slug = ? AND is_deleted = ?", slug, false).First(&service).Error
+
+	return service, notFound(err, "Service")
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/tech-azim/be-learnova/models"
+	"gorm.io/gorm"
+)
+
+// SitemapEntry data minimal per record untuk sitemap.xml
+type SitemapEntry struct {
+	Slug         string    `json:"slug"`
+	CanonicalURL string    `json:"canonical_url"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type SitemapRepository interface {
+	FindPrograms(ctx context.Context) ([]SitemapEntry, error)
+	FindServices(ctx context.Context) ([]SitemapEntry, error)
+	FindGalleries(ctx context.Context) ([]SitemapEntry, error)
+}
+
+type sitemapRepository struct {
+	db *gorm.DB
+}
+
+func NewSitemapRepository(db *gorm.DB) SitemapRepository {
+	return &sitemapRepository{db}
+}
+
+// FindPrograms implements SitemapRepository.
+func (r *sitemapRepository) FindPrograms(ctx context.Context) ([]SitemapEntry, error) {
+	var entries []SitemapEntry
+	err := r.db.WithContext(ctx).Model(&models.Program{}).
+		Select("slug, canonical_url, updated_at").
+		Where("is_deleted = ? AND slug <> ''", false).
+		Order("updated_at DESC").
+		Scan(&entries).Error
+	return entries, err
+}
+
+// FindServices implements SitemapRepository.
+func (r *sitemapRepository) FindServices(ctx context.Context) ([]SitemapEntry, error) {
+	var entries []SitemapEntry
+	err := r.db.WithContext(ctx).Model(&models.Service{}).
+		Select("slug, canonical_url, updated_at").
+		Where("is_deleted = ? AND slug <> ''", false).
+		Order("sort_order ASC").
+		Scan(&entries).Error
+	return entries, err
+}
+
+// FindGalleries implements SitemapRepository.
+func (r *sitemapRepository) FindGalleries(ctx context.Context) ([]SitemapEntry, error) {
+	var entries []SitemapEntry
+	err := r.db.WithContext(ctx).Model(&models.Gallery{}).
+		Select("slug, canonical_url, updated_at").
+		Where("is_deleted = ? AND is_active = ? AND slug <> '' AND album_id IS NULL", false, true).
+		Order("date DESC").
+		Scan(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/models"
+	"gorm.io/gorm"
+)
+
+// SlugRepository operasi slug yang dipakai bersama oleh program, service, dan gallery
+// Parameter table adalah nama tabel entity (contoh: "programs")
+type SlugRepository interface {
+	IsTaken(ctx context.Context, table string, slug string, excludeID uint) (bool, error)
+	FindRedirect(ctx context.Context, table string, slug string) (models.SlugRedirect, error)
+	SaveChange(ctx context.Context, table string, entityID uint, oldSlug string, newSlug string) error
+}
+
+type slugRepository struct {
+	db *gorm.DB
+}
+
+func NewSlugRepository(db *gorm.DB) SlugRepository {
+	return &slugRepository{db}
+}
+
+// IsTaken implements SlugRepository.
+// Slug dianggap terpakai jika dipakai record lain atau ada di riwayat redirect record lain
+func (r *slugRepository) IsTaken(ctx context.Context, table string, slug string, excludeID uint) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).Table(table).Where("slug = ? AND id <> ?", slug, excludeID).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	err = r.db.WithContext(ctx).Model(&models.SlugRedirect{}).
+		Where("entity_type = ? AND slug = ? AND entity_id <> ?", table, slug, excludeID).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// FindRedirect implements SlugRepository.
+func (r *slugRepository) FindRedirect(ctx context.Context, table string, slug string) (models.SlugRedirect, error) {
+	var redirect models.SlugRedirect
+
+	err := r.db.WithContext(ctx).Where("entity_type = ? AND slug = ?", table, slug).First(&redirect).Error
+
+	return redirect, err
+}
+
+// SaveChange implements SlugRepository.
+// Slug lama disimpan ke riwayat, slug baru dihapus dari riwayat (jika record memakai kembali slug lamanya)
+func (r *slugRepository) SaveChange(ctx context.Context, table string, entityID uint, oldSlug string, newSlug string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("entity_type = ? AND slug = ?", table, newSlug).Delete(&models.SlugRedirect{}).Error
+		if err != nil {
+			return err
+		}
+
+		if oldSlug == "" || oldSlug == newSlug {
+			return nil
+		}
+
+		return tx.Create(&models.SlugRedirect{
+			EntityType: table,
+			Slug:       oldSlug,
+			EntityID:   entityID,
+		}).Error
+	})
+}
@@ -0,0 +1,51 @@
+package repositories
+
+import "gorm.io/gorm"
+
+// notDeleted scope untuk tabel yang memakai flag is_deleted
+func notDeleted(db *gorm.DB) *gorm.DB {
+	return db.Where("is_deleted = ?", false)
+}
+
+// nextSortOrder mengambil posisi berikutnya (MAX(sort_order) + 1) untuk model
+// Dipakai saat create supaya data baru muncul di urutan paling akhir
+func nextSortOrder(db *gorm.DB, model any) (int, error) {
+	var last int
+	err := db.Model(model).Select("COALESCE(MAX(sort_order), 0)").Scan(&last).Error
+	return last + 1, err
+}
+
+// reorder menyimpan urutan baru sesuai posisi ID di slice (1, 2, 3, ...)
+// Data dalam scope yang tidak ada di ids diberi posisi setelahnya dengan urutan lama,
+// supaya tidak ada dua data dengan posisi yang sama.
+// Semua update berjalan dalam satu transaksi, jika ada ID yang tidak ditemukan
+// seluruh perubahan di-rollback dan mengembalikan gorm.ErrRecordNotFound
+func reorder(db *gorm.DB, model any, ids []uint, scopes ...func(*gorm.DB) *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			result := tx.Model(model).Scopes(scopes...).Where("id = ?", id).Update("sort_order", i+1)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+
+		query := tx.Model(model).Scopes(scopes...)
+		if len(ids) > 0 {
+			query = query.Where("id NOT IN ?", ids)
+		}
+		var rest []uint
+		if err := query.Order("sort_order, id").Pluck("id", &rest).Error; err != nil {
+			return err
+		}
+
+		for i, id := range rest {
+			if err := tx.Model(model).Where("id = ?", id).Update("sort_order", len(ids)+i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TranslationRepository interface {
+	FindByEntityIDs(ctx context.Context, entityType string, locale string, ids []uint) ([]models.Translation, error)
+	FindByEntity(ctx context.Context, entityType string, id uint) ([]models.Translation, error)
+	Save(ctx context.Context, entityType string, id uint, locale string, fields map[string]string) error
+	DeleteLocale(ctx context.Context, entityType string, id uint, locale string) error
+	EntityExists(ctx context.Context, model any, id uint, softDelete bool) (bool, error)
+	FindSourceValues(ctx context.Context, model any, fields []string, softDelete bool) ([]map[string]any, error)
+}
+
+type translationRepository struct {
+	db *gorm.DB
+}
+
+func NewTranslationRepository(db *gorm.DB) TranslationRepository {
+	return &translationRepository{db}
+}
+
+// FindByEntityIDs implements TranslationRepository.
+func (r *translationRepository) FindByEntityIDs(ctx context.Context, entityType string, locale string, ids []uint) ([]models.Translation, error) {
+	var translations []models.Translation
+
+	err := r.db.WithContext(ctx).Where("entity_type = ? AND locale = ? AND entity_id IN ?", entityType, locale, ids).
+		Find(&translations).Error
+
+	return translations, err
+}
+
+// FindByEntity implements TranslationRepository.
+func (r *translationRepository) FindByEntity(ctx context.Context, entityType string, id uint) ([]models.Translation, error) {
+	var translations []models.Translation
+
+	err := r.db.WithContext(ctx).Where("entity_type = ? AND entity_id = ?", entityType, id).
+		Order("locale ASC, field ASC").
+		Find(&translations).Error
+
+	return translations, err
+}
+
+// Save implements TranslationRepository.
+// Field dengan value kosong dihapus, selebihnya di-upsert dalam satu transaksi
+func (r *translationRepository) Save(ctx context.Context, entityType string, id uint, locale string, fields map[string]string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for field, value := range fields {
+			if value == "" {
+				err := tx.Where("entity_type = ? AND entity_id = ? AND locale = ? AND field = ?", entityType, id, locale, field).
+					Delete(&models.Translation{}).Error
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			translation := models.Translation{
+				EntityType: entityType,
+				EntityID:   id,
+				Locale:     locale,
+				Field:      field,
+				Value:      value,
+			}
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "entity_type"}, {Name: "entity_id"}, {Name: "locale"}, {Name: "field"}},
+				DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+			}).Create(&translation).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteLocale implements TranslationRepository.
+func (r *translationRepository) DeleteLocale(ctx context.Context, entityType string, id uint, locale string) error {
+	return r.db.WithContext(ctx).Where("entity_type = ? AND entity_id = ? AND locale = ?", entityType, id, locale).
+		Delete(&models.Translation{}).Error
+}
+
+// EntityExists implements TranslationRepository.
+func (r *translationRepository) EntityExists(ctx context.Context, model any, id uint, softDelete bool) (bool, error) {
+	var count int64
+
+	query := r.db.WithContext(ctx).Model(model).Where("id = ?", id)
+	if softDelete {
+		query = notDeleted(query)
+	}
+	err := query.Count(&count).Error
+
+	return count > 0, err
+}
+
+// FindSourceValues implements TranslationRepository.
+// Mengambil id dan nilai field bahasa default untuk laporan terjemahan yang belum lengkap
+func (r *translationRepository) FindSourceValues(ctx context.Context, model any, fields []string, softDelete bool) ([]map[string]any, error) {
+	var rows []map[string]any
+
+	query := r.db.WithContext(ctx).Model(model).Select(append([]string{"id"}, fields...))
+	if softDelete {
+		query = notDeleted(query)
+	}
+	err := query.Order("id ASC").Find(&rows).Error
+
+	return rows, err
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/utils"
 	"gorm.io/gorm"
@@ -9,12 +11,12 @@ import (
 // UserRepository interface untuk operasi database User
 // Interface ini memudahkan testing dan mengikuti prinsip SOLID
 type UserRepository interface {
-	FindAll() ([]models.User, error)
-	FindByID(id uint) (models.User, error)
-	FindByEmail(email string) (models.User, error)
-	Create(user models.User) (models.User, error)
-	Update(user models.User) (models.User, error)
-	Delete(id uint) error
+	FindAll(ctx context.Context) ([]models.User, error)
+	FindByID(ctx context.Context, id uint) (models.User, error)
+	FindByEmail(ctx context.Context, email string) (models.User, error)
+	Create(ctx context.Context, user models.User) (models.User, error)
+	Update(ctx context.Context, user models.User) (models.User, error)
+	Delete(ctx context.Context, id uint) error
 }
 
 // userRepository implementasi dari UserRepository
@@ -29,52 +31,52 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 }
 
 // FindAll mengambil semua user dari database
-func (r *userRepository) FindAll() ([]models.User, error) {
+func (r *userRepository) FindAll(ctx context.Context) ([]models.User, error) {
 	var users []models.User
-	err := r.db.Find(&users).Error
+	err := r.db.WithContext(ctx).Find(&users).Error
 	return users, err
 }
 
 // Create menyimpan user baru ke database
 // GORM otomatis mengisi ID setelah insert berhasil
-func (r *userRepository) Create(user models.User) (models.User, error) {
+func (r *userRepository) Create(ctx context.Context, user models.User) (models.User, error) {
 	hashPassword, errHash := utils.HashPassword(user.Password)
 	if errHash != nil {
 		return models.User{}, errHash
 	}
 	user.Password = hashPassword
-	err := r.db.Create(&user).Error
+	err := r.db.WithContext(ctx).Create(&user).Error
 	return user, err
 }
 
 // Delete menghapus user berdasarkan ID
 // Jika model punya DeletedAt, ini soft delete (data tidak benar-benar dihapus)
 // SQL: DELETE FROM users WHERE id = ? (atau UPDATE users SET deleted_at = NOW() WHERE id = ?)
-func (r *userRepository) Delete(id uint) error {
-	err := r.db.Delete(&models.User{}, id).Error
+func (r *userRepository) Delete(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Delete(&models.User{}, id).Error
 	return err
 }
 
 // FindByEmail mencari user berdasarkan email
 // Berguna untuk validasi email unique dan proses login
-func (r *userRepository) FindByEmail(email string) (models.User, error) {
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (models.User, error) {
 	var user models.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
 	return user, err
 }
 
 // FindByID mencari user berdasarkan ID
 // Return error gorm.ErrRecordNotFound jika tidak ditemukan
-func (r *userRepository) FindByID(id uint) (models.User, error) {
+func (r *userRepository) FindByID(ctx context.Context, id uint) (models.User, error) {
 	var user models.User
-	err := r.db.First(&user, id).Error
-	return user, err
+	err := r.db.WithContext(ctx).First(&user, id).Error
+	return user, notFound(err, "User")
 }
 
 // Update memperbarui data user yang sudah ada
 // user.ID harus sudah terisi, Save() akan update semua field
-func (r *userRepository) Update(user models.User) (models.User, error) {
-	current, errCurrent := r.FindByEmail(user.Email)
+func (r *userRepository) Update(ctx context.Context, user models.User) (models.User, error) {
+	current, errCurrent := r.FindByEmail(ctx, user.Email)
 	if errCurrent != nil {
 		return models.User{}, errCurrent
 	}
@@ -87,6 +89,6 @@ func (r *userRepository) Update(user models.User) (models.User, error) {
 	} else {
 		user.Password = current.Password
 	}
-	err := r.db.Save(&user).Error
+	err := r.db.WithContext(ctx).Save(&user).Error
 	return user, err
 }
@@ -1,20 +1,23 @@
 package repositories
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/utils"
 	"gorm.io/gorm"
 )
 
 type VideoGalleryRepository interface {
-	FindAll(param utils.PaginationParams) ([]models.VideoGallery, int64, error)
-	FindByID(id uint) (models.VideoGallery, error)
-	FindByCategory(category string, params utils.PaginationParams) ([]models.VideoGallery, int64, error)
-	Create(videoGallery models.VideoGallery) (models.VideoGallery, error)
-	Update(videoGallery models.VideoGallery) (models.VideoGallery, error)
-	Delete(id uint) error
-	FindAllActive() ([]models.VideoGallery, error)
-	FindAllCategories() ([]string, error)
+	FindAll(ctx context.Context, param utils.PaginationParams) ([]models.VideoGallery, int64, error)
+	FindByID(ctx context.Context, id uint) (models.VideoGallery, error)
+	FindByCategory(ctx context.Context, category string, params utils.PaginationParams) ([]models.VideoGallery, int64, error)
+	Create(ctx context.Context, videoGallery models.VideoGallery) (models.VideoGallery, error)
+	Update(ctx context.Context, videoGallery models.VideoGallery) (models.VideoGallery, error)
+	Delete(ctx context.Context, id uint) error
+	FindAllActive(ctx context.Context) ([]models.VideoGallery, error)
+	FindAllCategories(ctx context.Context) ([]string, error)
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type videoGalleryRepository struct {
@@ -26,52 +29,60 @@ func NewVideoGalleryRepository(db *gorm.DB) VideoGalleryRepository {
 }
 
 // Create implements VideoGalleryRepository.
-func (r *videoGalleryRepository) Create(videoGallery models.VideoGallery) (models.VideoGallery, error) {
-	err := r.db.Create(&videoGallery).Error
+func (r *videoGalleryRepository) Create(ctx context.Context, videoGallery models.VideoGallery) (models.VideoGallery, error) {
+	if videoGallery.SortOrder == 0 {
+		next, err := nextSortOrder(r.db.WithContext(ctx), &models.VideoGallery{})
+		if err != nil {
+			return videoGallery, err
+		}
+		videoGallery.SortOrder = next
+	}
+
+	err := r.db.WithContext(ctx).Create(&videoGallery).Error
 	return videoGallery, err
 }
 
 // Delete implements VideoGalleryRepository.
-func (r *videoGalleryRepository) Delete(id uint) error {
-	err := r.db.Model(&models.VideoGallery{}).Where("id = ?", id).Update("is_deleted", true).Error
+func (r *videoGalleryRepository) Delete(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Model(&models.VideoGallery{}).Where("id = ?", id).Update("is_deleted", true).Error
 	return err
 }
 
 // FindAll implements VideoGalleryRepository.
-func (r *videoGalleryRepository) FindAll(params utils.PaginationParams) ([]models.VideoGallery, int64, error) {
+func (r *videoGalleryRepository) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.VideoGallery, int64, error) {
 	offset := (params.Page - 1) * params.Limit
 
 	var videoGalleries []models.VideoGallery
 	var total int64
 
-	query := r.db.Model(&models.VideoGallery{}).Where("is_deleted = ?", false)
+	query := r.db.WithContext(ctx).Model(&models.VideoGallery{}).Where("is_deleted = ?", false)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := query.Offset(offset).Limit(params.Limit).Find(&videoGalleries).Error
+	err := query.Order("sort_order ASC, date DESC").Offset(offset).Limit(params.Limit).Find(&videoGalleries).Error
 
 	return videoGalleries, total, err
 }
 
 // FindByID implements VideoGalleryRepository.
-func (r *videoGalleryRepository) FindByID(id uint) (models.VideoGallery, error) {
+func (r *videoGalleryRepository) FindByID(ctx context.Context, id uint) (models.VideoGallery, error) {
 	var videoGallery models.VideoGallery
 
-	err := r.db.Where("id = ? AND is_deleted = ?", id, false).First(&videoGallery).Error
+	err := r.db.WithContext(ctx).Where("id = ? AND is_deleted = ?", id, false).First(&videoGallery).Error
 
-	return videoGallery, err
+	return videoGallery, notFound(err, "Video gallery")
 }
 
 // FindByCategory implements VideoGalleryRepository.
-func (r *videoGalleryRepository) FindByCategory(category string, params utils.PaginationParams) ([]models.VideoGallery, int64, error) {
+func (r *videoGalleryRepository) FindByCategory(ctx context.Context, category string, params utils.PaginationParams) ([]models.VideoGallery, int64, error) {
 	offset := (params.Page - 1) * params.Limit
 
 	var videoGalleries []models.VideoGallery
 	var total int64
 
-	query := r.db.Model(&models.VideoGallery{}).Where("is_deleted = ? AND is_active = ?", false, true)
+	query := r.db.WithContext(ctx).Model(&models.VideoGallery{}).Where("is_deleted = ? AND is_active = ?", false, true)
 
 	// Jika category bukan "Semua", filter berdasarkan category
 	if category != "" && category != "Semua" {
@@ -82,37 +93,42 @@ func (r *videoGalleryRepository) FindByCategory(category string, params utils.Pa
 		return nil, 0, err
 	}
 
-	err := query.Order("order ASC, date DESC").Offset(offset).Limit(params.Limit).Find(&videoGalleries).Error
+	err := query.Order("sort_order ASC, date DESC").Offset(offset).Limit(params.Limit).Find(&videoGalleries).Error
 
 	return videoGalleries, total, err
 }
 
 // Update implements VideoGalleryRepository.
-func (r *videoGalleryRepository) Update(videoGallery models.VideoGallery) (models.VideoGallery, error) {
-	err := r.db.Save(&videoGallery).Error
+func (r *videoGalleryRepository) Update(ctx context.Context, videoGallery models.VideoGallery) (models.VideoGallery, error) {
+	err := r.db.WithContext(ctx).Save(&videoGallery).Error
 
 	return videoGallery, err
 }
 
 // FindAllActive implements VideoGalleryRepository.
-func (r *videoGalleryRepository) FindAllActive() ([]models.VideoGallery, error) {
+func (r *videoGalleryRepository) FindAllActive(ctx context.Context) ([]models.VideoGallery, error) {
 	var videoGalleries []models.VideoGallery
 
-	err := r.db.Where("is_deleted = ? AND is_active = ?", false, true).
-		Order("order ASC, date DESC").
+	err := r.db.WithContext(ctx).Where("is_deleted = ? AND is_active = ?", false, true).
+		Order("sort_order ASC, date DESC").
 		Find(&videoGalleries).Error
 
 	return videoGalleries, err
 }
 
 // FindAllCategories implements VideoGalleryRepository.
-func (r *videoGalleryRepository) FindAllCategories() ([]string, error) {
+func (r *videoGalleryRepository) FindAllCategories(ctx context.Context) ([]string, error) {
 	var categories []string
 
-	err := r.db.Model(&models.VideoGallery{}).
+	err := r.db.WithContext(ctx).Model(&models.VideoGallery{}).
 		Where("is_deleted = ? AND is_active = ?", false, true).
 		Distinct("category").
 		Pluck("category", &categories).Error
 
 	return categories, err
 }
+
+// Reorder implements VideoGalleryRepository.
+func (r *videoGalleryRepository) Reorder(ctx context.Context, ids []uint) error {
+	return reorder(r.db.WithContext(ctx), &models.VideoGallery{}, ids, notDeleted)
+}
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	FindAll(params utils.PaginationParams) ([]models.WebhookSubscription, int64, error)
+	FindByID(id uint) (models.WebhookSubscription, error)
+	FindActive() ([]models.WebhookSubscription, error)
+	Create(subscription models.WebhookSubscription) (models.WebhookSubscription, error)
+	Update(subscription models.WebhookSubscription) (models.WebhookSubscription, error)
+	Delete(id uint) error
+	CreateDeliveries(deliveries []models.WebhookDelivery) ([]models.WebhookDelivery, error)
+	FindDeliveries(subscriptionID uint, params utils.PaginationParams, status string) ([]models.WebhookDelivery, int64, error)
+	FindDeliveryByID(subscriptionID uint, id uint) (models.WebhookDelivery, error)
+	UpdateDelivery(delivery models.WebhookDelivery) error
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db}
+}
+
+// FindAll implements WebhookRepository.
+func (r *webhookRepository) FindAll(params utils.PaginationParams) ([]models.WebhookSubscription, int64, error) {
+	offset := (params.Page - 1) * params.Limit
+
+	var subscriptions []models.WebhookSubscription
+	var total int64
+
+	query := r.db.Model(&models.WebhookSubscription{}).Where("is_deleted = ?", false)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id ASC").Offset(offset).Limit(params.Limit).Find(&subscriptions).Error
+
+	return subscriptions, total, err
+}
+
+// FindByID implements WebhookRepository.
+func (r *webhookRepository) FindByID(id uint) (models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+
+	err := r.db.Where("id = ? AND is_deleted = ?", id, false).First(&subscription).Error
+
+	return subscription, notFound(err, "Webhook")
+}
+
+// FindActive implements WebhookRepository.
+// Filter event dilakukan di service karena subscription bisa memakai wildcard (contoh: program.*)
+func (r *webhookRepository) FindActive() ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+
+	err := r.db.Where("is_active = ? AND is_deleted = ?", true, false).Find(&subscriptions).Error
+
+	return subscriptions, err
+}
+
+// Create implements WebhookRepository.
+func (r *webhookRepository) Create(subscription models.WebhookSubscription) (models.WebhookSubscription, error) {
+	err := r.db.Create(&subscription).Error
+
+	return subscription, err
+}
+
+// Update implements WebhookRepository.
+func (r *webhookRepository) Update(subscription models.WebhookSubscription) (models.WebhookSubscription, error) {
+	err := r.db.Save(&subscription).Error
+
+	return subscription, err
+}
+
+// Delete implements WebhookRepository.
+// Soft delete supaya log delivery tetap bisa dibaca
+func (r *webhookRepository) Delete(id uint) error {
+	return r.db.Model(&models.WebhookSubscription{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"is_deleted": true, "is_active": false}).Error
+}
+
+// CreateDeliveries implements WebhookRepository.
+func (r *webhookRepository) CreateDeliveries(deliveries []models.WebhookDelivery) ([]models.WebhookDelivery, error) {
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	err := r.db.Create(&deliveries).Error
+
+	return deliveries, err
+}
+
+// FindDeliveries implements WebhookRepository.
+func (r *webhookRepository) FindDeliveries(subscriptionID uint, params utils.PaginationParams, status string) ([]models.WebhookDelivery, int64, error) {
+	offset := (params.Page - 1) * params.Limit
+
+	var deliveries []models.WebhookDelivery
+	var total int64
+
+	query := r.db.Model(&models.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id DESC").Offset(offset).Limit(params.Limit).Find(&deliveries).Error
+
+	return deliveries, total, err
+}
+
+// FindDeliveryByID implements WebhookRepository.
+// subscriptionID 0 berarti tanpa filter subscription (dipakai job handler)
+func (r *webhookRepository) FindDeliveryByID(subscriptionID uint, id uint) (models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+
+	query := r.db.Where("id = ?", id)
+	if subscriptionID != 0 {
+		query = query.Where("subscription_id = ?", subscriptionID)
+	}
+	err := query.First(&delivery).Error
+
+	return delivery, notFound(err, "Webhook delivery")
+}
+
+// UpdateDelivery implements WebhookRepository.
+func (r *webhookRepository) UpdateDelivery(delivery models.WebhookDelivery) error {
+	return r.db.Save(&delivery).Error
+}
@@ -1,11 +1,22 @@
 package routes
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/tech-azim/be-learnova/controllers"
 	"github.com/tech-azim/be-learnova/middlewares"
 )
 
+// Nama route group untuk policy rate limit (RATE_LIMIT_<GROUP>)
+const (
+	RateLimitAPI   = "api"
+	RateLimitLogin = "login"
+)
+
+// albumUploadTimeout upload album bisa berisi puluhan file, melebihi SERVER_REQUEST_TIMEOUT default
+const albumUploadTimeout = 2 * time.Minute
+
 func Router(
 	r *gin.Engine,
 	authController *controllers.AuthController,
@@ -20,18 +31,46 @@ func Router(
 	flyerGalleryController *controllers.FlyerGalleryController,
 	dashboardController *controllers.DashboardController,
 	userController *controllers.UserController,
+	galleryAlbumController *controllers.GalleryAlbumController,
+	curriculumController *controllers.CurriculumController,
+	instructorController *controllers.InstructorController,
+	programFAQController *controllers.ProgramFAQController,
+	sitemapController *controllers.SitemapController,
+	translationController *controllers.TranslationController,
+	contactController *controllers.ContactController,
+	attendeeController *controllers.AttendeeController,
+	jobController *controllers.JobController,
+	webhookController *controllers.WebhookController,
+	reminderController *controllers.ReminderController,
+	apiKeyController *controllers.APIKeyController,
+	healthController *controllers.HealthController,
+	rateLimiter *middlewares.RateLimiter,
+	authMiddleware gin.HandlerFunc,
+	metricsHandler gin.HandlerFunc,
 ) {
+	// Probe load balancer / orchestrator, di luar /api/v1 supaya tidak kena rate limit
+	r.GET("/healthz", healthController.Healthz)
+	r.GET("/readyz", healthController.Readyz)
+
+	// nil jika metrics dimatikan (METRICS_ENABLED=false)
+	if metricsHandler != nil {
+		r.GET("/metrics", metricsHandler)
+	}
+
 	r.Static("/uploads", "./uploads")
+	r.GET("/sitemap.xml", sitemapController.Sitemap)
+	r.GET("/robots.txt", sitemapController.Robots)
 	api := r.Group("/api/v1")
+	api.Use(rateLimiter.Limit(RateLimitAPI))
 	api.GET("/dashboard", dashboardController.GetDashboard)
 	{
 		authRoute := api.Group("/auth")
 		{
-			authRoute.POST("/login", authController.Login)
+			authRoute.POST("/login", rateLimiter.Limit(RateLimitLogin), authController.Login)
 		}
 
 		profileRoute := api.Group("/profile")
-		profileRoute.Use(middlewares.AuthMiddleware())
+		profileRoute.Use(authMiddleware)
 		{
 			profileRoute.GET("", userController.GetProfile)    // GET  /api/v1/profile
 			profileRoute.PUT("", userController.UpdateProfile) // PUT  /api/v1/profile
@@ -39,7 +78,7 @@ func Router(
 
 		// ── USERS (semua protected) ───────────────────────────────────────────
 		userRoute := api.Group("/users")
-		userRoute.Use(middlewares.AuthMiddleware())
+		userRoute.Use(authMiddleware)
 		{
 			userRoute.GET("", userController.GetAllUsers)
 			userRoute.GET("/:id", userController.GetUserByID)
@@ -50,20 +89,59 @@ func Router(
 
 		heroRoute := api.Group("/heros")
 		{
-			heroRoute.POST("", middlewares.AuthMiddleware(), heroController.Create)
+			heroRoute.POST("", authMiddleware, heroController.Create)
 			heroRoute.GET("", heroController.FindAll)
 			heroRoute.GET("/:id", heroController.FindByID)
-			heroRoute.DELETE("/:id", middlewares.AuthMiddleware(), heroController.Delete)
-			heroRoute.PUT("/:id", middlewares.AuthMiddleware(), heroController.Update)
+			heroRoute.DELETE("/:id", authMiddleware, heroController.Delete)
+			heroRoute.PUT("/reorder", authMiddleware, heroController.Reorder)
+			heroRoute.PUT("/:id", authMiddleware, heroController.Update)
 		}
 
 		programRoute := api.Group("/programs")
 		{
-			programRoute.POST("", middlewares.AuthMiddleware(), programController.Create)
+			programRoute.POST("", authMiddleware, programController.Create)
 			programRoute.GET("", programController.FindAll)
+			programRoute.GET("/slug/:slug", programController.FindBySlug)
 			programRoute.GET("/:id", programController.FindByID)
-			programRoute.DELETE("/:id", middlewares.AuthMiddleware(), programController.Delete)
-			programRoute.PUT("/:id", middlewares.AuthMiddleware(), programController.Update)
+			programRoute.DELETE("/:id", authMiddleware, programController.Delete)
+			programRoute.PUT("/:id", authMiddleware, programController.Update)
+
+			// Curriculum (module + lesson)
+			programRoute.GET("/:id/curriculum", curriculumController.FindAll)
+			programRoute.POST("/:id/curriculum", authMiddleware, curriculumController.CreateModule)
+			programRoute.PUT("/:id/curriculum/reorder", authMiddleware, curriculumController.ReorderModules)
+			programRoute.PUT("/:id/curriculum/:moduleId", authMiddleware, curriculumController.UpdateModule)
+			programRoute.DELETE("/:id/curriculum/:moduleId", authMiddleware, curriculumController.DeleteModule)
+			programRoute.POST("/:id/curriculum/:moduleId/lessons", authMiddleware, curriculumController.CreateLesson)
+			programRoute.PUT("/:id/curriculum/:moduleId/lessons/reorder", authMiddleware, curriculumController.ReorderLessons)
+			programRoute.PUT("/:id/curriculum/:moduleId/lessons/:lessonId", authMiddleware, curriculumController.UpdateLesson)
+			programRoute.DELETE("/:id/curriculum/:moduleId/lessons/:lessonId", authMiddleware, curriculumController.DeleteLesson)
+
+			// Reminder email sebelum PreferredDate
+			programRoute.GET("/:id/reminders", authMiddleware, reminderController.FindAll)
+			programRoute.POST("/:id/reminders", authMiddleware, reminderController.Create)
+			programRoute.PUT("/:id/reminders/:reminderId", authMiddleware, reminderController.Update)
+			programRoute.DELETE("/:id/reminders/:reminderId", authMiddleware, reminderController.Delete)
+
+			// Instructor
+			programRoute.GET("/:id/instructors", instructorController.FindByProgram)
+			programRoute.PUT("/:id/instructors", authMiddleware, instructorController.SetForProgram)
+
+			// FAQ
+			programRoute.GET("/:id/faqs", programFAQController.FindAll)
+			programRoute.POST("/:id/faqs", authMiddleware, programFAQController.Create)
+			programRoute.PUT("/:id/faqs/reorder", authMiddleware, programFAQController.Reorder)
+			programRoute.PUT("/:id/faqs/:faqId", authMiddleware, programFAQController.Update)
+			programRoute.DELETE("/:id/faqs/:faqId", authMiddleware, programFAQController.Delete)
+		}
+
+		instructorRoute := api.Group("/instructors")
+		{
+			instructorRoute.GET("", instructorController.FindAll)
+			instructorRoute.GET("/:id", instructorController.FindByID)
+			instructorRoute.POST("", authMiddleware, instructorController.Create)
+			instructorRoute.PUT("/:id", authMiddleware, instructorController.Update)
+			instructorRoute.DELETE("/:id", authMiddleware, instructorController.Delete)
 		}
 
 		registrationRoute := api.Group("/registrations")
@@ -72,30 +150,87 @@ func Router(
 			registrationRoute.POST("", registrationController.Create)
 
 			// PROTECTED (pakai auth)
-			registrationRoute.GET("", middlewares.AuthMiddleware(), registrationController.FindAll)
-			registrationRoute.GET("/:id", middlewares.AuthMiddleware(), registrationController.FindByID)
-			registrationRoute.GET("/program/:programId", middlewares.AuthMiddleware(), registrationController.FindByProgramID)
-			registrationRoute.GET("/by-email", middlewares.AuthMiddleware(), registrationController.FindByEmail)
-			registrationRoute.PUT("/:id", middlewares.AuthMiddleware(), registrationController.Update)
-			registrationRoute.DELETE("/:id", middlewares.AuthMiddleware(), registrationController.Delete)
+			registrationRoute.GET("", authMiddleware, registrationController.FindAll)
+			registrationRoute.GET("/:id", authMiddleware, registrationController.FindByID)
+			registrationRoute.GET("/program/:programId", authMiddleware, registrationController.FindByProgramID)
+			registrationRoute.GET("/by-email", authMiddleware, registrationController.FindByEmail)
+			registrationRoute.PUT("/:id", authMiddleware, registrationController.Update)
+			registrationRoute.DELETE("/:id", authMiddleware, registrationController.Delete)
+
+			// Attendee (registrasi grup)
+			registrationRoute.GET("/:id/attendees", authMiddleware, attendeeController.FindAll)
+			registrationRoute.POST("/:id/attendees", authMiddleware, attendeeController.Create)
+			registrationRoute.PUT("/:id/attendees", authMiddleware, attendeeController.Replace)
+			registrationRoute.PUT("/:id/attendees/:attendeeId", authMiddleware, attendeeController.Update)
+			registrationRoute.DELETE("/:id/attendees/:attendeeId", authMiddleware, attendeeController.Delete)
+		}
+
+		jobRoute := api.Group("/jobs")
+		jobRoute.Use(authMiddleware)
+		{
+			jobRoute.GET("", jobController.FindAll)
+			jobRoute.GET("/stats", jobController.Stats)
+			jobRoute.GET("/:id", jobController.FindByID)
+			jobRoute.POST("/:id/retry", jobController.Retry)
+		}
+
+		webhookRoute := api.Group("/webhooks")
+		webhookRoute.Use(authMiddleware)
+		{
+			webhookRoute.GET("", webhookController.FindAll)
+			webhookRoute.GET("/events", webhookController.Events)
+			webhookRoute.POST("", webhookController.Create)
+			webhookRoute.GET("/:id", webhookController.FindByID)
+			webhookRoute.PUT("/:id", webhookController.Update)
+			webhookRoute.DELETE("/:id", webhookController.Delete)
+			webhookRoute.POST("/:id/rotate-secret", webhookController.RotateSecret)
+			webhookRoute.GET("/:id/deliveries", webhookController.FindDeliveries)
+			webhookRoute.GET("/:id/deliveries/:deliveryId", webhookController.FindDeliveryByID)
+			webhookRoute.POST("/:id/deliveries/:deliveryId/redeliver", webhookController.Redeliver)
+		}
+
+		// API key untuk client mesin. Route ini sendiri tidak bisa diakses dengan API key
+		apiKeyRoute := api.Group("/api-keys")
+		apiKeyRoute.Use(authMiddleware)
+		{
+			apiKeyRoute.GET("", apiKeyController.FindAll)
+			apiKeyRoute.GET("/scopes", apiKeyController.Scopes)
+			apiKeyRoute.POST("", apiKeyController.Create)
+			apiKeyRoute.GET("/:id", apiKeyController.FindByID)
+			apiKeyRoute.PUT("/:id", apiKeyController.Update)
+			apiKeyRoute.DELETE("/:id", apiKeyController.Delete)
+			apiKeyRoute.POST("/:id/rotate", apiKeyController.Rotate)
+		}
+
+		contactRoute := api.Group("/contacts")
+		contactRoute.Use(authMiddleware)
+		{
+			contactRoute.GET("", contactController.FindAll)
+			contactRoute.GET("/duplicates", contactController.FindDuplicates)
+			contactRoute.GET("/:id", contactController.FindByID)
+			contactRoute.PUT("/:id", contactController.Update)
+			contactRoute.POST("/:id/merge", contactController.Merge)
 		}
 
 		serviceRoute := api.Group("/services")
 		{
-			serviceRoute.POST("", middlewares.AuthMiddleware(), serviceController.Create)
+			serviceRoute.POST("", authMiddleware, serviceController.Create)
 			serviceRoute.GET("", serviceController.FindAll)
+			serviceRoute.GET("/slug/:slug", serviceController.FindBySlug)
 			serviceRoute.GET("/:id", serviceController.FindByID)
-			serviceRoute.PUT("/:id", middlewares.AuthMiddleware(), serviceController.Update)
-			serviceRoute.DELETE("/:id", middlewares.AuthMiddleware(), serviceController.Delete)
+			serviceRoute.PUT("/reorder", authMiddleware, serviceController.Reorder)
+			serviceRoute.PUT("/:id", authMiddleware, serviceController.Update)
+			serviceRoute.DELETE("/:id", authMiddleware, serviceController.Delete)
 		}
 
 		portfolioRoute := api.Group("/portfolios")
 		{
-			portfolioRoute.POST("", middlewares.AuthMiddleware(), portfolioController.Create)
+			portfolioRoute.POST("", authMiddleware, portfolioController.Create)
 			portfolioRoute.GET("", portfolioController.FindAll)
 			portfolioRoute.GET("/:id", portfolioController.FindByID)
-			portfolioRoute.PUT("/:id", middlewares.AuthMiddleware(), portfolioController.Update)
-			portfolioRoute.DELETE("/:id", middlewares.AuthMiddleware(), portfolioController.Delete)
+			portfolioRoute.PUT("/reorder", authMiddleware, portfolioController.Reorder)
+			portfolioRoute.PUT("/:id", authMiddleware, portfolioController.Update)
+			portfolioRoute.DELETE("/:id", authMiddleware, portfolioController.Delete)
 		}
 
 		featureRoute := api.Group("/features")
@@ -103,19 +238,22 @@ func Router(
 			featureRoute.GET("", featureController.FindAll)
 			featureRoute.GET("/active", featureController.FindAllActive)
 			featureRoute.GET("/:id", featureController.FindByID)
-			featureRoute.POST("", middlewares.AuthMiddleware(), featureController.Create)
-			featureRoute.PUT("/:id", middlewares.AuthMiddleware(), featureController.Update)
-			featureRoute.DELETE("/:id", middlewares.AuthMiddleware(), featureController.Delete)
+			featureRoute.POST("", authMiddleware, featureController.Create)
+			featureRoute.PUT("/reorder", authMiddleware, featureController.Reorder)
+			featureRoute.PUT("/:id", authMiddleware, featureController.Update)
+			featureRoute.DELETE("/:id", authMiddleware, featureController.Delete)
 		}
 
 		galleryRoute := api.Group("/galleries")
 		{
 			galleryRoute.GET("", galleryController.FindAll)
 			galleryRoute.GET("/active", galleryController.FindAllActive)
+			galleryRoute.GET("/slug/:slug", galleryController.FindBySlug)
 			galleryRoute.GET("/:id", galleryController.FindByID)
-			galleryRoute.POST("", middlewares.AuthMiddleware(), galleryController.Create)
-			galleryRoute.PUT("/:id", middlewares.AuthMiddleware(), galleryController.Update)
-			galleryRoute.DELETE("/:id", middlewares.AuthMiddleware(), galleryController.Delete)
+			galleryRoute.POST("", authMiddleware, galleryController.Create)
+			galleryRoute.PUT("/reorder", authMiddleware, galleryController.Reorder)
+			galleryRoute.PUT("/:id", authMiddleware, galleryController.Update)
+			galleryRoute.DELETE("/:id", authMiddleware, galleryController.Delete)
 		}
 
 		videoGalleryRoute := api.Group("/video-galleries")
@@ -125,9 +263,10 @@ func Router(
 			videoGalleryRoute.GET("/categories", videoGalleryController.FindAllCategories)
 			videoGalleryRoute.GET("/by-category", videoGalleryController.FindByCategory)
 			videoGalleryRoute.GET("/:id", videoGalleryController.FindByID)
-			videoGalleryRoute.POST("", middlewares.AuthMiddleware(), videoGalleryController.Create)
-			videoGalleryRoute.PUT("/:id", middlewares.AuthMiddleware(), videoGalleryController.Update)
-			videoGalleryRoute.DELETE("/:id", middlewares.AuthMiddleware(), videoGalleryController.Delete)
+			videoGalleryRoute.POST("", authMiddleware, videoGalleryController.Create)
+			videoGalleryRoute.PUT("/reorder", authMiddleware, videoGalleryController.Reorder)
+			videoGalleryRoute.PUT("/:id", authMiddleware, videoGalleryController.Update)
+			videoGalleryRoute.DELETE("/:id", authMiddleware, videoGalleryController.Delete)
 		}
 
 		flyerGalleryRoute := api.Group("/flyer-galleries")
@@ -135,9 +274,35 @@ func Router(
 			flyerGalleryRoute.GET("", flyerGalleryController.FindAll)
 			flyerGalleryRoute.GET("/active", flyerGalleryController.FindAllActive)
 			flyerGalleryRoute.GET("/:id", flyerGalleryController.FindByID)
-			flyerGalleryRoute.POST("", middlewares.AuthMiddleware(), flyerGalleryController.Create)
-			flyerGalleryRoute.PUT("/:id", middlewares.AuthMiddleware(), flyerGalleryController.Update)
-			flyerGalleryRoute.DELETE("/:id", middlewares.AuthMiddleware(), flyerGalleryController.Delete)
+			flyerGalleryRoute.POST("", authMiddleware, flyerGalleryController.Create)
+			flyerGalleryRoute.PUT("/reorder", authMiddleware, flyerGalleryController.Reorder)
+			flyerGalleryRoute.PUT("/:id", authMiddleware, flyerGalleryController.Update)
+			flyerGalleryRoute.DELETE("/:id", authMiddleware, flyerGalleryController.Delete)
+		}
+
+		galleryAlbumRoute := api.Group("/gallery-albums")
+		{
+			galleryAlbumRoute.GET("", galleryAlbumController.FindAll)
+			galleryAlbumRoute.GET("/active", galleryAlbumController.FindAllActive)
+			galleryAlbumRoute.GET("/:id", galleryAlbumController.FindByID)
+			albumUpload := []gin.HandlerFunc{
+				middlewares.RouteBodyLimit(controllers.MaxAlbumUploadSize),
+				middlewares.Timeout(albumUploadTimeout),
+			}
+			galleryAlbumRoute.POST("", append(albumUpload, authMiddleware, galleryAlbumController.Create)...)
+			galleryAlbumRoute.POST("/:id/images", append(albumUpload, authMiddleware, galleryAlbumController.UploadImages)...)
+			galleryAlbumRoute.PUT("/reorder", authMiddleware, galleryAlbumController.Reorder)
+			galleryAlbumRoute.PUT("/:id", authMiddleware, galleryAlbumController.Update)
+			galleryAlbumRoute.DELETE("/:id", authMiddleware, galleryAlbumController.Delete)
+		}
+
+		translationRoute := api.Group("/translations")
+		translationRoute.Use(authMiddleware)
+		{
+			translationRoute.GET("/missing", translationController.FindMissing)
+			translationRoute.GET("/:entity/:id", translationController.FindByEntity)
+			translationRoute.PUT("/:entity/:id/:locale", translationController.Save)
+			translationRoute.DELETE("/:entity/:id/:locale", translationController.DeleteLocale)
 		}
 	}
 }
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/tech-azim/be-learnova/apikey"
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+// apiKeyTouchInterval last_used_at hanya ditulis ulang setelah interval ini
+// supaya client yang sering memanggil API tidak menulis ke database di setiap request
+const apiKeyTouchInterval = time.Minute
+
+var (
+	// ErrAPIKeyInvalid key tidak dikenal, salah, nonaktif atau kedaluwarsa
+	ErrAPIKeyInvalid = apperrors.Unauthorized("invalid_api_key", "invalid or expired api key")
+	// ErrAPIKeyScope key tidak punya scope untuk route yang diminta
+	ErrAPIKeyScope = apperrors.Forbidden("api_key_scope", "api key is not allowed to access this resource")
+	// ErrAPIKeyIP request berasal dari IP di luar allowlist key
+	ErrAPIKeyIP = apperrors.Forbidden("api_key_ip_not_allowed", "api key is not allowed from this ip address")
+
+	ErrAPIKeyScopeInvalid = apperrors.Validation("invalid_api_key_scope", "unknown api key scope", nil)
+	ErrAPIKeyIPInvalid    = apperrors.Validation("invalid_api_key_ip", "allowed ip must be an ip address or cidr", nil)
+	ErrAPIKeyExpiry       = apperrors.Validation("invalid_api_key_expiry", "api key expiry must be in the future", nil)
+)
+
+type APIKeyService interface {
+	FindAll(params utils.PaginationParams) ([]models.APIKey, int64, error)
+	FindByID(id uint) (models.APIKey, error)
+	// Create & Rotate mengembalikan key plaintext, hanya bisa dilihat sekali
+	Create(key models.APIKey) (models.APIKey, string, error)
+	Update(key models.APIKey) (models.APIKey, error)
+	Delete(id uint) error
+	Rotate(id uint) (models.APIKey, string, error)
+	Authenticate(ctx context.Context, key, ip, method, route string) (models.APIKey, error)
+}
+
+type apiKeyService struct {
+	apiKeyRepo repositories.APIKeyRepository
+	logger     *slog.Logger
+}
+
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyRepository, logger *slog.Logger) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo,
+		logger,
+	}
+}
+
+// validate normalisasi & validasi scope, allowlist IP dan expiry
+func (s *apiKeyService) validate(key *models.APIKey, isNew bool) error {
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scope = apikey.NormalizeScope(scope)
+		if !apikey.ValidScope(scope) {
+			return fmt.Errorf("%w: %s", ErrAPIKeyScopeInvalid, scope)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	key.Scopes = scopes
+
+	prefixes, err := apikey.ParseAllowlist(key.AllowedIPs)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAPIKeyIPInvalid, err)
+	}
+	allowed := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		allowed[i] = prefix.String()
+	}
+	key.AllowedIPs = allowed
+
+	// Expiry lama yang sudah lewat boleh dipertahankan saat update (key tetap tidak bisa dipakai)
+	if isNew && key.Expired(time.Now()) {
+		return ErrAPIKeyExpiry
+	}
+	return nil
+}
+
+// FindAll implements APIKeyService.
+func (s *apiKeyService) FindAll(params utils.PaginationParams) ([]models.APIKey, int64, error) {
+	data, total, err := s.apiKeyRepo.FindAll(params)
+	if err != nil {
+		return []models.APIKey{}, 0, err
+	}
+
+	return data, total, nil
+}
+
+// FindByID implements APIKeyService.
+func (s *apiKeyService) FindByID(id uint) (models.APIKey, error) {
+	return s.apiKeyRepo.FindByID(id)
+}
+
+// Create implements APIKeyService.
+func (s *apiKeyService) Create(key models.APIKey) (models.APIKey, string, error) {
+	if err := s.validate(&key, true); err != nil {
+		return models.APIKey{}, "", err
+	}
+
+	plaintext, prefix, err := apikey.Generate()
+	if err != nil {
+		return models.APIKey{}, "", err
+	}
+	key.Prefix = prefix
+	key.KeyHash = apikey.Hash(plaintext)
+
+	data, err := s.apiKeyRepo.Create(key)
+	if err != nil {
+		return models.APIKey{}, "", err
+	}
+
+	return data, plaintext, nil
+}
+
+// Update implements APIKeyService.
+// Prefix & hash tidak berubah, gunakan Rotate untuk mengganti key
+func (s *apiKeyService) Update(key models.APIKey) (models.APIKey, error) {
+	existing, err := s.apiKeyRepo.FindByID(key.ID)
+	if err != nil {
+		return models.APIKey{}, err
+	}
+
+	isNewExpiry := key.ExpiresAt != nil && (existing.ExpiresAt == nil || !key.ExpiresAt.Equal(*existing.ExpiresAt))
+	if err := s.validate(&key, isNewExpiry); err != nil {
+		return models.APIKey{}, err
+	}
+	key.Prefix = existing.Prefix
+	key.KeyHash = existing.KeyHash
+
+	return s.apiKeyRepo.Update(key)
+}
+
+// Delete implements APIKeyService.
+func (s *apiKeyService) Delete(id uint) error {
+	return s.apiKeyRepo.Delete(id)
+}
+
+// Rotate implements APIKeyService.
+// Key lama langsung tidak berlaku, scope & pengaturan lain tetap
+func (s *apiKeyService) Rotate(id uint) (models.APIKey, string, error) {
+	key, err := s.apiKeyRepo.FindByID(id)
+	if err != nil {
+		return models.APIKey{}, "", err
+	}
+
+	plaintext, prefix, err := apikey.Generate()
+	if err != nil {
+		return models.APIKey{}, "", err
+	}
+	key.Prefix = prefix
+	key.KeyHash = apikey.Hash(plaintext)
+	key.LastUsedAt = nil
+	key.LastUsedIP = ""
+
+	data, err := s.apiKeyRepo.Update(key)
+	if err != nil {
+		return models.APIKey{}, "", err
+	}
+
+	return data, plaintext, nil
+}
+
+// Authenticate implements APIKeyService.
+// route pola route gin (contoh /api/v1/programs/:id) untuk menentukan scope yang dibutuhkan
+func (s *apiKeyService) Authenticate(ctx context.Context, key, ip, method, route string) (models.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.Authenticate")
+	defer span.End()
+
+	prefix, ok := apikey.ParsePrefix(key)
+	if !ok {
+		return models.APIKey{}, ErrAPIKeyInvalid
+	}
+
+	data, err := s.apiKeyRepo.FindByPrefix(ctx, prefix)
+	if apperrors.IsNotFound(err) {
+		return models.APIKey{}, ErrAPIKeyInvalid
+	}
+	if err != nil {
+		return models.APIKey{}, err
+	}
+
+	now := time.Now()
+	if !apikey.Verify(key, data.KeyHash) || !data.IsActive || data.Expired(now) {
+		return models.APIKey{}, ErrAPIKeyInvalid
+	}
+	if !apikey.IPAllowed(data.AllowedIPs, ip) {
+		return models.APIKey{}, ErrAPIKeyIP
+	}
+
+	required, ok := apikey.Required(method, route)
+	if !ok || !apikey.Allows(data.Scopes, required) {
+		return models.APIKey{}, fmt.Errorf("%w: requires %s", ErrAPIKeyScope, scopeLabel(required, ok))
+	}
+
+	// Gagal mencatat pemakaian tidak boleh menggagalkan request
+	if data.LastUsedAt == nil || now.Sub(*data.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.apiKeyRepo.TouchLastUsed(ctx, data.ID, now, ip); err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.WarnContext(ctx, "failed to update api key last used", "api_key_id", data.ID, "error", err)
+		}
+		data.LastUsedAt = &now
+		data.LastUsedIP = ip
+	}
+
+	return data, nil
+}
+
+// scopeLabel untuk pesan error: route yang tidak bisa diakses API key sama sekali
+func scopeLabel(required string, ok bool) string {
+	if !ok {
+		return "admin login"
+	}
+	return required
+}
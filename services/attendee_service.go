@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+// ErrAttendeeLimit jumlah attendee sudah sama dengan jumlah participants registrasi
+var ErrAttendeeLimit = repositories.ErrAttendeeLimit
+
+type AttendeeService interface {
+	FindByRegistrationID(ctx context.Context, registrationID uint) ([]models.Attendee, error)
+	FindByID(ctx context.Context, registrationID uint, id uint) (models.Attendee, error)
+	Create(ctx context.Context, attendee models.Attendee) (models.Attendee, error)
+	Update(ctx context.Context, attendee models.Attendee) (models.Attendee, error)
+	Delete(ctx context.Context, registrationID uint, id uint) error
+	Replace(ctx context.Context, registration models.Registration, attendees []models.Attendee) ([]models.Attendee, error)
+}
+
+type attendeeService struct {
+	attendeeRepo repositories.AttendeeRepository
+}
+
+func NewAttendeeService(attendeeRepo repositories.AttendeeRepository) AttendeeService {
+	return &attendeeService{
+		attendeeRepo,
+	}
+}
+
+// FindByRegistrationID implements AttendeeService.
+func (s *attendeeService) FindByRegistrationID(ctx context.Context, registrationID uint) ([]models.Attendee, error) {
+	ctx, span := tracing.Start(ctx, "AttendeeService.FindByRegistrationID")
+	defer span.End()
+
+	data, err := s.attendeeRepo.FindByRegistrationID(ctx, registrationID)
+
+	if err != nil {
+		return []models.Attendee{}, err
+	}
+
+	return data, nil
+}
+
+// FindByID implements AttendeeService.
+func (s *attendeeService) FindByID(ctx context.Context, registrationID uint, id uint) (models.Attendee, error) {
+	ctx, span := tracing.Start(ctx, "AttendeeService.FindByID")
+	defer span.End()
+
+	data, err := s.attendeeRepo.FindByID(ctx, registrationID, id)
+
+	if err != nil {
+		return models.Attendee{}, err
+	}
+
+	return data, nil
+}
+
+// Create implements AttendeeService.
+func (s *attendeeService) Create(ctx context.Context, attendee models.Attendee) (models.Attendee, error) {
+	ctx, span := tracing.Start(ctx, "AttendeeService.Create")
+	defer span.End()
+
+	attendee.Email = utils.NormalizeEmail(attendee.Email)
+
+	data, err := s.attendeeRepo.Create(ctx, attendee)
+
+	if err != nil {
+		return models.Attendee{}, err
+	}
+
+	return data, nil
+}
+
+// Update implements AttendeeService.
+func (s *attendeeService) Update(ctx context.Context, attendee models.Attendee) (models.Attendee, error) {
+	ctx, span := tracing.Start(ctx, "AttendeeService.Update")
+	defer span.End()
+
+	attendee.Email = utils.NormalizeEmail(attendee.Email)
+
+	data, err := s.attendeeRepo.Update(ctx, attendee)
+
+	if err != nil {
+		return models.Attendee{}, err
+	}
+
+	return data, nil
+}
+
+// Delete implements AttendeeService.
+func (s *attendeeService) Delete(ctx context.Context, registrationID uint, id uint) error {
+	ctx, span := tracing.Start(ctx, "AttendeeService.Delete")
+	defer span.End()
+
+	return s.attendeeRepo.Delete(ctx, registrationID, id)
+}
+
+// Replace implements AttendeeService.
+// Participants registrasi ikut disesuaikan dengan jumlah attendee baru
+func (s *attendeeService) Replace(ctx context.Context, registration models.Registration, attendees []models.Attendee) ([]models.Attendee, error) {
+	ctx, span := tracing.Start(ctx, "AttendeeService.Replace")
+	defer span.End()
+
+	for i := range attendees {
+		attendees[i].Email = utils.NormalizeEmail(attendees[i].Email)
+	}
+
+	data, err := s.attendeeRepo.Replace(ctx, registration, attendees)
+
+	if err != nil {
+		return []models.Attendee{}, err
+	}
+
+	return data, nil
+}
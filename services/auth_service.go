@@ -1,59 +1,80 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"os"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/tech-azim/be-learnova/apperrors"
 	"github.com/tech-azim/be-learnova/middlewares"
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
 	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrAccountNotFound tidak ada user dengan email tersebut
+	ErrAccountNotFound = apperrors.Unauthorized("account_not_found", "Account not found")
+	// ErrWrongPassword password tidak cocok
+	ErrWrongPassword = apperrors.Unauthorized("wrong_password", "Wrong password")
 )
 
 type AuthService interface {
-	Login(email string, password string) (string, models.User, error)
-	Register(user models.User) (models.User, error)
+	Login(ctx context.Context, email string, password string) (string, models.User, error)
+	Register(ctx context.Context, user models.User) (models.User, error)
 }
 
 type authService struct {
-	userRepo repositories.UserRepository
+	userRepo  repositories.UserRepository
+	jwtSecret []byte
+	tokenTTL  time.Duration
 }
 
-func NewAuthService(userRepo repositories.UserRepository) AuthService {
+func NewAuthService(userRepo repositories.UserRepository, jwtSecret string, tokenTTL time.Duration) AuthService {
 	return &authService{
 		userRepo,
+		[]byte(jwtSecret),
+		tokenTTL,
 	}
 }
 
 // Login implements [AuthService].
-func (a *authService) Login(email string, password string) (string, models.User, error) {
-	user, err := a.userRepo.FindByEmail(email)
+func (a *authService) Login(ctx context.Context, email string, password string) (string, models.User, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer span.End()
+
+	user, err := a.userRepo.FindByEmail(ctx, email)
 
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", models.User{}, ErrAccountNotFound
+	}
 	if err != nil {
-		return "", models.User{}, errors.New("Account not found")
+		return "", models.User{}, err
 	}
 
 	if _, err := utils.Descrypt(password, user.Password); err != nil {
-		return "", models.User{}, errors.New("Wrong password")
+		return "", models.User{}, ErrWrongPassword
 	}
 
 	claims := middlewares.ClaimStruct{
 		UserID: user.ID,
 		Email:  user.Email,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(a.tokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	tokenString, err := token.SignedString(a.jwtSecret)
 
 	if err != nil {
-		return "", models.User{}, errors.New("failed to generate token")
+		return "", models.User{}, apperrors.Internal(fmt.Errorf("failed to generate token: %w", err))
 	}
 
 	user.Password = ""
@@ -61,6 +82,9 @@ func (a *authService) Login(email string, password string) (string, models.User,
 }
 
 // Register implements [AuthService].
-func (a *authService) Register(user models.User) (models.User, error) {
+func (a *authService) Register(ctx context.Context, user models.User) (models.User, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Register")
+	defer span.End()
+
 	panic("unimplemented")
 }
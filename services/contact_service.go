@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+var ErrContactMergeSelf = apperrors.Validation("contact_merge_self", "cannot merge contact into itself", nil)
+
+type ContactService interface {
+	FindAll(ctx context.Context, params utils.PaginationParams, search string) ([]models.Contact, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Contact, error)
+	FindDetailByID(ctx context.Context, id uint) (models.Contact, error)
+	FindDuplicates(ctx context.Context) ([]repositories.DuplicateContactGroup, error)
+	Update(ctx context.Context, contact models.Contact, company string) (models.Contact, error)
+	Merge(ctx context.Context, targetID uint, sourceIDs []uint) (models.Contact, error)
+}
+
+type contactService struct {
+	contactRepo repositories.ContactRepository
+}
+
+func NewContactService(contactRepo repositories.ContactRepository) ContactService {
+	return &contactService{
+		contactRepo,
+	}
+}
+
+// organizationID cari/buat organization dari nama perusahaan, nil jika kosong
+func (s *contactService) organizationID(ctx context.Context, company string) (*uint, error) {
+	company = strings.TrimSpace(company)
+	if company == "" {
+		return nil, nil
+	}
+
+	organization, err := s.contactRepo.FindOrCreateOrganization(ctx, company)
+	if err != nil {
+		return nil, err
+	}
+	return &organization.ID, nil
+}
+
+// FindAll implements ContactService.
+func (s *contactService) FindAll(ctx context.Context, params utils.PaginationParams, search string) ([]models.Contact, int64, error) {
+	ctx, span := tracing.Start(ctx, "ContactService.FindAll")
+	defer span.End()
+
+	data, total, err := s.contactRepo.FindAll(ctx, params, strings.TrimSpace(search))
+
+	if err != nil {
+		return []models.Contact{}, 0, err
+	}
+
+	return data, total, nil
+}
+
+// FindByID implements ContactService.
+func (s *contactService) FindByID(ctx context.Context, id uint) (models.Contact, error) {
+	ctx, span := tracing.Start(ctx, "ContactService.FindByID")
+	defer span.End()
+
+	data, err := s.contactRepo.FindByID(ctx, id)
+
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	return data, nil
+}
+
+// FindDetailByID implements ContactService.
+func (s *contactService) FindDetailByID(ctx context.Context, id uint) (models.Contact, error) {
+	ctx, span := tracing.Start(ctx, "ContactService.FindDetailByID")
+	defer span.End()
+
+	data, err := s.contactRepo.FindDetailByID(ctx, id)
+
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	return data, nil
+}
+
+// FindDuplicates implements ContactService.
+func (s *contactService) FindDuplicates(ctx context.Context) ([]repositories.DuplicateContactGroup, error) {
+	ctx, span := tracing.Start(ctx, "ContactService.FindDuplicates")
+	defer span.End()
+
+	data, err := s.contactRepo.FindDuplicates(ctx)
+
+	if err != nil {
+		return []repositories.DuplicateContactGroup{}, err
+	}
+
+	return data, nil
+}
+
+// Update implements ContactService.
+func (s *contactService) Update(ctx context.Context, contact models.Contact, company string) (models.Contact, error) {
+	ctx, span := tracing.Start(ctx, "ContactService.Update")
+	defer span.End()
+
+	contact.Email = utils.NormalizeEmail(contact.Email)
+	contact.NormalizedPhone = utils.NormalizePhone(contact.Phone)
+
+	organizationID, err := s.organizationID(ctx, company)
+	if err != nil {
+		return models.Contact{}, err
+	}
+	contact.OrganizationID = organizationID
+
+	if _, err := s.contactRepo.Update(ctx, contact); err != nil {
+		return models.Contact{}, err
+	}
+
+	return s.contactRepo.FindByID(ctx, contact.ID)
+}
+
+// Merge implements ContactService.
+// Field kosong pada target diisi dari contact sumber, lalu semua registrasi dipindah ke target
+func (s *contactService) Merge(ctx context.Context, targetID uint, sourceIDs []uint) (models.Contact, error) {
+	ctx, span := tracing.Start(ctx, "ContactService.Merge")
+	defer span.End()
+
+	target, err := s.contactRepo.FindByID(ctx, targetID)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	for _, sourceID := range sourceIDs {
+		if sourceID == targetID {
+			return models.Contact{}, ErrContactMergeSelf
+		}
+
+		source, err := s.contactRepo.FindByID(ctx, sourceID)
+		if err != nil {
+			return models.Contact{}, err
+		}
+
+		if target.Name == "" {
+			target.Name = source.Name
+		}
+		if target.Email == "" {
+			target.Email = source.Email
+		}
+		if target.Phone == "" {
+			target.Phone = source.Phone
+			target.NormalizedPhone = source.NormalizedPhone
+		}
+		if target.Position == "" {
+			target.Position = source.Position
+		}
+		if target.OrganizationID == nil {
+			target.OrganizationID = source.OrganizationID
+		}
+	}
+
+	if err := s.contactRepo.Merge(ctx, target, sourceIDs); err != nil {
+		return models.Contact{}, err
+	}
+
+	return s.contactRepo.FindDetailByID(ctx, targetID)
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
+)
+
+type CurriculumService interface {
+	FindModulesByProgramID(ctx context.Context, programID uint) ([]models.CurriculumModule, error)
+	FindModuleByID(ctx context.Context, programID uint, moduleID uint) (models.CurriculumModule, error)
+	CreateModule(ctx context.Context, module models.CurriculumModule) (models.CurriculumModule, error)
+	UpdateModule(ctx context.Context, module models.CurriculumModule) (models.CurriculumModule, error)
+	DeleteModule(ctx context.Context, id uint) error
+	ReorderModules(ctx context.Context, programID uint, ids []uint) error
+	FindLessonByID(ctx context.Context, moduleID uint, lessonID uint) (models.CurriculumLesson, error)
+	CreateLesson(ctx context.Context, lesson models.CurriculumLesson) (models.CurriculumLesson, error)
+	UpdateLesson(ctx context.Context, lesson models.CurriculumLesson) (models.CurriculumLesson, error)
+	DeleteLesson(ctx context.Context, id uint) error
+	ReorderLessons(ctx context.Context, moduleID uint, ids []uint) error
+}
+
+type curriculumService struct {
+	curriculumRepo repositories.CurriculumRepository
+	webhookService WebhookService
+}
+
+func NewCurriculumService(curriculumRepo repositories.CurriculumRepository, webhookService WebhookService) CurriculumService {
+	return &curriculumService{
+		curriculumRepo,
+		webhookService,
+	}
+}
+
+// FindModulesByProgramID implements CurriculumService.
+func (s *curriculumService) FindModulesByProgramID(ctx context.Context, programID uint) ([]models.CurriculumModule, error) {
+	ctx, span := tracing.Start(ctx, "CurriculumService.FindModulesByProgramID")
+	defer span.End()
+
+	data, err := s.curriculumRepo.FindModulesByProgramID(ctx, programID)
+
+	if err != nil {
+		return []models.CurriculumModule{}, err
+	}
+
+	return data, nil
+}
+
+// FindModuleByID implements CurriculumService.
+func (s *curriculumService) FindModuleByID(ctx context.Context, programID uint, moduleID uint) (models.CurriculumModule, error) {
+	ctx, span := tracing.Start(ctx, "CurriculumService.FindModuleByID")
+	defer span.End()
+
+	data, err := s.curriculumRepo.FindModuleByID(ctx, programID, moduleID)
+
+	if err != nil {
+		return models.CurriculumModule{}, err
+	}
+
+	return data, nil
+}
+
+// CreateModule implements CurriculumService.
+func (s *curriculumService) CreateModule(ctx context.Context, module models.CurriculumModule) (models.CurriculumModule, error) {
+	ctx, span := tracing.Start(ctx, "CurriculumService.CreateModule")
+	defer span.End()
+
+	result, err := s.curriculumRepo.CreateModule(ctx, module)
+
+	if err != nil {
+		return models.CurriculumModule{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityCurriculumModule, ContentActionCreated, result.ID)
+
+	return result, nil
+}
+
+// UpdateModule implements CurriculumService.
+func (s *curriculumService) UpdateModule(ctx context.Context, module models.CurriculumModule) (models.CurriculumModule, error) {
+	ctx, span := tracing.Start(ctx, "CurriculumService.UpdateModule")
+	defer span.End()
+
+	data, err := s.curriculumRepo.UpdateModule(ctx, module)
+
+	if err != nil {
+		return models.CurriculumModule{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityCurriculumModule, ContentActionUpdated, data.ID)
+
+	return data, nil
+}
+
+// DeleteModule implements CurriculumService.
+func (s *curriculumService) DeleteModule(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "CurriculumService.DeleteModule")
+	defer span.End()
+
+	err := s.curriculumRepo.DeleteModule(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityCurriculumModule, ContentActionDeleted, id)
+
+	return nil
+}
+
+// ReorderModules implements CurriculumService.
+func (s *curriculumService) ReorderModules(ctx context.Context, programID uint, ids []uint) error {
+	ctx, span := tracing.Start(ctx, "CurriculumService.ReorderModules")
+	defer span.End()
+
+	err := s.curriculumRepo.ReorderModules(ctx, programID, ids)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityCurriculumModule, ContentActionReordered, 0)
+
+	return nil
+}
+
+// FindLessonByID implements CurriculumService.
+func (s *curriculumService) FindLessonByID(ctx context.Context, moduleID uint, lessonID uint) (models.CurriculumLesson, error) {
+	ctx, span := tracing.Start(ctx, "CurriculumService.FindLessonByID")
+	defer span.End()
+
+	data, err := s.curriculumRepo.FindLessonByID(ctx, moduleID, lessonID)
+
+	if err != nil {
+		return models.CurriculumLesson{}, err
+	}
+
+	return data, nil
+}
+
+// CreateLesson implements CurriculumService.
+func (s *curriculumService) CreateLesson(ctx context.Context, lesson models.CurriculumLesson) (models.CurriculumLesson, error) {
+	ctx, span := tracing.Start(ctx, "CurriculumService.CreateLesson")
+	defer span.End()
+
+	result, err := s.curriculumRepo.CreateLesson(ctx, lesson)
+
+	if err != nil {
+		return models.CurriculumLesson{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityCurriculumLesson, ContentActionCreated, result.ID)
+
+	return result, nil
+}
+
+// UpdateLesson implements CurriculumService.
+func (s *curriculumService) UpdateLesson(ctx context.Context, lesson models.CurriculumLesson) (models.CurriculumLesson, error) {
+	ctx, span := tracing.Start(ctx, "CurriculumService.UpdateLesson")
+	defer span.End()
+
+	data, err := s.curriculumRepo.UpdateLesson(ctx, lesson)
+
+	if err != nil {
+		return models.CurriculumLesson{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityCurriculumLesson, ContentActionUpdated, data.ID)
+
+	return data, nil
+}
+
+// DeleteLesson implements CurriculumService.
+func (s *curriculumService) DeleteLesson(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "CurriculumService.DeleteLesson")
+	defer span.End()
+
+	err := s.curriculumRepo.DeleteLesson(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityCurriculumLesson, ContentActionDeleted, id)
+
+	return nil
+}
+
+// ReorderLessons implements CurriculumService.
+func (s *curriculumService) ReorderLessons(ctx context.Context, moduleID uint, ids []uint) error {
+	ctx, span := tracing.Start(ctx, "CurriculumService.ReorderLessons")
+	defer span.End()
+
+	err := s.curriculumRepo.ReorderLessons(ctx, moduleID, ids)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityCurriculumLesson, ContentActionReordered, 0)
+
+	return nil
+}
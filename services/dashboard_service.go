@@ -1,12 +1,15 @@
 package services
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
 )
 
 type DashboardService interface {
-	GetDashboardData() (DashboardResponse, error)
+	GetDashboardData(ctx context.Context) (DashboardResponse, error)
 }
 
 type DashboardResponse struct {
@@ -36,46 +39,49 @@ func NewDashboardService(repo repositories.DashboardRepository) DashboardService
 	return &dashboardService{repo}
 }
 
-func (s *dashboardService) GetDashboardData() (DashboardResponse, error) {
+func (s *dashboardService) GetDashboardData(ctx context.Context) (DashboardResponse, error) {
+	ctx, span := tracing.Start(ctx, "DashboardService.GetDashboardData")
+	defer span.End()
+
 	var response DashboardResponse
 
-	totalProgram, err := s.repo.GetTotalProgram()
+	totalProgram, err := s.repo.GetTotalProgram(ctx)
 	if err != nil {
 		return response, err
 	}
 	response.TotalProgram = totalProgram
 
-	totalRegistration, err := s.repo.GetTotalRegistration()
+	totalRegistration, err := s.repo.GetTotalRegistration(ctx)
 	if err != nil {
 		return response, err
 	}
 	response.TotalRegistration = totalRegistration
 
-	activeParticipants, err := s.repo.GetActiveParticipants()
+	activeParticipants, err := s.repo.GetActiveParticipants(ctx)
 	if err != nil {
 		return response, err
 	}
 	response.ActiveParticipants = activeParticipants
 
-	pendingParticipants, err := s.repo.GetPendingParticipants()
+	pendingParticipants, err := s.repo.GetPendingParticipants(ctx)
 	if err != nil {
 		return response, err
 	}
 	response.PendingParticipants = pendingParticipants
 
-	latestRegistrations, err := s.repo.GetLatestRegistrations(5)
+	latestRegistrations, err := s.repo.GetLatestRegistrations(ctx, 5)
 	if err != nil {
 		return response, err
 	}
 	response.LatestRegistrations = latestRegistrations
 
-	recentActivities, err := s.repo.GetRecentActivities(10)
+	recentActivities, err := s.repo.GetRecentActivities(ctx, 10)
 	if err != nil {
 		return response, err
 	}
 	response.RecentActivities = mapToActivityItems(recentActivities)
 
-	popularPrograms, err := s.repo.GetPopularPrograms(5)
+	popularPrograms, err := s.repo.GetPopularPrograms(ctx, 5)
 	if err != nil {
 		return response, err
 	}
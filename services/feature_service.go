@@ -1,44 +1,58 @@
 package services
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
 	"github.com/tech-azim/be-learnova/utils"
 )
 
 type FeatureService interface {
-	Create(feature models.Feature) (models.Feature, error)
-	FindAll(params utils.PaginationParams) ([]models.Feature, int64, error)
-	FindByID(id uint) (models.Feature, error)
-	Update(feature models.Feature) (models.Feature, error)
-	Delete(id uint) error
-	FindAllActive() ([]models.Feature, error)
+	Create(ctx context.Context, feature models.Feature) (models.Feature, error)
+	FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Feature, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Feature, error)
+	Update(ctx context.Context, feature models.Feature) (models.Feature, error)
+	Delete(ctx context.Context, id uint) error
+	FindAllActive(ctx context.Context) ([]models.Feature, error)
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type featureService struct {
-	featureRepo repositories.FeatureRepository
+	featureRepo    repositories.FeatureRepository
+	webhookService WebhookService
 }
 
-func NewFeatureService(featureRepo repositories.FeatureRepository) FeatureService {
+func NewFeatureService(featureRepo repositories.FeatureRepository, webhookService WebhookService) FeatureService {
 	return &featureService{
 		featureRepo,
+		webhookService,
 	}
 }
 
 // Create implements FeatureService.
-func (s *featureService) Create(feature models.Feature) (models.Feature, error) {
-	result, err := s.featureRepo.Create(feature)
+func (s *featureService) Create(ctx context.Context, feature models.Feature) (models.Feature, error) {
+	ctx, span := tracing.Start(ctx, "FeatureService.Create")
+	defer span.End()
+
+	result, err := s.featureRepo.Create(ctx, feature)
 
 	if err != nil {
 		return models.Feature{}, err
 	}
 
+	dispatchContentChange(s.webhookService, ContentEntityFeature, ContentActionCreated, result.ID)
+
 	return result, nil
 }
 
 // FindAll implements FeatureService.
-func (s *featureService) FindAll(params utils.PaginationParams) ([]models.Feature, int64, error) {
-	data, total, err := s.featureRepo.FindAll(params)
+func (s *featureService) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Feature, int64, error) {
+	ctx, span := tracing.Start(ctx, "FeatureService.FindAll")
+	defer span.End()
+
+	data, total, err := s.featureRepo.FindAll(ctx, params)
 
 	if err != nil {
 		return []models.Feature{}, 0, err
@@ -48,8 +62,11 @@ func (s *featureService) FindAll(params utils.PaginationParams) ([]models.Featur
 }
 
 // FindByID implements FeatureService.
-func (s *featureService) FindByID(id uint) (models.Feature, error) {
-	data, err := s.featureRepo.FindByID(id)
+func (s *featureService) FindByID(ctx context.Context, id uint) (models.Feature, error) {
+	ctx, span := tracing.Start(ctx, "FeatureService.FindByID")
+	defer span.End()
+
+	data, err := s.featureRepo.FindByID(ctx, id)
 
 	if err != nil {
 		return models.Feature{}, err
@@ -59,34 +76,63 @@ func (s *featureService) FindByID(id uint) (models.Feature, error) {
 }
 
 // Update implements FeatureService.
-func (s *featureService) Update(feature models.Feature) (models.Feature, error) {
-	data, err := s.featureRepo.Update(feature)
+func (s *featureService) Update(ctx context.Context, feature models.Feature) (models.Feature, error) {
+	ctx, span := tracing.Start(ctx, "FeatureService.Update")
+	defer span.End()
+
+	data, err := s.featureRepo.Update(ctx, feature)
 
 	if err != nil {
 		return models.Feature{}, err
 	}
 
+	dispatchContentChange(s.webhookService, ContentEntityFeature, ContentActionUpdated, data.ID)
+
 	return data, nil
 }
 
 // Delete implements FeatureService.
-func (s *featureService) Delete(id uint) error {
-	err := s.featureRepo.Delete(id)
+func (s *featureService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "FeatureService.Delete")
+	defer span.End()
+
+	err := s.featureRepo.Delete(ctx, id)
 
 	if err != nil {
 		return err
 	}
 
+	dispatchContentChange(s.webhookService, ContentEntityFeature, ContentActionDeleted, id)
+
 	return nil
 }
 
 // FindAllActive implements FeatureService.
-func (s *featureService) FindAllActive() ([]models.Feature, error) {
-	data, err := s.featureRepo.FindAllActive()
+func (s *featureService) FindAllActive(ctx context.Context) ([]models.Feature, error) {
+	ctx, span := tracing.Start(ctx, "FeatureService.FindAllActive")
+	defer span.End()
+
+	data, err := s.featureRepo.FindAllActive(ctx)
 
 	if err != nil {
 		return []models.Feature{}, err
 	}
 
 	return data, nil
-}
\ No newline at end of file
+}
+
+// Reorder implements FeatureService.
+func (s *featureService) Reorder(ctx context.Context, ids []uint) error {
+	ctx, span := tracing.Start(ctx, "FeatureService.Reorder")
+	defer span.End()
+
+	err := s.featureRepo.Reorder(ctx, ids)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityFeature, ContentActionReordered, 0)
+
+	return nil
+}
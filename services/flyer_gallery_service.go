@@ -1,44 +1,58 @@
 package services
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
 	"github.com/tech-azim/be-learnova/utils"
 )
 
 type FlyerGalleryService interface {
-	Create(flyerGallery models.FlyerGallery) (models.FlyerGallery, error)
-	FindAll(params utils.PaginationParams) ([]models.FlyerGallery, int64, error)
-	FindByID(id uint) (models.FlyerGallery, error)
-	Update(flyerGallery models.FlyerGallery) (models.FlyerGallery, error)
-	Delete(id uint) error
-	FindAllActive() ([]models.FlyerGallery, error)
+	Create(ctx context.Context, flyerGallery models.FlyerGallery) (models.FlyerGallery, error)
+	FindAll(ctx context.Context, params utils.PaginationParams) ([]models.FlyerGallery, int64, error)
+	FindByID(ctx context.Context, id uint) (models.FlyerGallery, error)
+	Update(ctx context.Context, flyerGallery models.FlyerGallery) (models.FlyerGallery, error)
+	Delete(ctx context.Context, id uint) error
+	FindAllActive(ctx context.Context) ([]models.FlyerGallery, error)
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type flyerGalleryService struct {
 	flyerGalleryRepo repositories.FlyerGalleryRepository
+	webhookService   WebhookService
 }
 
-func NewFlyerGalleryService(flyerGalleryRepo repositories.FlyerGalleryRepository) FlyerGalleryService {
+func NewFlyerGalleryService(flyerGalleryRepo repositories.FlyerGalleryRepository, webhookService WebhookService) FlyerGalleryService {
 	return &flyerGalleryService{
 		flyerGalleryRepo,
+		webhookService,
 	}
 }
 
 // Create implements FlyerGalleryService.
-func (s *flyerGalleryService) Create(flyerGallery models.FlyerGallery) (models.FlyerGallery, error) {
-	result, err := s.flyerGalleryRepo.Create(flyerGallery)
+func (s *flyerGalleryService) Create(ctx context.Context, flyerGallery models.FlyerGallery) (models.FlyerGallery, error) {
+	ctx, span := tracing.Start(ctx, "FlyerGalleryService.Create")
+	defer span.End()
+
+	result, err := s.flyerGalleryRepo.Create(ctx, flyerGallery)
 
 	if err != nil {
 		return models.FlyerGallery{}, err
 	}
 
+	dispatchContentChange(s.webhookService, ContentEntityFlyerGallery, ContentActionCreated, result.ID)
+
 	return result, nil
 }
 
 // FindAll implements FlyerGalleryService.
-func (s *flyerGalleryService) FindAll(params utils.PaginationParams) ([]models.FlyerGallery, int64, error) {
-	data, total, err := s.flyerGalleryRepo.FindAll(params)
+func (s *flyerGalleryService) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.FlyerGallery, int64, error) {
+	ctx, span := tracing.Start(ctx, "FlyerGalleryService.FindAll")
+	defer span.End()
+
+	data, total, err := s.flyerGalleryRepo.FindAll(ctx, params)
 
 	if err != nil {
 		return []models.FlyerGallery{}, 0, err
@@ -48,8 +62,11 @@ func (s *flyerGalleryService) FindAll(params utils.PaginationParams) ([]models.F
 }
 
 // FindByID implements FlyerGalleryService.
-func (s *flyerGalleryService) FindByID(id uint) (models.FlyerGallery, error) {
-	data, err := s.flyerGalleryRepo.FindByID(id)
+func (s *flyerGalleryService) FindByID(ctx context.Context, id uint) (models.FlyerGallery, error) {
+	ctx, span := tracing.Start(ctx, "FlyerGalleryService.FindByID")
+	defer span.End()
+
+	data, err := s.flyerGalleryRepo.FindByID(ctx, id)
 
 	if err != nil {
 		return models.FlyerGallery{}, err
@@ -59,34 +76,63 @@ func (s *flyerGalleryService) FindByID(id uint) (models.FlyerGallery, error) {
 }
 
 // Update implements FlyerGalleryService.
-func (s *flyerGalleryService) Update(flyerGallery models.FlyerGallery) (models.FlyerGallery, error) {
-	data, err := s.flyerGalleryRepo.Update(flyerGallery)
+func (s *flyerGalleryService) Update(ctx context.Context, flyerGallery models.FlyerGallery) (models.FlyerGallery, error) {
+	ctx, span := tracing.Start(ctx, "FlyerGalleryService.Update")
+	defer span.End()
+
+	data, err := s.flyerGalleryRepo.Update(ctx, flyerGallery)
 
 	if err != nil {
 		return models.FlyerGallery{}, err
 	}
 
+	dispatchContentChange(s.webhookService, ContentEntityFlyerGallery, ContentActionUpdated, data.ID)
+
 	return data, nil
 }
 
 // Delete implements FlyerGalleryService.
-func (s *flyerGalleryService) Delete(id uint) error {
-	err := s.flyerGalleryRepo.Delete(id)
+func (s *flyerGalleryService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "FlyerGalleryService.Delete")
+	defer span.End()
+
+	err := s.flyerGalleryRepo.Delete(ctx, id)
 
 	if err != nil {
 		return err
 	}
 
+	dispatchContentChange(s.webhookService, ContentEntityFlyerGallery, ContentActionDeleted, id)
+
 	return nil
 }
 
 // FindAllActive implements FlyerGalleryService.
-func (s *flyerGalleryService) FindAllActive() ([]models.FlyerGallery, error) {
-	data, err := s.flyerGalleryRepo.FindAllActive()
+func (s *flyerGalleryService) FindAllActive(ctx context.Context) ([]models.FlyerGallery, error) {
+	ctx, span := tracing.Start(ctx, "FlyerGalleryService.FindAllActive")
+	defer span.End()
+
+	data, err := s.flyerGalleryRepo.FindAllActive(ctx)
 
 	if err != nil {
 		return []models.FlyerGallery{}, err
 	}
 
 	return data, nil
-}
\ No newline at end of file
+}
+
+// Reorder implements FlyerGalleryService.
+func (s *flyerGalleryService) Reorder(ctx context.Context, ids []uint) error {
+	ctx, span := tracing.Start(ctx, "FlyerGalleryService.Reorder")
+	defer span.End()
+
+	err := s.flyerGalleryRepo.Reorder(ctx, ids)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityFlyerGallery, ContentActionReordered, 0)
+
+	return nil
+}
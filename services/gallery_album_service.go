@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+type GalleryAlbumService interface {
+	Create(ctx context.Context, album models.GalleryAlbum, images []models.Gallery) (models.GalleryAlbum, error)
+	FindAll(ctx context.Context, params utils.PaginationParams) ([]models.GalleryAlbum, int64, error)
+	FindAllActive(ctx context.Context, params utils.PaginationParams) ([]models.GalleryAlbum, int64, error)
+	FindByID(ctx context.Context, id uint) (models.GalleryAlbum, error)
+	FindActiveByID(ctx context.Context, id uint) (models.GalleryAlbum, error)
+	Update(ctx context.Context, album models.GalleryAlbum) (models.GalleryAlbum, error)
+	Delete(ctx context.Context, id uint) error
+	AddImages(ctx context.Context, albumID uint, images []models.Gallery) ([]models.Gallery, error)
+	Reorder(ctx context.Context, ids []uint) error
+}
+
+type galleryAlbumService struct {
+	galleryAlbumRepo repositories.GalleryAlbumRepository
+	webhookService   WebhookService
+}
+
+func NewGalleryAlbumService(galleryAlbumRepo repositories.GalleryAlbumRepository, webhookService WebhookService) GalleryAlbumService {
+	return &galleryAlbumService{
+		galleryAlbumRepo,
+		webhookService,
+	}
+}
+
+// Create implements GalleryAlbumService.
+// Jika cover tidak diupload, gambar pertama dipakai sebagai cover
+func (s *galleryAlbumService) Create(ctx context.Context, album models.GalleryAlbum, images []models.Gallery) (models.GalleryAlbum, error) {
+	ctx, span := tracing.Start(ctx, "GalleryAlbumService.Create")
+	defer span.End()
+
+	if album.Cover == "" && len(images) > 0 {
+		album.Cover = images[0].URL
+	}
+
+	result, err := s.galleryAlbumRepo.Create(ctx, album, images)
+
+	if err != nil {
+		return models.GalleryAlbum{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityGalleryAlbum, ContentActionCreated, result.ID)
+
+	return result, nil
+}
+
+// FindAll implements GalleryAlbumService.
+func (s *galleryAlbumService) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.GalleryAlbum, int64, error) {
+	ctx, span := tracing.Start(ctx, "GalleryAlbumService.FindAll")
+	defer span.End()
+
+	data, total, err := s.galleryAlbumRepo.FindAll(ctx, params)
+
+	if err != nil {
+		return []models.GalleryAlbum{}, 0, err
+	}
+
+	return data, total, nil
+}
+
+// FindAllActive implements GalleryAlbumService.
+func (s *galleryAlbumService) FindAllActive(ctx context.Context, params utils.PaginationParams) ([]models.GalleryAlbum, int64, error) {
+	ctx, span := tracing.Start(ctx, "GalleryAlbumService.FindAllActive")
+	defer span.End()
+
+	data, total, err := s.galleryAlbumRepo.FindAllActive(ctx, params)
+
+	if err != nil {
+		return []models.GalleryAlbum{}, 0, err
+	}
+
+	return data, total, nil
+}
+
+// FindByID implements GalleryAlbumService.
+func (s *galleryAlbumService) FindByID(ctx context.Context, id uint) (models.GalleryAlbum, error) {
+	ctx, span := tracing.Start(ctx, "GalleryAlbumService.FindByID")
+	defer span.End()
+
+	data, err := s.galleryAlbumRepo.FindByID(ctx, id)
+
+	if err != nil {
+		return models.GalleryAlbum{}, err
+	}
+
+	return data, nil
+}
+
+// FindActiveByID implements GalleryAlbumService.
+func (s *galleryAlbumService) FindActiveByID(ctx context.Context, id uint) (models.GalleryAlbum, error) {
+	ctx, span := tracing.Start(ctx, "GalleryAlbumService.FindActiveByID")
+	defer span.End()
+
+	data, err := s.galleryAlbumRepo.FindActiveByID(ctx, id)
+
+	if err != nil {
+		return models.GalleryAlbum{}, err
+	}
+
+	return data, nil
+}
+
+// Update implements GalleryAlbumService.
+func (s *galleryAlbumService) Update(ctx context.Context, album models.GalleryAlbum) (models.GalleryAlbum, error) {
+	ctx, span := tracing.Start(ctx, "GalleryAlbumService.Update")
+	defer span.End()
+
+	data, err := s.galleryAlbumRepo.Update(ctx, album)
+
+	if err != nil {
+		return models.GalleryAlbum{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityGalleryAlbum, ContentActionUpdated, data.ID)
+
+	return data, nil
+}
+
+// Delete implements GalleryAlbumService.
+func (s *galleryAlbumService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "GalleryAlbumService.Delete")
+	defer span.End()
+
+	err := s.galleryAlbumRepo.Delete(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityGalleryAlbum, ContentActionDeleted, id)
+
+	return nil
+}
+
+// AddImages implements GalleryAlbumService.
+func (s *galleryAlbumService) AddImages(ctx context.Context, albumID uint, images []models.Gallery) ([]models.Gallery, error) {
+	ctx, span := tracing.Start(ctx, "GalleryAlbumService.AddImages")
+	defer span.End()
+
+	data, err := s.galleryAlbumRepo.AddImages(ctx, albumID, images)
+
+	if err != nil {
+		return []models.Gallery{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityGalleryAlbum, ContentActionUpdated, albumID)
+
+	return data, nil
+}
+
+// Reorder implements GalleryAlbumService.
+func (s *galleryAlbumService) Reorder(ctx context.Context, ids []uint) error {
+	ctx, span := tracing.Start(ctx, "GalleryAlbumService.Reorder")
+	defer span.End()
+
+	err := s.galleryAlbumRepo.Reorder(ctx, ids)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityGalleryAlbum, ContentActionReordered, 0)
+
+	return nil
+}
@@ -1,44 +1,69 @@
 package services
 
 import (
+	"context"
+	"errors"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
 	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
 )
 
 type GalleryService interface {
-	Create(gallery models.Gallery) (models.Gallery, error)
-	FindAll(params utils.PaginationParams) ([]models.Gallery, int64, error)
-	FindByID(id uint) (models.Gallery, error)
-	Update(gallery models.Gallery) (models.Gallery, error)
-	Delete(id uint) error
-	FindAllActive() ([]models.Gallery, error)
+	Create(ctx context.Context, gallery models.Gallery) (models.Gallery, error)
+	FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Gallery, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Gallery, error)
+	FindBySlug(ctx context.Context, slug string) (models.Gallery, bool, error)
+	Update(ctx context.Context, gallery models.Gallery) (models.Gallery, error)
+	Delete(ctx context.Context, id uint) error
+	FindAllActive(ctx context.Context) ([]models.Gallery, error)
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type galleryService struct {
-	galleryRepo repositories.GalleryRepository
+	galleryRepo    repositories.GalleryRepository
+	slugService    SlugService
+	webhookService WebhookService
 }
 
-func NewGalleryService(galleryRepo repositories.GalleryRepository) GalleryService {
+func NewGalleryService(galleryRepo repositories.GalleryRepository, slugService SlugService, webhookService WebhookService) GalleryService {
 	return &galleryService{
 		galleryRepo,
+		slugService,
+		webhookService,
 	}
 }
 
 // Create implements GalleryService.
-func (s *galleryService) Create(gallery models.Gallery) (models.Gallery, error) {
-	result, err := s.galleryRepo.Create(gallery)
+func (s *galleryService) Create(ctx context.Context, gallery models.Gallery) (models.Gallery, error) {
+	ctx, span := tracing.Start(ctx, "GalleryService.Create")
+	defer span.End()
 
+	slug, err := s.slugService.Prepare(ctx, SlugEntityGallery, gallery.Slug, gallery.Title, 0)
 	if err != nil {
 		return models.Gallery{}, err
 	}
+	gallery.Slug = slug
+
+	result, err := s.galleryRepo.Create(ctx, gallery)
+
+	if err != nil {
+		return models.Gallery{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityGallery, ContentActionCreated, result.ID)
 
 	return result, nil
 }
 
 // FindAll implements GalleryService.
-func (s *galleryService) FindAll(params utils.PaginationParams) ([]models.Gallery, int64, error) {
-	data, total, err := s.galleryRepo.FindAll(params)
+func (s *galleryService) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Gallery, int64, error) {
+	ctx, span := tracing.Start(ctx, "GalleryService.FindAll")
+	defer span.End()
+
+	data, total, err := s.galleryRepo.FindAll(ctx, params)
 
 	if err != nil {
 		return []models.Gallery{}, 0, err
@@ -48,8 +73,11 @@ func (s *galleryService) FindAll(params utils.PaginationParams) ([]models.Galler
 }
 
 // FindByID implements GalleryService.
-func (s *galleryService) FindByID(id uint) (models.Gallery, error) {
-	data, err := s.galleryRepo.FindByID(id)
+func (s *galleryService) FindByID(ctx context.Context, id uint) (models.Gallery, error) {
+	ctx, span := tracing.Start(ctx, "GalleryService.FindByID")
+	defer span.End()
+
+	data, err := s.galleryRepo.FindByID(ctx, id)
 
 	if err != nil {
 		return models.Gallery{}, err
@@ -59,34 +87,109 @@ func (s *galleryService) FindByID(id uint) (models.Gallery, error) {
 }
 
 // Update implements GalleryService.
-func (s *galleryService) Update(gallery models.Gallery) (models.Gallery, error) {
-	data, err := s.galleryRepo.Update(gallery)
+// Slug kosong berarti slug lama dipertahankan, slug lama disimpan ke riwayat jika berubah
+func (s *galleryService) Update(ctx context.Context, gallery models.Gallery) (models.Gallery, error) {
+	ctx, span := tracing.Start(ctx, "GalleryService.Update")
+	defer span.End()
 
+	existing, err := s.galleryRepo.FindByID(ctx, gallery.ID)
 	if err != nil {
 		return models.Gallery{}, err
 	}
 
+	if gallery.Slug == "" {
+		gallery.Slug = existing.Slug
+	}
+	slug, err := s.slugService.Prepare(ctx, SlugEntityGallery, gallery.Slug, gallery.Title, gallery.ID)
+	if err != nil {
+		return models.Gallery{}, err
+	}
+	gallery.Slug = slug
+
+	data, err := s.galleryRepo.Update(ctx, gallery)
+
+	if err != nil {
+		return models.Gallery{}, err
+	}
+
+	if err := s.slugService.Track(ctx, SlugEntityGallery, gallery.ID, existing.Slug, gallery.Slug); err != nil {
+		return models.Gallery{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityGallery, ContentActionUpdated, data.ID)
+
 	return data, nil
 }
 
 // Delete implements GalleryService.
-func (s *galleryService) Delete(id uint) error {
-	err := s.galleryRepo.Delete(id)
+func (s *galleryService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "GalleryService.Delete")
+	defer span.End()
+
+	err := s.galleryRepo.Delete(ctx, id)
 
 	if err != nil {
 		return err
 	}
 
+	dispatchContentChange(s.webhookService, ContentEntityGallery, ContentActionDeleted, id)
+
 	return nil
 }
 
 // FindAllActive implements GalleryService.
-func (s *galleryService) FindAllActive() ([]models.Gallery, error) {
-	data, err := s.galleryRepo.FindAllActive()
+func (s *galleryService) FindAllActive(ctx context.Context) ([]models.Gallery, error) {
+	ctx, span := tracing.Start(ctx, "GalleryService.FindAllActive")
+	defer span.End()
+
+	data, err := s.galleryRepo.FindAllActive(ctx)
 
 	if err != nil {
 		return []models.Gallery{}, err
 	}
 
 	return data, nil
-}
\ No newline at end of file
+}
+
+// Reorder implements GalleryService.
+func (s *galleryService) Reorder(ctx context.Context, ids []uint) error {
+	ctx, span := tracing.Start(ctx, "GalleryService.Reorder")
+	defer span.End()
+
+	err := s.galleryRepo.Reorder(ctx, ids)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityGallery, ContentActionReordered, 0)
+
+	return nil
+}
+
+// FindBySlug implements GalleryService.
+// Jika slug sudah diganti, data dicari lewat riwayat slug dan redirected bernilai true
+func (s *galleryService) FindBySlug(ctx context.Context, slug string) (models.Gallery, bool, error) {
+	ctx, span := tracing.Start(ctx, "GalleryService.FindBySlug")
+	defer span.End()
+
+	data, err := s.galleryRepo.FindBySlug(ctx, slug)
+	if err == nil {
+		return data, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Gallery{}, false, err
+	}
+
+	id, err := s.slugService.Resolve(ctx, SlugEntityGallery, slug)
+	if err != nil {
+		return models.Gallery{}, false, err
+	}
+
+	data, err = s.galleryRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.Gallery{}, false, err
+	}
+
+	return data, true, nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/tech-azim/be-learnova/database/migrations"
+	"gorm.io/gorm"
+)
+
+// ErrShuttingDown aplikasi sedang graceful shutdown, load balancer harus berhenti mengirim request
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// HealthCheck hasil satu pemeriksaan readiness. Error hanya untuk log,
+// /readyz terbuka tanpa autentikasi sehingga detail error (misalnya dari database) tidak dikirim
+type HealthCheck struct {
+	Status string `json:"status"`
+	Error  error  `json:"-"`
+}
+
+type HealthService interface {
+	// Ready jalankan semua pemeriksaan, ok false jika salah satu gagal
+	Ready(ctx context.Context) (map[string]HealthCheck, bool)
+	// Drain tandai aplikasi sedang shutdown sehingga Ready selalu gagal
+	Drain()
+}
+
+type healthService struct {
+	db          *gorm.DB
+	migrator    *migrations.Migrator
+	storageDirs []string
+	draining    atomic.Bool
+}
+
+// NewHealthService storageDirs folder yang harus bisa ditulis (uploads, outbox email)
+func NewHealthService(db *gorm.DB, migrator *migrations.Migrator, storageDirs []string) HealthService {
+	return &healthService{
+		db:          db,
+		migrator:    migrator,
+		storageDirs: storageDirs,
+	}
+}
+
+// Drain implements HealthService.
+func (s *healthService) Drain() {
+	s.draining.Store(true)
+}
+
+// Ready implements HealthService.
+func (s *healthService) Ready(ctx context.Context) (map[string]HealthCheck, bool) {
+	checks := map[string]func(ctx context.Context) error{
+		"server":     s.checkServer,
+		"database":   s.checkDatabase,
+		"migrations": s.checkMigrations,
+		"storage":    s.checkStorage,
+	}
+
+	result := make(map[string]HealthCheck, len(checks))
+	ok := true
+	for name, check := range checks {
+		if err := check(ctx); err != nil {
+			result[name] = HealthCheck{Status: "fail", Error: err}
+			ok = false
+			continue
+		}
+		result[name] = HealthCheck{Status: "ok"}
+	}
+
+	return result, ok
+}
+
+func (s *healthService) checkServer(ctx context.Context) error {
+	if s.draining.Load() {
+		return ErrShuttingDown
+	}
+	return nil
+}
+
+func (s *healthService) checkDatabase(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (s *healthService) checkMigrations(ctx context.Context) error {
+	pending, err := s.migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d pending migrations", pending)
+	}
+	return nil
+}
+
+// checkStorage pastikan folder ada dan bisa ditulis dengan membuat file sementara
+func (s *healthService) checkStorage(ctx context.Context) error {
+	for _, dir := range s.storageDirs {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return err
+		}
+
+		file, err := os.CreateTemp(dir, ".readyz-*")
+		if err != nil {
+			return err
+		}
+		file.Close()
+		os.Remove(file.Name())
+	}
+	return nil
+}
@@ -1,43 +1,57 @@
 package services
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
 	"github.com/tech-azim/be-learnova/utils"
 )
 
 type HeroService interface {
-	Create(hero models.Hero) (models.Hero, error)
-	FindAll(params utils.PaginationParams) ([]models.Hero,int64, error)
-	FindByID(id uint) (models.Hero, error)
-	Update(hero models.Hero) (models.Hero, error)
-	Delete(id uint) error
+	Create(ctx context.Context, hero models.Hero) (models.Hero, error)
+	FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Hero,int64, error)
+	FindByID(ctx context.Context, id uint) (models.Hero, error)
+	Update(ctx context.Context, hero models.Hero) (models.Hero, error)
+	Delete(ctx context.Context, id uint) error
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type heroService struct {
-	heroRepo repositories.HeroRepository
+	heroRepo       repositories.HeroRepository
+	webhookService WebhookService
 }
 
-func NewHeroService(heroRepo repositories.HeroRepository) HeroService {
+func NewHeroService(heroRepo repositories.HeroRepository, webhookService WebhookService) HeroService {
 	return &heroService{
 		heroRepo,
+		webhookService,
 	}
 }
 
 // Create implements [HeroService].
-func (h *heroService) Create(hero models.Hero) (models.Hero, error) {
-	result, err := h.heroRepo.Create(hero)
+func (h *heroService) Create(ctx context.Context, hero models.Hero) (models.Hero, error) {
+	ctx, span := tracing.Start(ctx, "HeroService.Create")
+	defer span.End()
+
+	result, err := h.heroRepo.Create(ctx, hero)
 
 	if err != nil {
 		return models.Hero{}, err
 	}
 
+	dispatchContentChange(h.webhookService, ContentEntityHero, ContentActionCreated, result.ID)
+
 	return result, err
 }
 
 
-func (h *heroService) FindAll(params utils.PaginationParams) ([]models.Hero,int64, error) {
-	data,total, err := h.heroRepo.FindAll(params)
+func (h *heroService) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Hero,int64, error) {
+	ctx, span := tracing.Start(ctx, "HeroService.FindAll")
+	defer span.End()
+
+	data,total, err := h.heroRepo.FindAll(ctx, params)
 
 	if err != nil {
 		return []models.Hero{},0, err
@@ -45,8 +59,11 @@ func (h *heroService) FindAll(params utils.PaginationParams) ([]models.Hero,int6
 	return data,total, nil
 }
 
-func (h *heroService) FindByID(id uint) (models.Hero, error){
-	data, err := h.heroRepo.FindByID(id)
+func (h *heroService) FindByID(ctx context.Context, id uint) (models.Hero, error){
+	ctx, span := tracing.Start(ctx, "HeroService.FindByID")
+	defer span.End()
+
+	data, err := h.heroRepo.FindByID(ctx, id)
 
 	if err != nil {
 		return models.Hero{}, err
@@ -55,22 +72,48 @@ func (h *heroService) FindByID(id uint) (models.Hero, error){
 	return data, nil
 }
 
-func (h *heroService) Update(hero models.Hero) (models.Hero, error){
-	data, err := h.heroRepo.Update(hero)
+func (h *heroService) Update(ctx context.Context, hero models.Hero) (models.Hero, error){
+	ctx, span := tracing.Start(ctx, "HeroService.Update")
+	defer span.End()
+
+	data, err := h.heroRepo.Update(ctx, hero)
 
 	if err != nil {
 		return models.Hero{}, err
 	}
 
+	dispatchContentChange(h.webhookService, ContentEntityHero, ContentActionUpdated, data.ID)
+
 	return data, nil
 }
 
-func (h *heroService) Delete(id uint) error {
-	err := h.heroRepo.Delete(id)
+func (h *heroService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "HeroService.Delete")
+	defer span.End()
+
+	err := h.heroRepo.Delete(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(h.webhookService, ContentEntityHero, ContentActionDeleted, id)
+
+	return nil
+}
+
+// Reorder implements [HeroService].
+func (h *heroService) Reorder(ctx context.Context, ids []uint) error {
+	ctx, span := tracing.Start(ctx, "HeroService.Reorder")
+	defer span.End()
+
+	err := h.heroRepo.Reorder(ctx, ids)
 
 	if err != nil {
 		return err
 	}
 
+	dispatchContentChange(h.webhookService, ContentEntityHero, ContentActionReordered, 0)
+
 	return nil
-}
\ No newline at end of file
+}
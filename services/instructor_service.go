@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+type InstructorService interface {
+	Create(ctx context.Context, instructor models.Instructor) (models.Instructor, error)
+	FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Instructor, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Instructor, error)
+	Update(ctx context.Context, instructor models.Instructor) (models.Instructor, error)
+	Delete(ctx context.Context, id uint) error
+	FindByProgramID(ctx context.Context, programID uint) ([]models.ProgramInstructor, error)
+	SetProgramInstructors(ctx context.Context, programID uint, instructorIDs []uint) ([]models.ProgramInstructor, error)
+}
+
+type instructorService struct {
+	instructorRepo repositories.InstructorRepository
+	webhookService WebhookService
+}
+
+func NewInstructorService(instructorRepo repositories.InstructorRepository, webhookService WebhookService) InstructorService {
+	return &instructorService{
+		instructorRepo,
+		webhookService,
+	}
+}
+
+// Create implements InstructorService.
+func (s *instructorService) Create(ctx context.Context, instructor models.Instructor) (models.Instructor, error) {
+	ctx, span := tracing.Start(ctx, "InstructorService.Create")
+	defer span.End()
+
+	result, err := s.instructorRepo.Create(ctx, instructor)
+
+	if err != nil {
+		return models.Instructor{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityInstructor, ContentActionCreated, result.ID)
+
+	return result, nil
+}
+
+// FindAll implements InstructorService.
+func (s *instructorService) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Instructor, int64, error) {
+	ctx, span := tracing.Start(ctx, "InstructorService.FindAll")
+	defer span.End()
+
+	data, total, err := s.instructorRepo.FindAll(ctx, params)
+
+	if err != nil {
+		return []models.Instructor{}, 0, err
+	}
+
+	return data, total, nil
+}
+
+// FindByID implements InstructorService.
+func (s *instructorService) FindByID(ctx context.Context, id uint) (models.Instructor, error) {
+	ctx, span := tracing.Start(ctx, "InstructorService.FindByID")
+	defer span.End()
+
+	data, err := s.instructorRepo.FindByID(ctx, id)
+
+	if err != nil {
+		return models.Instructor{}, err
+	}
+
+	return data, nil
+}
+
+// Update implements InstructorService.
+func (s *instructorService) Update(ctx context.Context, instructor models.Instructor) (models.Instructor, error) {
+	ctx, span := tracing.Start(ctx, "InstructorService.Update")
+	defer span.End()
+
+	data, err := s.instructorRepo.Update(ctx, instructor)
+
+	if err != nil {
+		return models.Instructor{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityInstructor, ContentActionUpdated, data.ID)
+
+	return data, nil
+}
+
+// Delete implements InstructorService.
+func (s *instructorService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "InstructorService.Delete")
+	defer span.End()
+
+	err := s.instructorRepo.Delete(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityInstructor, ContentActionDeleted, id)
+
+	return nil
+}
+
+// FindByProgramID implements InstructorService.
+func (s *instructorService) FindByProgramID(ctx context.Context, programID uint) ([]models.ProgramInstructor, error) {
+	ctx, span := tracing.Start(ctx, "InstructorService.FindByProgramID")
+	defer span.End()
+
+	data, err := s.instructorRepo.FindByProgramID(ctx, programID)
+
+	if err != nil {
+		return []models.ProgramInstructor{}, err
+	}
+
+	return data, nil
+}
+
+// SetProgramInstructors implements InstructorService.
+// Return daftar instructor program setelah diperbarui
+func (s *instructorService) SetProgramInstructors(ctx context.Context, programID uint, instructorIDs []uint) ([]models.ProgramInstructor, error) {
+	ctx, span := tracing.Start(ctx, "InstructorService.SetProgramInstructors")
+	defer span.End()
+
+	if err := s.instructorRepo.SetProgramInstructors(ctx, programID, instructorIDs); err != nil {
+		return []models.ProgramInstructor{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityProgram, ContentActionUpdated, programID)
+
+	return s.FindByProgramID(ctx, programID)
+}
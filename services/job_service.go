@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/tech-azim/be-learnova/jobs"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+// ErrJobNotRetryable hanya job dead (dead-letter) yang bisa di-retry manual
+var ErrJobNotRetryable = repositories.ErrJobNotRetryable
+
+// JobOptions pengaturan job saat enqueue, field kosong memakai default
+type JobOptions struct {
+	Queue string
+	// RunAt waktu paling awal job boleh dijalankan (delayed/scheduled job)
+	RunAt       time.Time
+	MaxAttempts int
+}
+
+type JobService interface {
+	Enqueue(ctx context.Context, jobType string, payload any, opts JobOptions) (models.Job, error)
+	FindAll(params utils.PaginationParams, filter repositories.JobFilter) ([]models.Job, int64, error)
+	FindByID(id uint) (models.Job, error)
+	Retry(id uint) (models.Job, error)
+	Stats() (map[string]int64, error)
+}
+
+type jobService struct {
+	jobRepo repositories.JobRepository
+}
+
+func NewJobService(jobRepo repositories.JobRepository) JobService {
+	return &jobService{
+		jobRepo,
+	}
+}
+
+// Enqueue implements JobService.
+// Payload di-encode ke JSON, handler job membaca kembali dengan json.Unmarshal
+func (s *jobService) Enqueue(ctx context.Context, jobType string, payload any, opts JobOptions) (models.Job, error) {
+	ctx, span := tracing.Start(ctx, "JobService.Enqueue")
+	defer span.End()
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	job := models.Job{
+		Queue:       opts.Queue,
+		Type:        jobType,
+		Payload:     encoded,
+		Status:      models.JobStatusPending,
+		MaxAttempts: opts.MaxAttempts,
+		RunAt:       opts.RunAt,
+	}
+	if job.Queue == "" {
+		job.Queue = jobs.DefaultQueue
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = 5
+	}
+	if job.RunAt.IsZero() {
+		job.RunAt = time.Now()
+	}
+
+	return s.jobRepo.Create(ctx, job)
+}
+
+// FindAll implements JobService.
+func (s *jobService) FindAll(params utils.PaginationParams, filter repositories.JobFilter) ([]models.Job, int64, error) {
+	data, total, err := s.jobRepo.FindAll(params, filter)
+
+	if err != nil {
+		return []models.Job{}, 0, err
+	}
+
+	return data, total, nil
+}
+
+// FindByID implements JobService.
+func (s *jobService) FindByID(id uint) (models.Job, error) {
+	data, err := s.jobRepo.FindByID(id)
+
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	return data, nil
+}
+
+// Retry implements JobService.
+func (s *jobService) Retry(id uint) (models.Job, error) {
+	data, err := s.jobRepo.Retry(id)
+
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	return data, nil
+}
+
+// Stats implements JobService.
+func (s *jobService) Stats() (map[string]int64, error) {
+	return s.jobRepo.CountByStatus()
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/tech-azim/be-learnova/jobs"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/notifications"
+	"github.com/tech-azim/be-learnova/repositories"
+)
+
+// NotificationConfig pengirim, penerima admin dan identitas situs untuk email notifikasi
+type NotificationConfig struct {
+	From        string
+	AdminEmails []string
+	SiteName    string
+	// AdminURL base URL dashboard admin, dipakai untuk link detail registrasi (opsional)
+	AdminURL string
+}
+
+// JobTypeSendEmail job pengiriman email, payload berisi notifications.Message
+const JobTypeSendEmail = "email.send"
+
+// JobQueueMail queue khusus email supaya tidak tertahan job lain yang lambat
+const JobQueueMail = "mail"
+
+// NotificationService kirim email transaksional untuk event registrasi.
+// Email di-render lalu dimasukkan ke job queue, kegagalan tidak menggagalkan request.
+type NotificationService interface {
+	RegistrationCreated(registration models.Registration)
+	RegistrationStatusChanged(registration models.Registration, oldStatus string)
+	RegistrationReminder(ctx context.Context, registration models.Registration, daysLeft int, template *models.ReminderTemplate) error
+	RenderReminderTemplate(template models.ReminderTemplate, data ReminderEmailData) (notifications.Message, error)
+}
+
+type notificationService struct {
+	programRepo repositories.ProgramRepository
+	jobService  JobService
+	renderer    *notifications.Renderer
+	config      NotificationConfig
+	logger      *slog.Logger
+}
+
+func NewNotificationService(programRepo repositories.ProgramRepository, jobService JobService, renderer *notifications.Renderer, config NotificationConfig, logger *slog.Logger) NotificationService {
+	if config.SiteName == "" {
+		config.SiteName = "Learnova"
+	}
+	if config.From == "" {
+		config.From = config.SiteName + " <no-reply@localhost>"
+	}
+
+	return &notificationService{
+		programRepo,
+		jobService,
+		renderer,
+		config,
+		logger,
+	}
+}
+
+// registrationStatusLabels label status registrasi yang ditampilkan di email
+var registrationStatusLabels = map[string]string{
+	"pending":     "Menunggu konfirmasi",
+	"active":      "Aktif",
+	"completed":   "Selesai",
+	"cancelled":   "Dibatalkan",
+	"rejected":    "Ditolak",
+	"quarantined": "Dikarantina",
+}
+
+func registrationStatusLabel(status string) string {
+	if label, ok := registrationStatusLabels[status]; ok {
+		return label
+	}
+	return status
+}
+
+// registrationEmailData data yang tersedia di template email registrasi
+type registrationEmailData struct {
+	SiteName       string
+	Program        string
+	Registration   models.Registration
+	StatusLabel    string
+	OldStatusLabel string
+	AdminURL       string
+}
+
+// RegistrationCreated implements NotificationService.
+// Konfirmasi ke pendaftar dan alert ke admin (jika AdminEmails diisi)
+func (s *notificationService) RegistrationCreated(registration models.Registration) {
+	ctx := context.Background()
+	data := s.emailData(ctx, registration)
+	if s.config.AdminURL != "" {
+		data.AdminURL = fmt.Sprintf("%s/registrations/%d", strings.TrimRight(s.config.AdminURL, "/"), registration.ID)
+	}
+
+	s.send(ctx, notifications.TemplateRegistrationConfirmation, []string{registration.Email}, data)
+
+	if len(s.config.AdminEmails) > 0 {
+		s.send(ctx, notifications.TemplateAdminNewRegistration, s.config.AdminEmails, data)
+	}
+}
+
+// RegistrationStatusChanged implements NotificationService.
+func (s *notificationService) RegistrationStatusChanged(registration models.Registration, oldStatus string) {
+	if registration.Status == "" || registration.Status == oldStatus {
+		return
+	}
+
+	ctx := context.Background()
+	data := s.emailData(ctx, registration)
+	data.OldStatusLabel = registrationStatusLabel(oldStatus)
+
+	s.send(ctx, notifications.TemplateRegistrationStatus, []string{registration.Email}, data)
+}
+
+// ReminderEmailData placeholder yang tersedia di template reminder per program
+type ReminderEmailData struct {
+	SiteName     string
+	Name         string
+	Email        string
+	Company      string
+	Program      string
+	Date         string
+	DaysLeft     int
+	Participants int
+}
+
+// RegistrationReminder implements NotificationService.
+// template nil berarti memakai template bawaan
+func (s *notificationService) RegistrationReminder(ctx context.Context, registration models.Registration, daysLeft int, template *models.ReminderTemplate) error {
+	base := s.emailData(ctx, registration)
+	data := ReminderEmailData{
+		SiteName:     s.config.SiteName,
+		Name:         registration.Name,
+		Email:        registration.Email,
+		Company:      registration.Company,
+		Program:      base.Program,
+		Date:         registration.PreferredDate.Format("02 January 2006"),
+		DaysLeft:     daysLeft,
+		Participants: registration.Participants,
+	}
+
+	var msg notifications.Message
+	var err error
+	if template != nil {
+		msg, err = s.RenderReminderTemplate(*template, data)
+	} else {
+		msg, err = s.renderer.Render(notifications.TemplateRegistrationReminder, data)
+	}
+	if err != nil {
+		return err
+	}
+	msg.From = s.config.From
+	msg.To = []string{registration.Email}
+
+	_, err = s.jobService.Enqueue(ctx, JobTypeSendEmail, msg, JobOptions{Queue: JobQueueMail})
+	return err
+}
+
+// RenderReminderTemplate implements NotificationService.
+func (s *notificationService) RenderReminderTemplate(template models.ReminderTemplate, data ReminderEmailData) (notifications.Message, error) {
+	return s.renderer.RenderCustom(s.config.SiteName, template.Subject, template.Body, data)
+}
+
+// emailData isi data template, judul program diambil dari database jika belum di-preload
+func (s *notificationService) emailData(ctx context.Context, registration models.Registration) registrationEmailData {
+	program := registration.Program.Title
+	if program == "" {
+		if found, err := s.programRepo.FindByID(ctx, registration.ProgramID); err == nil {
+			program = found.Title
+		}
+	}
+
+	return registrationEmailData{
+		SiteName:     s.config.SiteName,
+		Program:      program,
+		Registration: registration,
+		StatusLabel:  registrationStatusLabel(registration.Status),
+	}
+}
+
+func (s *notificationService) send(ctx context.Context, template string, to []string, data registrationEmailData) {
+	msg, err := s.renderer.Render(template, data)
+	if err != nil {
+		s.logger.Error("failed to render email", "template", template, "registration_id", data.Registration.ID, "error", err)
+		return
+	}
+	msg.From = s.config.From
+	msg.To = to
+
+	if _, err := s.jobService.Enqueue(ctx, JobTypeSendEmail, msg, JobOptions{Queue: JobQueueMail}); err != nil {
+		s.logger.Error("failed to enqueue email", "template", template, "registration_id", data.Registration.ID, "error", err)
+	}
+}
+
+// SendEmailJobHandler handler job JobTypeSendEmail, retry & dead-letter diatur job worker
+func SendEmailJobHandler(mailer notifications.Mailer) jobs.Handler {
+	return func(ctx context.Context, job models.Job) error {
+		var msg notifications.Message
+		if err := json.Unmarshal(job.Payload, &msg); err != nil {
+			return jobs.Permanent(fmt.Errorf("invalid email payload: %w", err))
+		}
+
+		return mailer.Send(ctx, msg)
+	}
+}
@@ -1,43 +1,57 @@
 package services
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
 	"github.com/tech-azim/be-learnova/utils"
 )
 
 type PortfolioService interface {
-	Create(portfolio models.Portfolio) (models.Portfolio, error)
-	FindAll(params utils.PaginationParams) ([]models.Portfolio, int64, error)
-	FindByID(id uint) (models.Portfolio, error)
-	Update(portfolio models.Portfolio) (models.Portfolio, error)
-	Delete(id uint) error
+	Create(ctx context.Context, portfolio models.Portfolio) (models.Portfolio, error)
+	FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Portfolio, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Portfolio, error)
+	Update(ctx context.Context, portfolio models.Portfolio) (models.Portfolio, error)
+	Delete(ctx context.Context, id uint) error
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type portfolioService struct {
-	portfolioRepo repositories.PortfolioRepository
+	portfolioRepo  repositories.PortfolioRepository
+	webhookService WebhookService
 }
 
-func NewPortfolioService(portfolioRepo repositories.PortfolioRepository) PortfolioService {
+func NewPortfolioService(portfolioRepo repositories.PortfolioRepository, webhookService WebhookService) PortfolioService {
 	return &portfolioService{
 		portfolioRepo,
+		webhookService,
 	}
 }
 
 // Create implements PortfolioService.
-func (p *portfolioService) Create(portfolio models.Portfolio) (models.Portfolio, error) {
-	result, err := p.portfolioRepo.Create(portfolio)
+func (p *portfolioService) Create(ctx context.Context, portfolio models.Portfolio) (models.Portfolio, error) {
+	ctx, span := tracing.Start(ctx, "PortfolioService.Create")
+	defer span.End()
+
+	result, err := p.portfolioRepo.Create(ctx, portfolio)
 
 	if err != nil {
 		return models.Portfolio{}, err
 	}
 
+	dispatchContentChange(p.webhookService, ContentEntityPortfolio, ContentActionCreated, result.ID)
+
 	return result, nil
 }
 
 // FindAll implements PortfolioService.
-func (p *portfolioService) FindAll(params utils.PaginationParams) ([]models.Portfolio, int64, error) {
-	data, total, err := p.portfolioRepo.FindAll(params)
+func (p *portfolioService) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Portfolio, int64, error) {
+	ctx, span := tracing.Start(ctx, "PortfolioService.FindAll")
+	defer span.End()
+
+	data, total, err := p.portfolioRepo.FindAll(ctx, params)
 
 	if err != nil {
 		return []models.Portfolio{}, 0, err
@@ -47,8 +61,11 @@ func (p *portfolioService) FindAll(params utils.PaginationParams) ([]models.Port
 }
 
 // FindByID implements PortfolioService.
-func (p *portfolioService) FindByID(id uint) (models.Portfolio, error) {
-	data, err := p.portfolioRepo.FindByID(id)
+func (p *portfolioService) FindByID(ctx context.Context, id uint) (models.Portfolio, error) {
+	ctx, span := tracing.Start(ctx, "PortfolioService.FindByID")
+	defer span.End()
+
+	data, err := p.portfolioRepo.FindByID(ctx, id)
 
 	if err != nil {
 		return models.Portfolio{}, err
@@ -58,23 +75,49 @@ func (p *portfolioService) FindByID(id uint) (models.Portfolio, error) {
 }
 
 // Update implements PortfolioService.
-func (p *portfolioService) Update(portfolio models.Portfolio) (models.Portfolio, error) {
-	data, err := p.portfolioRepo.Update(portfolio)
+func (p *portfolioService) Update(ctx context.Context, portfolio models.Portfolio) (models.Portfolio, error) {
+	ctx, span := tracing.Start(ctx, "PortfolioService.Update")
+	defer span.End()
+
+	data, err := p.portfolioRepo.Update(ctx, portfolio)
 
 	if err != nil {
 		return models.Portfolio{}, err
 	}
 
+	dispatchContentChange(p.webhookService, ContentEntityPortfolio, ContentActionUpdated, data.ID)
+
 	return data, nil
 }
 
 // Delete implements PortfolioService.
-func (p *portfolioService) Delete(id uint) error {
-	err := p.portfolioRepo.Delete(id)
+func (p *portfolioService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "PortfolioService.Delete")
+	defer span.End()
+
+	err := p.portfolioRepo.Delete(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(p.webhookService, ContentEntityPortfolio, ContentActionDeleted, id)
+
+	return nil
+}
+
+// Reorder implements PortfolioService.
+func (p *portfolioService) Reorder(ctx context.Context, ids []uint) error {
+	ctx, span := tracing.Start(ctx, "PortfolioService.Reorder")
+	defer span.End()
+
+	err := p.portfolioRepo.Reorder(ctx, ids)
 
 	if err != nil {
 		return err
 	}
 
+	dispatchContentChange(p.webhookService, ContentEntityPortfolio, ContentActionReordered, 0)
+
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
+)
+
+type ProgramFAQService interface {
+	FindByProgramID(ctx context.Context, programID uint) ([]models.ProgramFAQ, error)
+	FindByID(ctx context.Context, programID uint, id uint) (models.ProgramFAQ, error)
+	Create(ctx context.Context, faq models.ProgramFAQ) (models.ProgramFAQ, error)
+	Update(ctx context.Context, faq models.ProgramFAQ) (models.ProgramFAQ, error)
+	Delete(ctx context.Context, id uint) error
+	Reorder(ctx context.Context, programID uint, ids []uint) error
+}
+
+type programFAQService struct {
+	programFAQRepo repositories.ProgramFAQRepository
+	webhookService WebhookService
+}
+
+func NewProgramFAQService(programFAQRepo repositories.ProgramFAQRepository, webhookService WebhookService) ProgramFAQService {
+	return &programFAQService{
+		programFAQRepo,
+		webhookService,
+	}
+}
+
+// FindByProgramID implements ProgramFAQService.
+func (s *programFAQService) FindByProgramID(ctx context.Context, programID uint) ([]models.ProgramFAQ, error) {
+	ctx, span := tracing.Start(ctx, "ProgramFAQService.FindByProgramID")
+	defer span.End()
+
+	data, err := s.programFAQRepo.FindByProgramID(ctx, programID)
+
+	if err != nil {
+		return []models.ProgramFAQ{}, err
+	}
+
+	return data, nil
+}
+
+// FindByID implements ProgramFAQService.
+func (s *programFAQService) FindByID(ctx context.Context, programID uint, id uint) (models.ProgramFAQ, error) {
+	ctx, span := tracing.Start(ctx, "ProgramFAQService.FindByID")
+	defer span.End()
+
+	data, err := s.programFAQRepo.FindByID(ctx, programID, id)
+
+	if err != nil {
+		return models.ProgramFAQ{}, err
+	}
+
+	return data, nil
+}
+
+// Create implements ProgramFAQService.
+func (s *programFAQService) Create(ctx context.Context, faq models.ProgramFAQ) (models.ProgramFAQ, error) {
+	ctx, span := tracing.Start(ctx, "ProgramFAQService.Create")
+	defer span.End()
+
+	result, err := s.programFAQRepo.Create(ctx, faq)
+
+	if err != nil {
+		return models.ProgramFAQ{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityProgramFAQ, ContentActionCreated, result.ID)
+
+	return result, nil
+}
+
+// Update implements ProgramFAQService.
+func (s *programFAQService) Update(ctx context.Context, faq models.ProgramFAQ) (models.ProgramFAQ, error) {
+	ctx, span := tracing.Start(ctx, "ProgramFAQService.Update")
+	defer span.End()
+
+	data, err := s.programFAQRepo.Update(ctx, faq)
+
+	if err != nil {
+		return models.ProgramFAQ{}, err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityProgramFAQ, ContentActionUpdated, data.ID)
+
+	return data, nil
+}
+
+// Delete implements ProgramFAQService.
+func (s *programFAQService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "ProgramFAQService.Delete")
+	defer span.End()
+
+	err := s.programFAQRepo.Delete(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityProgramFAQ, ContentActionDeleted, id)
+
+	return nil
+}
+
+// Reorder implements ProgramFAQService.
+func (s *programFAQService) Reorder(ctx context.Context, programID uint, ids []uint) error {
+	ctx, span := tracing.Start(ctx, "ProgramFAQService.Reorder")
+	defer span.End()
+
+	err := s.programFAQRepo.Reorder(ctx, programID, ids)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityProgramFAQ, ContentActionReordered, 0)
+
+	return nil
+}
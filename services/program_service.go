@@ -1,43 +1,69 @@
 package services
 
 import (
+	"context"
+	"errors"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
 	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
 )
 
 type ProgramService interface {
-	Create(program models.Program) (models.Program, error)
-	FindAll(params utils.PaginationParams) ([]models.Program, int64, error)
-	FindByID(id uint) (models.Program, error)
-	Update(program models.Program) (models.Program, error)
-	Delete(id uint) error
+	Create(ctx context.Context, program models.Program) (models.Program, error)
+	FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Program, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Program, error)
+	FindDetailByID(ctx context.Context, id uint) (models.Program, error)
+	FindBySlug(ctx context.Context, slug string) (models.Program, bool, error)
+	Update(ctx context.Context, program models.Program) (models.Program, error)
+	Delete(ctx context.Context, id uint) error
 }
 
 type programService struct {
-	programRepo repositories.ProgramRepository
+	programRepo    repositories.ProgramRepository
+	slugService    SlugService
+	webhookService WebhookService
 }
 
-func NewProgramService(programRepo repositories.ProgramRepository) ProgramService {
+func NewProgramService(programRepo repositories.ProgramRepository, slugService SlugService, webhookService WebhookService) ProgramService {
 	return &programService{
 		programRepo,
+		slugService,
+		webhookService,
 	}
 }
 
 // Create implements ProgramService.
-func (p *programService) Create(program models.Program) (models.Program, error) {
-	result, err := p.programRepo.Create(program)
+func (p *programService) Create(ctx context.Context, program models.Program) (models.Program, error) {
+	ctx, span := tracing.Start(ctx, "ProgramService.Create")
+	defer span.End()
+
+	slug, err := p.slugService.Prepare(ctx, SlugEntityProgram, program.Slug, program.Title, 0)
+	if err != nil {
+		return models.Program{}, err
+	}
+	program.Slug = slug
+
+	result, err := p.programRepo.Create(ctx, program)
 
 	if err != nil {
 		return models.Program{}, err
 	}
 
+	p.webhookService.Dispatch(WebhookEventProgramCreated, result)
+	dispatchContentChange(p.webhookService, ContentEntityProgram, ContentActionCreated, result.ID)
+
 	return result, nil
 }
 
 // FindAll implements ProgramService.
-func (p *programService) FindAll(params utils.PaginationParams) ([]models.Program, int64, error) {
-	data, total, err := p.programRepo.FindAll(params)
+func (p *programService) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Program, int64, error) {
+	ctx, span := tracing.Start(ctx, "ProgramService.FindAll")
+	defer span.End()
+
+	data, total, err := p.programRepo.FindAll(ctx, params)
 
 	if err != nil {
 		return []models.Program{}, 0, err
@@ -47,8 +73,11 @@ func (p *programService) FindAll(params utils.PaginationParams) ([]models.Progra
 }
 
 // FindByID implements ProgramService.
-func (p *programService) FindByID(id uint) (models.Program, error) {
-	data, err := p.programRepo.FindByID(id)
+func (p *programService) FindByID(ctx context.Context, id uint) (models.Program, error) {
+	ctx, span := tracing.Start(ctx, "ProgramService.FindByID")
+	defer span.End()
+
+	data, err := p.programRepo.FindByID(ctx, id)
 
 	if err != nil {
 		return models.Program{}, err
@@ -58,23 +87,99 @@ func (p *programService) FindByID(id uint) (models.Program, error) {
 }
 
 // Update implements ProgramService.
-func (p *programService) Update(program models.Program) (models.Program, error) {
-	data, err := p.programRepo.Update(program)
+// Slug kosong berarti slug lama dipertahankan, slug lama disimpan ke riwayat jika berubah
+func (p *programService) Update(ctx context.Context, program models.Program) (models.Program, error) {
+	ctx, span := tracing.Start(ctx, "ProgramService.Update")
+	defer span.End()
 
+	existing, err := p.programRepo.FindByID(ctx, program.ID)
 	if err != nil {
 		return models.Program{}, err
 	}
 
+	if program.Slug == "" {
+		program.Slug = existing.Slug
+	}
+	slug, err := p.slugService.Prepare(ctx, SlugEntityProgram, program.Slug, program.Title, program.ID)
+	if err != nil {
+		return models.Program{}, err
+	}
+	program.Slug = slug
+
+	data, err := p.programRepo.Update(ctx, program)
+
+	if err != nil {
+		return models.Program{}, err
+	}
+
+	if err := p.slugService.Track(ctx, SlugEntityProgram, program.ID, existing.Slug, program.Slug); err != nil {
+		return models.Program{}, err
+	}
+
+	p.webhookService.Dispatch(WebhookEventProgramUpdated, data)
+	dispatchContentChange(p.webhookService, ContentEntityProgram, ContentActionUpdated, data.ID)
+
 	return data, nil
 }
 
 // Delete implements ProgramService.
-func (p *programService) Delete(id uint) error {
-	err := p.programRepo.Delete(id)
+func (p *programService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "ProgramService.Delete")
+	defer span.End()
+
+	err := p.programRepo.Delete(ctx, id)
 
 	if err != nil {
 		return err
 	}
 
+	p.webhookService.Dispatch(WebhookEventProgramDeleted, map[string]uint{"id": id})
+	dispatchContentChange(p.webhookService, ContentEntityProgram, ContentActionDeleted, id)
+
 	return nil
 }
+
+// FindDetailByID implements ProgramService.
+func (p *programService) FindDetailByID(ctx context.Context, id uint) (models.Program, error) {
+	ctx, span := tracing.Start(ctx, "ProgramService.FindDetailByID")
+	defer span.End()
+
+	data, err := p.programRepo.FindDetailByID(ctx, id)
+
+	if err != nil {
+		return models.Program{}, err
+	}
+
+	return data, nil
+}
+
+// FindBySlug implements ProgramService.
+// Jika slug sudah diganti, program dicari lewat riwayat slug dan redirected bernilai true
+func (p *programService) FindBySlug(ctx context.Context, slug string) (models.Program, bool, error) {
+	ctx, span := tracing.Start(ctx, "ProgramService.FindBySlug")
+	defer span.End()
+
+	data, err := p.programRepo.FindDetailBySlug(ctx, slug)
+	if err == nil {
+		return data, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Program{}, false, err
+	}
+
+	// Slug tanpa riwayat tetap dilaporkan sebagai program not found
+	id, resolveErr := p.slugService.Resolve(ctx, SlugEntityProgram, slug)
+	if errors.Is(resolveErr, gorm.ErrRecordNotFound) {
+		return models.Program{}, false, err
+	}
+	if resolveErr != nil {
+		return models.Program{}, false, resolveErr
+	}
+
+	data, err = p.programRepo.FindDetailByID(ctx, id)
+	if err != nil {
+		return models.Program{}, false, err
+	}
+
+	return data, true, nil
+}
@@ -1,46 +1,86 @@
 package services
 
 import (
+	"context"
+
+	"github.com/tech-azim/be-learnova/apperrors"
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
 	"github.com/tech-azim/be-learnova/utils"
 )
 
+var (
+	// ErrRegistrationExists email sudah terdaftar di program yang sama
+	ErrRegistrationExists = repositories.ErrRegistrationExists
+	// ErrProgramFull jumlah peserta melebihi kapasitas program
+	ErrProgramFull = repositories.ErrProgramFull
+	// ErrAttendeeCountMismatch jumlah attendee harus sama dengan participants
+	ErrAttendeeCountMismatch = apperrors.Validation("attendee_count_mismatch", "attendee count must match participants", nil)
+)
+
 type RegistrationService interface {
-	Create(registration models.Registration) (models.Registration, error)
-	FindAll(params utils.PaginationParams) ([]models.Registration, int64, error)
-	FindByID(id uint) (models.Registration, error)
-	FindByProgramID(programID uint, params utils.PaginationParams) ([]models.Registration, int64, error)
-	FindByEmail(email string) (models.Registration, error)
-	Update(registration models.Registration) (models.Registration, error)
-	Delete(id uint) error
-	CheckEmailExists(email string, programID uint) (bool, error)
+	Create(ctx context.Context, registration models.Registration) (models.Registration, error)
+	FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Registration, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Registration, error)
+	FindByProgramID(ctx context.Context, programID uint, params utils.PaginationParams) ([]models.Registration, int64, error)
+	FindByEmail(ctx context.Context, email string) ([]models.Registration, error)
+	Update(ctx context.Context, registration models.Registration) (models.Registration, error)
+	Delete(ctx context.Context, id uint) error
+	CheckEmailExists(ctx context.Context, email string, programID uint) (bool, error)
 }
 
 type registrationService struct {
-	registrationRepo repositories.RegistrationRepository
+	registrationRepo    repositories.RegistrationRepository
+	notificationService NotificationService
+	webhookService      WebhookService
 }
 
-func NewRegistrationService(registrationRepo repositories.RegistrationRepository) RegistrationService {
+func NewRegistrationService(registrationRepo repositories.RegistrationRepository, notificationService NotificationService, webhookService WebhookService) RegistrationService {
 	return &registrationService{
 		registrationRepo,
+		notificationService,
+		webhookService,
 	}
 }
 
 // Create implements RegistrationService.
-func (s *registrationService) Create(registration models.Registration) (models.Registration, error) {
-	result, err := s.registrationRepo.Create(registration)
+// Attendee bersifat opsional, tapi jika diisi jumlahnya harus sama dengan participants
+func (s *registrationService) Create(ctx context.Context, registration models.Registration) (models.Registration, error) {
+	ctx, span := tracing.Start(ctx, "RegistrationService.Create")
+	defer span.End()
+
+	if len(registration.Attendees) > 0 && len(registration.Attendees) != registration.Participants {
+		return models.Registration{}, ErrAttendeeCountMismatch
+	}
+	registration.Email = utils.NormalizeEmail(registration.Email)
+	quarantined := registration.Status == RegistrationStatusQuarantined
+
+	// Hubungkan ke contact yang sama (email/phone) atau buat contact baru di transaksi registrasi.
+	// Registrasi yang dikarantina baru dihubungkan saat direview supaya CRM tidak terisi spam
+	result, err := s.registrationRepo.Create(ctx, registration, !quarantined)
 
 	if err != nil {
 		return models.Registration{}, err
 	}
 
+	if quarantined {
+		return result, nil
+	}
+
+	// Email konfirmasi & alert admin dikirim di background
+	s.notificationService.RegistrationCreated(result)
+	s.webhookService.Dispatch(WebhookEventRegistrationCreated, result)
+
 	return result, nil
 }
 
 // FindAll implements RegistrationService.
-func (s *registrationService) FindAll(params utils.PaginationParams) ([]models.Registration, int64, error) {
-	data, total, err := s.registrationRepo.FindAll(params)
+func (s *registrationService) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Registration, int64, error) {
+	ctx, span := tracing.Start(ctx, "RegistrationService.FindAll")
+	defer span.End()
+
+	data, total, err := s.registrationRepo.FindAll(ctx, params)
 
 	if err != nil {
 		return []models.Registration{}, 0, err
@@ -50,8 +90,11 @@ func (s *registrationService) FindAll(params utils.PaginationParams) ([]models.R
 }
 
 // FindByID implements RegistrationService.
-func (s *registrationService) FindByID(id uint) (models.Registration, error) {
-	data, err := s.registrationRepo.FindByID(id)
+func (s *registrationService) FindByID(ctx context.Context, id uint) (models.Registration, error) {
+	ctx, span := tracing.Start(ctx, "RegistrationService.FindByID")
+	defer span.End()
+
+	data, err := s.registrationRepo.FindByID(ctx, id)
 
 	if err != nil {
 		return models.Registration{}, err
@@ -61,8 +104,11 @@ func (s *registrationService) FindByID(id uint) (models.Registration, error) {
 }
 
 // FindByProgramID implements RegistrationService.
-func (s *registrationService) FindByProgramID(programID uint, params utils.PaginationParams) ([]models.Registration, int64, error) {
-	data, total, err := s.registrationRepo.FindByProgramID(programID, params)
+func (s *registrationService) FindByProgramID(ctx context.Context, programID uint, params utils.PaginationParams) ([]models.Registration, int64, error) {
+	ctx, span := tracing.Start(ctx, "RegistrationService.FindByProgramID")
+	defer span.End()
+
+	data, total, err := s.registrationRepo.FindByProgramID(ctx, programID, params)
 
 	if err != nil {
 		return []models.Registration{}, 0, err
@@ -72,41 +118,93 @@ func (s *registrationService) FindByProgramID(programID uint, params utils.Pagin
 }
 
 // FindByEmail implements RegistrationService.
-func (s *registrationService) FindByEmail(email string) (models.Registration, error) {
-	data, err := s.registrationRepo.FindByEmail(email)
+func (s *registrationService) FindByEmail(ctx context.Context, email string) ([]models.Registration, error) {
+	ctx, span := tracing.Start(ctx, "RegistrationService.FindByEmail")
+	defer span.End()
+
+	data, err := s.registrationRepo.FindByEmail(ctx, utils.NormalizeEmail(email))
 
 	if err != nil {
-		return models.Registration{}, err
+		return []models.Registration{}, err
 	}
 
 	return data, nil
 }
 
 // Update implements RegistrationService.
-func (s *registrationService) Update(registration models.Registration) (models.Registration, error) {
-	data, err := s.registrationRepo.Update(registration)
+// Jika registrasi sudah punya attendee, participants tidak boleh berbeda dari jumlah attendee
+// (ubah lewat endpoint attendees)
+func (s *registrationService) Update(ctx context.Context, registration models.Registration) (models.Registration, error) {
+	ctx, span := tracing.Start(ctx, "RegistrationService.Update")
+	defer span.End()
+
+	existing, err := s.registrationRepo.FindByID(ctx, registration.ID)
+	if err != nil {
+		return models.Registration{}, err
+	}
+	if len(existing.Attendees) > 0 && len(existing.Attendees) != registration.Participants {
+		return models.Registration{}, ErrAttendeeCountMismatch
+	}
+
+	registration.Email = utils.NormalizeEmail(registration.Email)
+
+	// Contact yang sudah terhubung (termasuk hasil merge admin) tidak dicocokkan ulang,
+	// registrasi tanpa contact (baru dilepas dari karantina) dihubungkan sekarang
+	registration.ContactID = existing.ContactID
+	withContact := registration.ContactID == nil && registration.Status != RegistrationStatusQuarantined
+
+	data, err := s.registrationRepo.Update(ctx, registration, withContact)
 
 	if err != nil {
 		return models.Registration{}, err
 	}
 
+	// Registrasi yang dilepas dari karantina diperlakukan seperti registrasi baru
+	if existing.Status == RegistrationStatusQuarantined && data.Status != RegistrationStatusQuarantined {
+		data.Program = existing.Program
+		s.notificationService.RegistrationCreated(data)
+		s.webhookService.Dispatch(WebhookEventRegistrationCreated, data)
+		return data, nil
+	}
+	if data.Status == RegistrationStatusQuarantined {
+		return data, nil
+	}
+
+	s.webhookService.Dispatch(WebhookEventRegistrationUpdated, data)
+	if data.Status != existing.Status {
+		data.Program = existing.Program
+		s.notificationService.RegistrationStatusChanged(data, existing.Status)
+		s.webhookService.Dispatch(WebhookEventRegistrationStatusChanged, map[string]any{
+			"registration":    data,
+			"previous_status": existing.Status,
+		})
+	}
+
 	return data, nil
 }
 
 // Delete implements RegistrationService.
-func (s *registrationService) Delete(id uint) error {
-	err := s.registrationRepo.Delete(id)
+func (s *registrationService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "RegistrationService.Delete")
+	defer span.End()
+
+	err := s.registrationRepo.Delete(ctx, id)
 
 	if err != nil {
 		return err
 	}
 
+	s.webhookService.Dispatch(WebhookEventRegistrationDeleted, map[string]uint{"id": id})
+
 	return nil
 }
 
 // CheckEmailExists implements RegistrationService.
-func (s *registrationService) CheckEmailExists(email string, programID uint) (bool, error) {
-	exists, err := s.registrationRepo.CheckEmailExists(email, programID)
+func (s *registrationService) CheckEmailExists(ctx context.Context, email string, programID uint) (bool, error) {
+	ctx, span := tracing.Start(ctx, "RegistrationService.CheckEmailExists")
+	defer span.End()
+
+	exists, err := s.registrationRepo.CheckEmailExists(ctx, utils.NormalizeEmail(email), programID)
 
 	if err != nil {
 		return false, err
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/config"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
+)
+
+// ReminderMaxDays batas DaysBefore, juga rentang registrasi yang dicek scheduler
+const ReminderMaxDays = config.ReminderMaxDays
+
+var (
+	// ErrReminderTemplateExists program sudah punya template untuk jumlah hari yang sama
+	ErrReminderTemplateExists = repositories.ErrReminderTemplateExists
+	// ErrReminderTemplate subject/body bukan template yang valid
+	ErrReminderTemplate = apperrors.Validation("invalid_reminder_template", "invalid reminder template", nil)
+)
+
+// ReminderPlaceholders placeholder yang bisa dipakai di subject & body template reminder
+var ReminderPlaceholders = []string{
+	"{{.Name}}", "{{.Email}}", "{{.Company}}", "{{.Program}}",
+	"{{.Date}}", "{{.DaysLeft}}", "{{.Participants}}", "{{.SiteName}}",
+}
+
+type ReminderService interface {
+	DefaultDays() []int
+	FindTemplates(ctx context.Context, programID uint) ([]models.ReminderTemplate, error)
+	FindTemplateByID(ctx context.Context, programID uint, id uint) (models.ReminderTemplate, error)
+	CreateTemplate(ctx context.Context, template models.ReminderTemplate) (models.ReminderTemplate, error)
+	UpdateTemplate(ctx context.Context, template models.ReminderTemplate) (models.ReminderTemplate, error)
+	DeleteTemplate(ctx context.Context, programID uint, id uint) error
+	SendDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type reminderService struct {
+	reminderRepo        repositories.ReminderRepository
+	notificationService NotificationService
+	defaultDays         []int
+	logger              *slog.Logger
+}
+
+// NewReminderService defaultDays dipakai untuk program yang belum punya template reminder sendiri
+func NewReminderService(reminderRepo repositories.ReminderRepository, notificationService NotificationService, defaultDays []int, logger *slog.Logger) ReminderService {
+	return &reminderService{
+		reminderRepo,
+		notificationService,
+		defaultDays,
+		logger,
+	}
+}
+
+// DefaultDays implements ReminderService.
+func (s *reminderService) DefaultDays() []int {
+	return s.defaultDays
+}
+
+// FindTemplates implements ReminderService.
+func (s *reminderService) FindTemplates(ctx context.Context, programID uint) ([]models.ReminderTemplate, error) {
+	ctx, span := tracing.Start(ctx, "ReminderService.FindTemplates")
+	defer span.End()
+
+	data, err := s.reminderRepo.FindTemplatesByProgramID(ctx, programID)
+
+	if err != nil {
+		return []models.ReminderTemplate{}, err
+	}
+
+	return data, nil
+}
+
+// FindTemplateByID implements ReminderService.
+func (s *reminderService) FindTemplateByID(ctx context.Context, programID uint, id uint) (models.ReminderTemplate, error) {
+	ctx, span := tracing.Start(ctx, "ReminderService.FindTemplateByID")
+	defer span.End()
+
+	data, err := s.reminderRepo.FindTemplateByID(ctx, programID, id)
+
+	if err != nil {
+		return models.ReminderTemplate{}, err
+	}
+
+	return data, nil
+}
+
+// validate template di-render dengan data contoh supaya placeholder yang salah ketahuan saat disimpan
+func (s *reminderService) validate(template models.ReminderTemplate) error {
+	sample := ReminderEmailData{
+		SiteName:     "Learnova",
+		Name:         "Budi",
+		Email:        "budi@example.com",
+		Company:      "PT Contoh",
+		Program:      "Program",
+		Date:         time.Now().Format("02 January 2006"),
+		DaysLeft:     template.DaysBefore,
+		Participants: 1,
+	}
+
+	if _, err := s.notificationService.RenderReminderTemplate(template, sample); err != nil {
+		return fmt.Errorf("%w: %v", ErrReminderTemplate, err)
+	}
+	return nil
+}
+
+// CreateTemplate implements ReminderService.
+func (s *reminderService) CreateTemplate(ctx context.Context, template models.ReminderTemplate) (models.ReminderTemplate, error) {
+	ctx, span := tracing.Start(ctx, "ReminderService.CreateTemplate")
+	defer span.End()
+
+	if err := s.validate(template); err != nil {
+		return models.ReminderTemplate{}, err
+	}
+
+	data, err := s.reminderRepo.CreateTemplate(ctx, template)
+
+	if err != nil {
+		return models.ReminderTemplate{}, err
+	}
+
+	return data, nil
+}
+
+// UpdateTemplate implements ReminderService.
+func (s *reminderService) UpdateTemplate(ctx context.Context, template models.ReminderTemplate) (models.ReminderTemplate, error) {
+	ctx, span := tracing.Start(ctx, "ReminderService.UpdateTemplate")
+	defer span.End()
+
+	if err := s.validate(template); err != nil {
+		return models.ReminderTemplate{}, err
+	}
+
+	data, err := s.reminderRepo.UpdateTemplate(ctx, template)
+
+	if err != nil {
+		return models.ReminderTemplate{}, err
+	}
+
+	return data, nil
+}
+
+// DeleteTemplate implements ReminderService.
+func (s *reminderService) DeleteTemplate(ctx context.Context, programID uint, id uint) error {
+	ctx, span := tracing.Start(ctx, "ReminderService.DeleteTemplate")
+	defer span.End()
+
+	return s.reminderRepo.DeleteTemplate(ctx, programID, id)
+}
+
+// dateOnly tanggal (tanpa jam) dari t di zona waktunya sendiri, dinormalisasi ke UTC
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// SendDue implements ReminderService.
+// Untuk setiap registrasi terkonfirmasi dipilih jadwal reminder terkecil yang >= sisa hari,
+// jadi registrasi yang masuk mepet tetap menerima satu reminder. Reminder yang sudah tercatat
+// tidak dikirim ulang, termasuk setelah restart atau saat dijalankan di beberapa replica.
+func (s *reminderService) SendDue(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "ReminderService.SendDue")
+	defer span.End()
+
+	today := dateOnly(now)
+
+	registrations, err := s.reminderRepo.FindUpcomingRegistrations(ctx, today, today.AddDate(0, 0, ReminderMaxDays))
+	if err != nil {
+		return 0, err
+	}
+	if len(registrations) == 0 {
+		return 0, nil
+	}
+
+	programIDs := make([]uint, 0, len(registrations))
+	registrationIDs := make([]uint, 0, len(registrations))
+	for _, registration := range registrations {
+		programIDs = append(programIDs, registration.ProgramID)
+		registrationIDs = append(registrationIDs, registration.ID)
+	}
+
+	templates, err := s.reminderRepo.FindActiveTemplates(ctx, programIDs)
+	if err != nil {
+		return 0, err
+	}
+	templatesByProgram := make(map[uint]map[int]models.ReminderTemplate)
+	for _, template := range templates {
+		if templatesByProgram[template.ProgramID] == nil {
+			templatesByProgram[template.ProgramID] = make(map[int]models.ReminderTemplate)
+		}
+		templatesByProgram[template.ProgramID][template.DaysBefore] = template
+	}
+
+	sent, err := s.reminderRepo.FindSent(ctx, registrationIDs)
+	if err != nil {
+		return 0, err
+	}
+	sentKeys := make(map[string]bool, len(sent))
+	for _, reminder := range sent {
+		sentKeys[reminderKey(reminder.RegistrationID, reminder.DaysBefore, reminder.PreferredDate)] = true
+	}
+
+	count := 0
+	for _, registration := range registrations {
+		preferredDate := dateOnly(registration.PreferredDate)
+		daysLeft := int(preferredDate.Sub(today).Hours() / 24)
+
+		programTemplates := templatesByProgram[registration.ProgramID]
+		days := s.defaultDays
+		if len(programTemplates) > 0 {
+			days = make([]int, 0, len(programTemplates))
+			for day := range programTemplates {
+				days = append(days, day)
+			}
+		}
+
+		day, ok := nextReminderDay(days, daysLeft)
+		if !ok || sentKeys[reminderKey(registration.ID, day, preferredDate)] {
+			continue
+		}
+
+		reminder := models.RegistrationReminder{
+			RegistrationID: registration.ID,
+			DaysBefore:     day,
+			PreferredDate:  preferredDate,
+			SentAt:         now,
+		}
+		claimed, err := s.reminderRepo.Claim(ctx, reminder)
+		if err != nil {
+			return count, err
+		}
+		if !claimed {
+			continue
+		}
+
+		var template *models.ReminderTemplate
+		if programTemplate, ok := programTemplates[day]; ok {
+			template = &programTemplate
+		}
+
+		if err := s.notificationService.RegistrationReminder(ctx, registration, daysLeft, template); err != nil {
+			s.logger.ErrorContext(ctx, "failed to queue reminder", "registration_id", registration.ID, "error", err)
+			// Release tetap jalan walau ctx sudah dibatalkan, supaya reminder bisa dicoba lagi
+			if err := s.reminderRepo.Release(context.WithoutCancel(ctx), reminder); err != nil {
+				s.logger.ErrorContext(ctx, "failed to release reminder", "registration_id", registration.ID, "error", err)
+			}
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// nextReminderDay jadwal terkecil yang >= daysLeft
+func nextReminderDay(days []int, daysLeft int) (int, bool) {
+	if daysLeft < 0 {
+		return 0, false
+	}
+
+	sorted := append([]int(nil), days...)
+	sort.Ints(sorted)
+	for _, day := range sorted {
+		if day >= daysLeft {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+func reminderKey(registrationID uint, days int, preferredDate time.Time) string {
+	return fmt.Sprintf("%d:%d:%s", registrationID, days, dateOnly(preferredDate).Format(time.DateOnly))
+}
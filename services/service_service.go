@@ -1,43 +1,69 @@
 package services
 
 import (
+	"context"
+	"errors"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
 	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
 )
 
 type ServiceService interface {
-	Create(service models.Service) (models.Service, error)
-	FindAll(params utils.PaginationParams) ([]models.Service, int64, error)
-	FindByID(id uint) (models.Service, error)
-	Update(service models.Service) (models.Service, error)
-	Delete(id uint) error
+	Create(ctx context.Context, service models.Service) (models.Service, error)
+	FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Service, int64, error)
+	FindByID(ctx context.Context, id uint) (models.Service, error)
+	FindBySlug(ctx context.Context, slug string) (models.Service, bool, error)
+	Update(ctx context.Context, service models.Service) (models.Service, error)
+	Delete(ctx context.Context, id uint) error
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type serviceService struct {
-	serviceRepo repositories.ServiceRepository
+	serviceRepo    repositories.ServiceRepository
+	slugService    SlugService
+	webhookService WebhookService
 }
 
-func NewServiceService(serviceRepo repositories.ServiceRepository) ServiceService {
+func NewServiceService(serviceRepo repositories.ServiceRepository, slugService SlugService, webhookService WebhookService) ServiceService {
 	return &serviceService{
 		serviceRepo,
+		slugService,
+		webhookService,
 	}
 }
 
 // Create implements ServiceService.
-func (s *serviceService) Create(service models.Service) (models.Service, error) {
-	result, err := s.serviceRepo.Create(service)
+func (s *serviceService) Create(ctx context.Context, service models.Service) (models.Service, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.Create")
+	defer span.End()
+
+	slug, err := s.slugService.Prepare(ctx, SlugEntityService, service.Slug, service.Title, 0)
+	if err != nil {
+		return models.Service{}, err
+	}
+	service.Slug = slug
+
+	result, err := s.serviceRepo.Create(ctx, service)
 
 	if err != nil {
 		return models.Service{}, err
 	}
 
+	s.webhookService.Dispatch(WebhookEventServiceCreated, result)
+	dispatchContentChange(s.webhookService, ContentEntityService, ContentActionCreated, result.ID)
+
 	return result, nil
 }
 
 // FindAll implements ServiceService.
-func (s *serviceService) FindAll(params utils.PaginationParams) ([]models.Service, int64, error) {
-	data, total, err := s.serviceRepo.FindAll(params)
+func (s *serviceService) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.Service, int64, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.FindAll")
+	defer span.End()
+
+	data, total, err := s.serviceRepo.FindAll(ctx, params)
 
 	if err != nil {
 		return []models.Service{}, 0, err
@@ -47,8 +73,11 @@ func (s *serviceService) FindAll(params utils.PaginationParams) ([]models.Servic
 }
 
 // FindByID implements ServiceService.
-func (s *serviceService) FindByID(id uint) (models.Service, error) {
-	data, err := s.serviceRepo.FindByID(id)
+func (s *serviceService) FindByID(ctx context.Context, id uint) (models.Service, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.FindByID")
+	defer span.End()
+
+	data, err := s.serviceRepo.FindByID(ctx, id)
 
 	if err != nil {
 		return models.Service{}, err
@@ -58,23 +87,101 @@ func (s *serviceService) FindByID(id uint) (models.Service, error) {
 }
 
 // Update implements ServiceService.
-func (s *serviceService) Update(service models.Service) (models.Service, error) {
-	data, err := s.serviceRepo.Update(service)
+// Slug kosong berarti slug lama dipertahankan, slug lama disimpan ke riwayat jika berubah
+func (s *serviceService) Update(ctx context.Context, service models.Service) (models.Service, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.Update")
+	defer span.End()
+
+	existing, err := s.serviceRepo.FindByID(ctx, service.ID)
+	if err != nil {
+		return models.Service{}, err
+	}
+
+	if service.Slug == "" {
+		service.Slug = existing.Slug
+	}
+	slug, err := s.slugService.Prepare(ctx, SlugEntityService, service.Slug, service.Title, service.ID)
+	if err != nil {
+		return models.Service{}, err
+	}
+	service.Slug = slug
+
+	data, err := s.serviceRepo.Update(ctx, service)
 
 	if err != nil {
 		return models.Service{}, err
 	}
 
+	if err := s.slugService.Track(ctx, SlugEntityService, service.ID, existing.Slug, service.Slug); err != nil {
+		return models.Service{}, err
+	}
+
+	s.webhookService.Dispatch(WebhookEventServiceUpdated, data)
+	dispatchContentChange(s.webhookService, ContentEntityService, ContentActionUpdated, data.ID)
+
 	return data, nil
 }
 
 // Delete implements ServiceService.
-func (s *serviceService) Delete(id uint) error {
-	err := s.serviceRepo.Delete(id)
+func (s *serviceService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "ServiceService.Delete")
+	defer span.End()
+
+	err := s.serviceRepo.Delete(ctx, id)
 
 	if err != nil {
 		return err
 	}
 
+	s.webhookService.Dispatch(WebhookEventServiceDeleted, map[string]uint{"id": id})
+	dispatchContentChange(s.webhookService, ContentEntityService, ContentActionDeleted, id)
+
+	return nil
+}
+
+// Reorder implements ServiceService.
+func (s *serviceService) Reorder(ctx context.Context, ids []uint) error {
+	ctx, span := tracing.Start(ctx, "ServiceService.Reorder")
+	defer span.End()
+
+	err := s.serviceRepo.Reorder(ctx, ids)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityService, ContentActionReordered, 0)
+
 	return nil
-}
\ No newline at end of file
+}
+
+// FindBySlug implements ServiceService.
+// Jika slug sudah diganti, data dicari lewat riwayat slug dan redirected bernilai true
+func (s *serviceService) FindBySlug(ctx context.Context, slug string) (models.Service, bool, error) {
+	ctx, span := tracing.Start(ctx, "ServiceService.FindBySlug")
+	defer span.End()
+
+	data, err := s.serviceRepo.FindBySlug(ctx, slug)
+	if err == nil {
+		return data, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Service{}, false, err
+	}
+
+	// Slug tanpa riwayat tetap dilaporkan sebagai service not found
+	id, resolveErr := s.slugService.Resolve(ctx, SlugEntityService, slug)
+	if errors.Is(resolveErr, gorm.ErrRecordNotFound) {
+		return models.Service{}, false, err
+	}
+	if resolveErr != nil {
+		return models.Service{}, false, resolveErr
+	}
+
+	data, err = s.serviceRepo.FindByID(ctx, id)
+	if err != nil {
+		return models.Service{}, false, err
+	}
+
+	return data, true, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
+)
+
+type SitemapService interface {
+	BuildSitemap(ctx context.Context, baseURL string) ([]byte, error)
+	BuildRobots(baseURL string) string
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapService struct {
+	sitemapRepo repositories.SitemapRepository
+}
+
+func NewSitemapService(sitemapRepo repositories.SitemapRepository) SitemapService {
+	return &sitemapService{
+		sitemapRepo,
+	}
+}
+
+// BuildSitemap implements SitemapService.
+// URL halaman publik: {baseURL}/programs/{slug}, /services/{slug}, /galleries/{slug}
+// canonical_url dipakai jika diisi pada record
+func (s *sitemapService) BuildSitemap(ctx context.Context, baseURL string) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "SitemapService.BuildSitemap")
+	defer span.End()
+
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	sections := []struct {
+		path       string
+		changeFreq string
+		find       func(context.Context) ([]repositories.SitemapEntry, error)
+	}{
+		{"/programs/", "weekly", s.sitemapRepo.FindPrograms},
+		{"/services/", "monthly", s.sitemapRepo.FindServices},
+		{"/galleries/", "monthly", s.sitemapRepo.FindGalleries},
+	}
+
+	urlSet := sitemapURLSet{
+		XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		URLs:  []sitemapURL{{Loc: baseURL + "/", ChangeFreq: "daily"}},
+	}
+
+	for _, section := range sections {
+		entries, err := section.find(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			loc := entry.CanonicalURL
+			if loc == "" {
+				loc = baseURL + section.path + entry.Slug
+			}
+
+			item := sitemapURL{Loc: loc, ChangeFreq: section.changeFreq}
+			if !entry.UpdatedAt.IsZero() {
+				item.LastMod = entry.UpdatedAt.Format("2006-01-02")
+			}
+			urlSet.URLs = append(urlSet.URLs, item)
+		}
+	}
+
+	body, err := xml.MarshalIndent(urlSet, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+// BuildRobots implements SitemapService.
+func (s *sitemapService) BuildRobots(baseURL string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	return fmt.Sprintf("User-agent: *\nAllow: /\nDisallow: /api/\n\nSitemap: %s/sitemap.xml\n", baseURL)
+}
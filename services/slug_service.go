@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+// Nama tabel entity yang memakai slug
+const (
+	SlugEntityProgram = "programs"
+	SlugEntityService = "services"
+	SlugEntityGallery = "galleries"
+)
+
+var (
+	ErrSlugTaken   = apperrors.Conflict("slug_taken", "slug already in use")
+	ErrSlugInvalid = apperrors.Validation("invalid_slug", "slug is invalid", nil)
+)
+
+type SlugService interface {
+	Prepare(ctx context.Context, table string, requested string, source string, id uint) (string, error)
+	Track(ctx context.Context, table string, id uint, oldSlug string, newSlug string) error
+	Resolve(ctx context.Context, table string, slug string) (uint, error)
+}
+
+type slugService struct {
+	slugRepo repositories.SlugRepository
+}
+
+func NewSlugService(slugRepo repositories.SlugRepository) SlugService {
+	return &slugService{
+		slugRepo,
+	}
+}
+
+// Prepare implements SlugService.
+// - requested kosong: slug di-generate dari source (title), ditambah suffix -2, -3, ... jika bentrok
+// - requested diisi: slug dinormalisasi, ErrSlugTaken jika sudah dipakai record lain
+func (s *slugService) Prepare(ctx context.Context, table string, requested string, source string, id uint) (string, error) {
+	ctx, span := tracing.Start(ctx, "SlugService.Prepare")
+	defer span.End()
+
+	if requested != "" {
+		slug := utils.Slugify(requested)
+		if slug == "" {
+			return "", ErrSlugInvalid
+		}
+
+		taken, err := s.slugRepo.IsTaken(ctx, table, slug, id)
+		if err != nil {
+			return "", err
+		}
+		if taken {
+			return "", ErrSlugTaken
+		}
+		return slug, nil
+	}
+
+	base := utils.Slugify(source)
+	if base == "" {
+		base = "item"
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		taken, err := s.slugRepo.IsTaken(ctx, table, slug, id)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// Track implements SlugService.
+func (s *slugService) Track(ctx context.Context, table string, id uint, oldSlug string, newSlug string) error {
+	ctx, span := tracing.Start(ctx, "SlugService.Track")
+	defer span.End()
+
+	if oldSlug == newSlug {
+		return nil
+	}
+
+	return s.slugRepo.SaveChange(ctx, table, id, oldSlug, newSlug)
+}
+
+// Resolve implements SlugService.
+// Mencari ID record dari slug lama (riwayat redirect)
+func (s *slugService) Resolve(ctx context.Context, table string, slug string) (uint, error) {
+	ctx, span := tracing.Start(ctx, "SlugService.Resolve")
+	defer span.End()
+
+	redirect, err := s.slugRepo.FindRedirect(ctx, table, slug)
+	if err != nil {
+		return 0, err
+	}
+
+	return redirect.EntityID, nil
+}
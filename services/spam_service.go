@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/ratelimit"
+	"github.com/tech-azim/be-learnova/spam"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+var (
+	// ErrRateLimited terlalu banyak submission dari IP yang sama atau untuk email yang sama
+	ErrRateLimited = errors.New("too many submissions, please try again later")
+	// ErrDisposableEmail email memakai domain sekali pakai
+	ErrDisposableEmail = apperrors.Validation("disposable_email", "disposable email addresses are not allowed", nil)
+	// ErrCaptchaInvalid token CAPTCHA kosong atau tidak valid
+	ErrCaptchaInvalid = apperrors.Validation("captcha_invalid", "captcha verification failed", nil)
+)
+
+// RegistrationStatusQuarantined status registrasi yang ditahan karena terindikasi spam.
+// Tidak memakai kursi, tidak dikirimi email dan webhook sampai admin mengubah statusnya.
+const RegistrationStatusQuarantined = "quarantined"
+
+// Alasan karantina yang disimpan di Registration.SpamReason
+const (
+	SpamReasonHoneypot           = "honeypot"
+	SpamReasonCaptchaUnavailable = "captcha_unavailable"
+	SpamReasonTooManyLinks       = "too_many_links"
+)
+
+// SpamCheck data submission publik yang diperiksa
+type SpamCheck struct {
+	// IP client yang sudah diverifikasi (gin ClientIP dengan trusted proxies)
+	IP           string
+	Email        string
+	Honeypot     string
+	CaptchaToken string
+	Message      string
+}
+
+// SpamVerdict hasil pemeriksaan yang lolos (tidak ditolak). Quarantine berarti data tetap
+// disimpan dengan status quarantined untuk direview admin, bukan ditolak.
+type SpamVerdict struct {
+	Quarantine bool
+	Reason     string
+	// RetryAfter diisi saat ErrRateLimited
+	RetryAfter time.Duration
+}
+
+// SpamOptions batas submission
+type SpamOptions struct {
+	IPLimit ratelimit.Limit
+	// EmailLimit dihitung per email (sudah dinormalisasi), dari IP mana pun
+	EmailLimit ratelimit.Limit
+	// MaxLinks jumlah link di pesan sebelum dianggap mencurigakan
+	MaxLinks int
+}
+
+// DefaultSpamOptions 5 submission per 10 menit per IP, 3 per jam per email
+func DefaultSpamOptions() SpamOptions {
+	return SpamOptions{
+		IPLimit:    ratelimit.Limit{Requests: 5, Period: 10 * time.Minute},
+		EmailLimit: ratelimit.Limit{Requests: 3, Period: time.Hour},
+		MaxLinks:   2,
+	}
+}
+
+type SpamService interface {
+	CaptchaEnabled() bool
+	Check(ctx context.Context, input SpamCheck) (SpamVerdict, error)
+}
+
+type spamService struct {
+	store     ratelimit.Store
+	verifier  spam.CaptchaVerifier
+	blocklist *spam.DomainBlocklist
+	options   SpamOptions
+	logger    *slog.Logger
+}
+
+func NewSpamService(store ratelimit.Store, verifier spam.CaptchaVerifier, blocklist *spam.DomainBlocklist, options SpamOptions, logger *slog.Logger) SpamService {
+	return &spamService{
+		store,
+		verifier,
+		blocklist,
+		options,
+		logger,
+	}
+}
+
+var linkPattern = regexp.MustCompile(`(?i)https?://|www\.`)
+
+// CaptchaEnabled implements SpamService.
+func (s *spamService) CaptchaEnabled() bool {
+	return s.verifier.Enabled()
+}
+
+// Check implements SpamService.
+// Urutan: rate limit IP & email, domain disposable, CAPTCHA, lalu heuristik karantina
+func (s *spamService) Check(ctx context.Context, input SpamCheck) (SpamVerdict, error) {
+	email := utils.NormalizeEmail(input.Email)
+
+	limits := []struct {
+		key   string
+		limit ratelimit.Limit
+	}{
+		{"registration:" + ratelimit.ClientKey(input.IP), s.options.IPLimit},
+		// Limit per email dari IP mana pun, supaya submission dari banyak IP tidak bisa
+		// membanjiri inbox satu orang dengan email konfirmasi
+		{"registration:email:" + email, s.options.EmailLimit},
+	}
+	for _, l := range limits {
+		result, err := s.store.Take(ctx, l.key, l.limit)
+		if err != nil {
+			// Store bermasalah tidak boleh menghentikan pendaftaran
+			s.logger.ErrorContext(ctx, "registration rate limit store failed", "error", err)
+			continue
+		}
+		if !result.Allowed {
+			return SpamVerdict{RetryAfter: result.RetryAfter}, ErrRateLimited
+		}
+	}
+
+	// Bot biasanya mengisi semua field, termasuk field tersembunyi
+	if input.Honeypot != "" {
+		return SpamVerdict{Quarantine: true, Reason: SpamReasonHoneypot}, nil
+	}
+
+	if s.blocklist.IsDisposable(email) {
+		return SpamVerdict{}, ErrDisposableEmail
+	}
+
+	if s.verifier.Enabled() {
+		ok, err := s.verifier.Verify(ctx, input.CaptchaToken, input.IP)
+		if err != nil {
+			s.logger.WarnContext(ctx, "captcha verification failed, registration quarantined", "error", err)
+			return SpamVerdict{Quarantine: true, Reason: SpamReasonCaptchaUnavailable}, nil
+		}
+		if !ok {
+			return SpamVerdict{}, ErrCaptchaInvalid
+		}
+	}
+
+	if s.options.MaxLinks > 0 && len(linkPattern.FindAllStringIndex(input.Message, -1)) > s.options.MaxLinks {
+		return SpamVerdict{Quarantine: true, Reason: SpamReasonTooManyLinks}, nil
+	}
+
+	return SpamVerdict{}, nil
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/tech-azim/be-learnova/ratelimit"
+	"github.com/tech-azim/be-learnova/spam"
+)
+
+const testCaptchaToken = "valid-token"
+
+// failingStore store rate limit yang selalu error (misalnya Redis down)
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func newTestSpamService(store ratelimit.Store, verifier spam.CaptchaVerifier, options SpamOptions) SpamService {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewSpamService(store, verifier, spam.NewDomainBlocklist(nil), options, logger)
+}
+
+func validSpamCheck() SpamCheck {
+	return SpamCheck{
+		IP:           "203.0.113.10",
+		Email:        "budi@example.com",
+		CaptchaToken: testCaptchaToken,
+		Message:      "Saya tertarik dengan program ini",
+	}
+}
+
+func TestSpamServiceCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier spam.CaptchaVerifier
+		modify   func(*SpamCheck)
+		want     SpamVerdict
+		wantErr  error
+	}{
+		{
+			name:     "clean submission",
+			verifier: spam.FakeVerifier{ValidToken: testCaptchaToken},
+		},
+		{
+			name:     "honeypot filled",
+			verifier: spam.FakeVerifier{ValidToken: testCaptchaToken},
+			modify:   func(c *SpamCheck) { c.Honeypot = "http://spam.example" },
+			want:     SpamVerdict{Quarantine: true, Reason: SpamReasonHoneypot},
+		},
+		{
+			name:     "disposable email",
+			verifier: spam.FakeVerifier{ValidToken: testCaptchaToken},
+			modify:   func(c *SpamCheck) { c.Email = "bot@10MinuteMail.com" },
+			wantErr:  ErrDisposableEmail,
+		},
+		{
+			name:     "wrong captcha token",
+			verifier: spam.FakeVerifier{ValidToken: testCaptchaToken},
+			modify:   func(c *SpamCheck) { c.CaptchaToken = "wrong-token" },
+			wantErr:  ErrCaptchaInvalid,
+		},
+		{
+			name:     "missing captcha token",
+			verifier: spam.FakeVerifier{ValidToken: testCaptchaToken},
+			modify:   func(c *SpamCheck) { c.CaptchaToken = "" },
+			wantErr:  ErrCaptchaInvalid,
+		},
+		{
+			name:     "captcha provider down",
+			verifier: spam.FakeVerifier{ValidToken: testCaptchaToken, Err: spam.ErrCaptchaUnavailable},
+			want:     SpamVerdict{Quarantine: true, Reason: SpamReasonCaptchaUnavailable},
+		},
+		{
+			name:     "captcha disabled",
+			verifier: spam.DisabledVerifier{},
+			modify:   func(c *SpamCheck) { c.CaptchaToken = "" },
+		},
+		{
+			name:     "too many links",
+			verifier: spam.FakeVerifier{ValidToken: testCaptchaToken},
+			modify: func(c *SpamCheck) {
+				c.Message = "https://a.example https://b.example www.c.example"
+			},
+			want: SpamVerdict{Quarantine: true, Reason: SpamReasonTooManyLinks},
+		},
+		{
+			name:     "links within limit",
+			verifier: spam.FakeVerifier{ValidToken: testCaptchaToken},
+			modify:   func(c *SpamCheck) { c.Message = "Profil: https://a.example" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestSpamService(ratelimit.NewMemoryStore(), tt.verifier, DefaultSpamOptions())
+
+			input := validSpamCheck()
+			if tt.modify != nil {
+				tt.modify(&input)
+			}
+
+			got, err := service.Check(context.Background(), input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Check() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Check() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpamServiceCheckRateLimit(t *testing.T) {
+	options := DefaultSpamOptions()
+	options.IPLimit = ratelimit.Limit{Requests: 3, Period: time.Hour}
+	options.EmailLimit = ratelimit.Limit{Requests: 2, Period: time.Hour}
+
+	t.Run("per ip", func(t *testing.T) {
+		service := newTestSpamService(ratelimit.NewMemoryStore(), spam.DisabledVerifier{}, options)
+
+		emails := []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com"}
+		for i, email := range emails {
+			input := validSpamCheck()
+			input.Email = email
+
+			verdict, err := service.Check(context.Background(), input)
+			if i < 3 {
+				if err != nil {
+					t.Fatalf("submission %d: unexpected error %v", i+1, err)
+				}
+				continue
+			}
+			if !errors.Is(err, ErrRateLimited) {
+				t.Fatalf("submission %d: error = %v, want ErrRateLimited", i+1, err)
+			}
+			if verdict.RetryAfter <= 0 {
+				t.Errorf("submission %d: RetryAfter = %v, want > 0", i+1, verdict.RetryAfter)
+			}
+		}
+
+		other := validSpamCheck()
+		other.IP = "198.51.100.7"
+		if _, err := service.Check(context.Background(), other); err != nil {
+			t.Errorf("other ip: unexpected error %v", err)
+		}
+	})
+
+	t.Run("per email", func(t *testing.T) {
+		service := newTestSpamService(ratelimit.NewMemoryStore(), spam.DisabledVerifier{}, options)
+
+		// Email yang sama dari IP berbeda tetap dihitung di bucket yang sama
+		ips := []string{"203.0.113.10", "198.51.100.7"}
+		for i, ip := range ips {
+			input := validSpamCheck()
+			input.IP = ip
+			if _, err := service.Check(context.Background(), input); err != nil {
+				t.Fatalf("submission %d: unexpected error %v", i+1, err)
+			}
+		}
+
+		// Email sama dengan huruf berbeda tetap dihitung sebagai email yang sama
+		input := validSpamCheck()
+		input.IP = "192.0.2.44"
+		input.Email = "Budi@Example.com"
+		verdict, err := service.Check(context.Background(), input)
+		if !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("same email from third ip: error = %v, want ErrRateLimited", err)
+		}
+		if verdict.RetryAfter <= 0 {
+			t.Errorf("RetryAfter = %v, want > 0", verdict.RetryAfter)
+		}
+
+		// Email lain dari IP yang sama tidak ikut terblokir
+		input.Email = "siti@example.com"
+		if _, err := service.Check(context.Background(), input); err != nil {
+			t.Errorf("other email: unexpected error %v", err)
+		}
+	})
+
+	t.Run("ipv6 counted per /64", func(t *testing.T) {
+		service := newTestSpamService(ratelimit.NewMemoryStore(), spam.DisabledVerifier{}, options)
+
+		ips := []string{"2001:db8:1:1::1", "2001:db8:1:1::2", "2001:db8:1:1::3", "2001:db8:1:1::4"}
+		var err error
+		for i, ip := range ips {
+			input := validSpamCheck()
+			input.IP = ip
+			input.Email = ip + "@example.com"
+			if _, err = service.Check(context.Background(), input); i < 3 && err != nil {
+				t.Fatalf("submission %d: unexpected error %v", i+1, err)
+			}
+		}
+		if !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("error = %v, want ErrRateLimited", err)
+		}
+	})
+
+	t.Run("store failure fails open", func(t *testing.T) {
+		service := newTestSpamService(failingStore{}, spam.DisabledVerifier{}, options)
+
+		if _, err := service.Check(context.Background(), validSpamCheck()); err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
+
+func TestSpamServiceCaptchaEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier spam.CaptchaVerifier
+		want     bool
+	}{
+		{"fake verifier", spam.FakeVerifier{ValidToken: testCaptchaToken}, true},
+		{"disabled verifier", spam.DisabledVerifier{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestSpamService(ratelimit.NewMemoryStore(), tt.verifier, DefaultSpamOptions())
+			if got := service.CaptchaEnabled(); got != tt.want {
+				t.Errorf("CaptchaEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
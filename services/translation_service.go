@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
+	"github.com/tech-azim/be-learnova/utils"
+	"gorm.io/gorm"
+)
+
+// Nama entity yang bisa diterjemahkan, sama dengan path resource di API
+const (
+	TranslatableHero         = "heros"
+	TranslatableProgram      = "programs"
+	TranslatableService      = "services"
+	TranslatableFeature      = "features"
+	TranslatablePortfolio    = "portfolios"
+	TranslatableGallery      = "galleries"
+	TranslatableVideoGallery = "video-galleries"
+	TranslatableFlyerGallery = "flyer-galleries"
+	TranslatableGalleryAlbum = "gallery-albums"
+)
+
+var (
+	ErrTranslationEntity = apperrors.Validation("translation_entity_invalid", "entity is not translatable", nil)
+	ErrTranslationLocale = apperrors.Validation("translation_locale_invalid", "unsupported locale", nil)
+	ErrTranslationField  = apperrors.Validation("translation_field_invalid", "field is not translatable", nil)
+)
+
+// translatableEntity field yang bisa diterjemahkan per entity (nama field = json tag)
+type translatableEntity struct {
+	model      any
+	fields     []string
+	softDelete bool
+}
+
+var translatableEntities = map[string]translatableEntity{
+	TranslatableHero:         {&models.Hero{}, []string{"title", "description", "alt"}, false},
+	TranslatableProgram:      {&models.Program{}, []string{"title", "description", "duration", "participants", "level"}, true},
+	TranslatableService:      {&models.Service{}, []string{"title", "description"}, true},
+	TranslatableFeature:      {&models.Feature{}, []string{"title", "description"}, true},
+	TranslatablePortfolio:    {&models.Portfolio{}, []string{"title", "description"}, true},
+	TranslatableGallery:      {&models.Gallery{}, []string{"title", "description"}, true},
+	TranslatableVideoGallery: {&models.VideoGallery{}, []string{"title", "description", "category"}, true},
+	TranslatableFlyerGallery: {&models.FlyerGallery{}, []string{"title", "description"}, true},
+	TranslatableGalleryAlbum: {&models.GalleryAlbum{}, []string{"title", "description"}, true},
+}
+
+// MissingTranslation record yang terjemahannya belum lengkap untuk suatu locale
+type MissingTranslation struct {
+	EntityType    string   `json:"entity_type"`
+	EntityID      uint     `json:"entity_id"`
+	Title         string   `json:"title"`
+	MissingFields []string `json:"missing_fields"`
+}
+
+type TranslationService interface {
+	Localize(ctx context.Context, entityType string, locale string, data any) error
+	FindByEntity(ctx context.Context, entityType string, id uint) (map[string]map[string]string, error)
+	Save(ctx context.Context, entityType string, id uint, locale string, fields map[string]string) (map[string]string, error)
+	DeleteLocale(ctx context.Context, entityType string, id uint, locale string) error
+	FindMissing(ctx context.Context, entityType string, locale string) ([]MissingTranslation, error)
+	Fields(entityType string) ([]string, error)
+}
+
+type translationService struct {
+	translationRepo repositories.TranslationRepository
+	webhookService  WebhookService
+}
+
+func NewTranslationService(translationRepo repositories.TranslationRepository, webhookService WebhookService) TranslationService {
+	return &translationService{
+		translationRepo,
+		webhookService,
+	}
+}
+
+func lookupTranslatable(entityType string) (translatableEntity, error) {
+	entity, ok := translatableEntities[entityType]
+	if !ok {
+		return translatableEntity{}, fmt.Errorf("%w: %s", ErrTranslationEntity, entityType)
+	}
+	return entity, nil
+}
+
+// validateLocale locale terjemahan harus didukung dan bukan bahasa default
+func validateLocale(locale string) error {
+	if locale == utils.DefaultLocale || !utils.IsSupportedLocale(locale) {
+		return fmt.Errorf("%w: %s", ErrTranslationLocale, locale)
+	}
+	return nil
+}
+
+// Fields implements TranslationService.
+func (s *translationService) Fields(entityType string) ([]string, error) {
+	entity, err := lookupTranslatable(entityType)
+	if err != nil {
+		return nil, err
+	}
+	return entity.fields, nil
+}
+
+// Localize implements TranslationService.
+// data berupa pointer ke struct atau pointer ke slice struct. Field yang belum
+// diterjemahkan tetap memakai nilai bahasa default (fallback).
+func (s *translationService) Localize(ctx context.Context, entityType string, locale string, data any) error {
+	ctx, span := tracing.Start(ctx, "TranslationService.Localize")
+	defer span.End()
+
+	if locale == utils.DefaultLocale {
+		return nil
+	}
+
+	entity, err := lookupTranslatable(entityType)
+	if err != nil {
+		return err
+	}
+
+	items := collectLocalizable(reflect.ValueOf(data))
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, uint(item.FieldByName("ID").Uint()))
+	}
+
+	translations, err := s.translationRepo.FindByEntityIDs(ctx, entityType, locale, ids)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[uint]map[string]string)
+	for _, t := range translations {
+		if values[t.EntityID] == nil {
+			values[t.EntityID] = make(map[string]string)
+		}
+		values[t.EntityID][t.Field] = t.Value
+	}
+
+	for _, item := range items {
+		fields := values[uint(item.FieldByName("ID").Uint())]
+		if len(fields) == 0 {
+			continue
+		}
+		for _, field := range entity.fields {
+			if value, ok := fields[field]; ok {
+				setJSONField(item, field, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// collectLocalizable mengumpulkan struct yang bisa di-set dari pointer struct / slice
+func collectLocalizable(v reflect.Value) []reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.CanSet() && v.FieldByName("ID").IsValid() {
+			return []reflect.Value{v}
+		}
+	case reflect.Slice:
+		items := make([]reflect.Value, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, collectLocalizable(v.Index(i).Addr())...)
+		}
+		return items
+	}
+	return nil
+}
+
+// setJSONField set field string berdasarkan nama json tag
+func setJSONField(v reflect.Value, name string, value string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if tag == name && t.Field(i).Type.Kind() == reflect.String {
+			v.Field(i).SetString(value)
+			return
+		}
+	}
+}
+
+// FindByEntity implements TranslationService.
+// Return map locale -> field -> value
+func (s *translationService) FindByEntity(ctx context.Context, entityType string, id uint) (map[string]map[string]string, error) {
+	ctx, span := tracing.Start(ctx, "TranslationService.FindByEntity")
+	defer span.End()
+
+	entity, err := lookupTranslatable(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.translationRepo.EntityExists(ctx, entity.model, id, entity.softDelete)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	translations, err := s.translationRepo.FindByEntity(ctx, entityType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]map[string]string)
+	for _, t := range translations {
+		if result[t.Locale] == nil {
+			result[t.Locale] = make(map[string]string)
+		}
+		result[t.Locale][t.Field] = t.Value
+	}
+
+	return result, nil
+}
+
+// Save implements TranslationService.
+// Value kosong menghapus terjemahan field tersebut
+func (s *translationService) Save(ctx context.Context, entityType string, id uint, locale string, fields map[string]string) (map[string]string, error) {
+	ctx, span := tracing.Start(ctx, "TranslationService.Save")
+	defer span.End()
+
+	entity, err := lookupTranslatable(entityType)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLocale(locale); err != nil {
+		return nil, err
+	}
+
+	for field := range fields {
+		if !containsString(entity.fields, field) {
+			return nil, fmt.Errorf("%w: %s", ErrTranslationField, field)
+		}
+	}
+
+	exists, err := s.translationRepo.EntityExists(ctx, entity.model, id, entity.softDelete)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if err := s.translationRepo.Save(ctx, entityType, id, locale, fields); err != nil {
+		return nil, err
+	}
+
+	dispatchContentChange(s.webhookService, entityType, ContentActionTranslated, id)
+
+	all, err := s.FindByEntity(ctx, entityType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return all[locale], nil
+}
+
+// DeleteLocale implements TranslationService.
+func (s *translationService) DeleteLocale(ctx context.Context, entityType string, id uint, locale string) error {
+	ctx, span := tracing.Start(ctx, "TranslationService.DeleteLocale")
+	defer span.End()
+
+	if _, err := lookupTranslatable(entityType); err != nil {
+		return err
+	}
+	if err := validateLocale(locale); err != nil {
+		return err
+	}
+
+	if err := s.translationRepo.DeleteLocale(ctx, entityType, id, locale); err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, entityType, ContentActionTranslated, id)
+
+	return nil
+}
+
+// FindMissing implements TranslationService.
+// entityType kosong berarti semua entity. Field yang kosong di bahasa default tidak dihitung.
+func (s *translationService) FindMissing(ctx context.Context, entityType string, locale string) ([]MissingTranslation, error) {
+	ctx, span := tracing.Start(ctx, "TranslationService.FindMissing")
+	defer span.End()
+
+	if err := validateLocale(locale); err != nil {
+		return nil, err
+	}
+
+	entityTypes := []string{entityType}
+	if entityType == "" {
+		entityTypes = make([]string, 0, len(translatableEntities))
+		for name := range translatableEntities {
+			entityTypes = append(entityTypes, name)
+		}
+		sort.Strings(entityTypes)
+	}
+
+	result := []MissingTranslation{}
+	for _, name := range entityTypes {
+		entity, err := lookupTranslatable(name)
+		if err != nil {
+			return nil, err
+		}
+
+		rows, err := s.translationRepo.FindSourceValues(ctx, entity.model, entity.fields, entity.softDelete)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		ids := make([]uint, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, toUint(row["id"]))
+		}
+
+		translations, err := s.translationRepo.FindByEntityIDs(ctx, name, locale, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		translated := make(map[uint]map[string]bool)
+		for _, t := range translations {
+			if translated[t.EntityID] == nil {
+				translated[t.EntityID] = make(map[string]bool)
+			}
+			translated[t.EntityID][t.Field] = true
+		}
+
+		for _, row := range rows {
+			id := toUint(row["id"])
+
+			var missing []string
+			for _, field := range entity.fields {
+				source, _ := row[field].(string)
+				if source != "" && !translated[id][field] {
+					missing = append(missing, field)
+				}
+			}
+
+			if len(missing) > 0 {
+				title, _ := row["title"].(string)
+				result = append(result, MissingTranslation{
+					EntityType:    name,
+					EntityID:      id,
+					Title:         title,
+					MissingFields: missing,
+				})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// toUint konversi kolom id hasil scan map (int64/uint/dll) ke uint
+func toUint(value any) uint {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uint(v.Uint())
+	}
+	return 0
+}
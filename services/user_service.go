@@ -1,14 +1,25 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"log"
 
+	"github.com/tech-azim/be-learnova/apperrors"
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
 	"gorm.io/gorm"
 )
 
+var (
+	// ErrUserNotFound user dengan ID tersebut tidak ada
+	ErrUserNotFound = apperrors.NotFound("user_not_found", "user not found")
+	// ErrEmailRegistered email sudah dipakai user lain saat membuat user
+	ErrEmailRegistered = apperrors.Conflict("email_registered", "email already registered")
+	// ErrEmailTaken email sudah dipakai user lain saat update
+	ErrEmailTaken = apperrors.Conflict("email_taken", "email already used by another user")
+)
+
 type UpdateProfileInput struct {
 	Name     string `json:"name"     binding:"omitempty,min=3"`
 	Email    string `json:"email"    binding:"omitempty,email"`
@@ -18,14 +29,14 @@ type UpdateProfileInput struct {
 
 // UserService interface mendefinisikan business logic untuk User
 type UserService interface {
-	GetAllUsers() ([]models.User, error)
-	GetUserByID(id uint) (models.User, error)
-	CreateUser(input CreateUserInput) (models.User, error)
-	UpdateUser(id uint, input UpdateUserInput) (models.User, error)
-	DeleteUser(id uint) error
-
-	GetProfile(id uint) (models.User, error)
-	UpdateProfile(id uint, input UpdateProfileInput) (models.User, error)
+	GetAllUsers(ctx context.Context) ([]models.User, error)
+	GetUserByID(ctx context.Context, id uint) (models.User, error)
+	CreateUser(ctx context.Context, input CreateUserInput) (models.User, error)
+	UpdateUser(ctx context.Context, id uint, input UpdateUserInput) (models.User, error)
+	DeleteUser(ctx context.Context, id uint) error
+
+	GetProfile(ctx context.Context, id uint) (models.User, error)
+	UpdateProfile(ctx context.Context, id uint, input UpdateProfileInput) (models.User, error)
 }
 
 // CreateUserInput DTO untuk membuat user baru
@@ -54,17 +65,23 @@ func NewUserService(repo repositories.UserRepository) UserService {
 }
 
 // GetAllUsers mengambil semua user
-func (s *userService) GetAllUsers() ([]models.User, error) {
-	return s.repo.FindAll()
+func (s *userService) GetAllUsers(ctx context.Context) ([]models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetAllUsers")
+	defer span.End()
+
+	return s.repo.FindAll(ctx)
 }
 
 // GetUserByID mencari user berdasarkan ID
-// Return error "user not found" jika tidak ada
-func (s *userService) GetUserByID(id uint) (models.User, error) {
-	user, err := s.repo.FindByID(id)
+// Return ErrUserNotFound jika tidak ada
+func (s *userService) GetUserByID(ctx context.Context, id uint) (models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByID")
+	defer span.End()
+
+	user, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.User{}, errors.New("user not found")
+			return models.User{}, ErrUserNotFound
 		}
 		return models.User{}, err
 	}
@@ -72,11 +89,14 @@ func (s *userService) GetUserByID(id uint) (models.User, error) {
 }
 
 // CreateUser membuat user baru dengan validasi email unik
-func (s *userService) CreateUser(input CreateUserInput) (models.User, error) {
+func (s *userService) CreateUser(ctx context.Context, input CreateUserInput) (models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	defer span.End()
+
 	// Cek apakah email sudah digunakan
-	_, err := s.repo.FindByEmail(input.Email)
+	_, err := s.repo.FindByEmail(ctx, input.Email)
 	if err == nil {
-		return models.User{}, errors.New("email already registered")
+		return models.User{}, ErrEmailRegistered
 	}
 	if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return models.User{}, err
@@ -89,25 +109,28 @@ func (s *userService) CreateUser(input CreateUserInput) (models.User, error) {
 		Phone:    input.Phone,
 	}
 
-	return s.repo.Create(user)
+	return s.repo.Create(ctx, user)
 }
 
 // UpdateUser memperbarui data user berdasarkan ID
 // Validasi email unik jika email berubah
-func (s *userService) UpdateUser(id uint, input UpdateUserInput) (models.User, error) {
-	user, err := s.repo.FindByID(id)
+func (s *userService) UpdateUser(ctx context.Context, id uint, input UpdateUserInput) (models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUser")
+	defer span.End()
+
+	user, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.User{}, errors.New("user not found")
+			return models.User{}, ErrUserNotFound
 		}
 		return models.User{}, err
 	}
 
 	// Cek konflik email hanya jika email berubah
 	if input.Email != "" && input.Email != user.Email {
-		existing, err := s.repo.FindByEmail(input.Email)
+		existing, err := s.repo.FindByEmail(ctx, input.Email)
 		if err == nil && existing.ID != id {
-			return models.User{}, errors.New("email already used by another user")
+			return models.User{}, ErrEmailTaken
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return models.User{}, err
@@ -126,26 +149,32 @@ func (s *userService) UpdateUser(id uint, input UpdateUserInput) (models.User, e
 		user.Password = input.Password
 	}
 
-	return s.repo.Update(user)
+	return s.repo.Update(ctx, user)
 }
 
 // DeleteUser menghapus user berdasarkan ID
-func (s *userService) DeleteUser(id uint) error {
-	_, err := s.repo.FindByID(id)
+func (s *userService) DeleteUser(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser")
+	defer span.End()
+
+	_, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("user not found")
+			return ErrUserNotFound
 		}
 		return err
 	}
-	return s.repo.Delete(id)
+	return s.repo.Delete(ctx, id)
 }
 
-func (s *userService) GetProfile(id uint) (models.User, error) {
-	user, err := s.repo.FindByID(id)
+func (s *userService) GetProfile(ctx context.Context, id uint) (models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetProfile")
+	defer span.End()
+
+	user, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.User{}, errors.New("user not found")
+			return models.User{}, ErrUserNotFound
 		}
 		return models.User{}, err
 	}
@@ -154,19 +183,22 @@ func (s *userService) GetProfile(id uint) (models.User, error) {
 
 // UpdateProfile memperbarui data user yang sedang login
 // Validasi email unik hanya jika email berubah
-func (s *userService) UpdateProfile(id uint, input UpdateProfileInput) (models.User, error) {
-	user, err := s.repo.FindByID(id)
+func (s *userService) UpdateProfile(ctx context.Context, id uint, input UpdateProfileInput) (models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateProfile")
+	defer span.End()
+
+	user, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.User{}, errors.New("user not found")
+			return models.User{}, ErrUserNotFound
 		}
 		return models.User{}, err
 	}
 
 	if input.Email != "" && input.Email != user.Email {
-		existing, err := s.repo.FindByEmail(input.Email)
+		existing, err := s.repo.FindByEmail(ctx, input.Email)
 		if err == nil && existing.ID != id {
-			return models.User{}, errors.New("email already used by another user")
+			return models.User{}, ErrEmailTaken
 		}
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			return models.User{}, err
@@ -180,12 +212,10 @@ func (s *userService) UpdateProfile(id uint, input UpdateProfileInput) (models.U
 	if input.Phone != "" {
 		user.Phone = input.Phone
 	}
-	log.Printf("log passsowrd input %s", input.Password)
-	log.Printf("log passsowrd user %s", user.Password)
 
 	if input.Password != "" {
 		user.Password = input.Password
 	}
 
-	return s.repo.Update(user)
+	return s.repo.Update(ctx, user)
 }
@@ -1,46 +1,60 @@
 package services
 
 import (
+	"context"
+
 	"github.com/tech-azim/be-learnova/models"
 	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/tracing"
 	"github.com/tech-azim/be-learnova/utils"
 )
 
 type VideoGalleryService interface {
-	Create(videoGallery models.VideoGallery) (models.VideoGallery, error)
-	FindAll(params utils.PaginationParams) ([]models.VideoGallery, int64, error)
-	FindByID(id uint) (models.VideoGallery, error)
-	FindByCategory(category string, params utils.PaginationParams) ([]models.VideoGallery, int64, error)
-	Update(videoGallery models.VideoGallery) (models.VideoGallery, error)
-	Delete(id uint) error
-	FindAllActive() ([]models.VideoGallery, error)
-	FindAllCategories() ([]string, error)
+	Create(ctx context.Context, videoGallery models.VideoGallery) (models.VideoGallery, error)
+	FindAll(ctx context.Context, params utils.PaginationParams) ([]models.VideoGallery, int64, error)
+	FindByID(ctx context.Context, id uint) (models.VideoGallery, error)
+	FindByCategory(ctx context.Context, category string, params utils.PaginationParams) ([]models.VideoGallery, int64, error)
+	Update(ctx context.Context, videoGallery models.VideoGallery) (models.VideoGallery, error)
+	Delete(ctx context.Context, id uint) error
+	FindAllActive(ctx context.Context) ([]models.VideoGallery, error)
+	FindAllCategories(ctx context.Context) ([]string, error)
+	Reorder(ctx context.Context, ids []uint) error
 }
 
 type videoGalleryService struct {
 	videoGalleryRepo repositories.VideoGalleryRepository
+	webhookService   WebhookService
 }
 
-func NewVideoGalleryService(videoGalleryRepo repositories.VideoGalleryRepository) VideoGalleryService {
+func NewVideoGalleryService(videoGalleryRepo repositories.VideoGalleryRepository, webhookService WebhookService) VideoGalleryService {
 	return &videoGalleryService{
 		videoGalleryRepo,
+		webhookService,
 	}
 }
 
 // Create implements VideoGalleryService.
-func (s *videoGalleryService) Create(videoGallery models.VideoGallery) (models.VideoGallery, error) {
-	result, err := s.videoGalleryRepo.Create(videoGallery)
+func (s *videoGalleryService) Create(ctx context.Context, videoGallery models.VideoGallery) (models.VideoGallery, error) {
+	ctx, span := tracing.Start(ctx, "VideoGalleryService.Create")
+	defer span.End()
+
+	result, err := s.videoGalleryRepo.Create(ctx, videoGallery)
 
 	if err != nil {
 		return models.VideoGallery{}, err
 	}
 
+	dispatchContentChange(s.webhookService, ContentEntityVideoGallery, ContentActionCreated, result.ID)
+
 	return result, nil
 }
 
 // FindAll implements VideoGalleryService.
-func (s *videoGalleryService) FindAll(params utils.PaginationParams) ([]models.VideoGallery, int64, error) {
-	data, total, err := s.videoGalleryRepo.FindAll(params)
+func (s *videoGalleryService) FindAll(ctx context.Context, params utils.PaginationParams) ([]models.VideoGallery, int64, error) {
+	ctx, span := tracing.Start(ctx, "VideoGalleryService.FindAll")
+	defer span.End()
+
+	data, total, err := s.videoGalleryRepo.FindAll(ctx, params)
 
 	if err != nil {
 		return []models.VideoGallery{}, 0, err
@@ -50,8 +64,11 @@ func (s *videoGalleryService) FindAll(params utils.PaginationParams) ([]models.V
 }
 
 // FindByID implements VideoGalleryService.
-func (s *videoGalleryService) FindByID(id uint) (models.VideoGallery, error) {
-	data, err := s.videoGalleryRepo.FindByID(id)
+func (s *videoGalleryService) FindByID(ctx context.Context, id uint) (models.VideoGallery, error) {
+	ctx, span := tracing.Start(ctx, "VideoGalleryService.FindByID")
+	defer span.End()
+
+	data, err := s.videoGalleryRepo.FindByID(ctx, id)
 
 	if err != nil {
 		return models.VideoGallery{}, err
@@ -61,8 +78,11 @@ func (s *videoGalleryService) FindByID(id uint) (models.VideoGallery, error) {
 }
 
 // FindByCategory implements VideoGalleryService.
-func (s *videoGalleryService) FindByCategory(category string, params utils.PaginationParams) ([]models.VideoGallery, int64, error) {
-	data, total, err := s.videoGalleryRepo.FindByCategory(category, params)
+func (s *videoGalleryService) FindByCategory(ctx context.Context, category string, params utils.PaginationParams) ([]models.VideoGallery, int64, error) {
+	ctx, span := tracing.Start(ctx, "VideoGalleryService.FindByCategory")
+	defer span.End()
+
+	data, total, err := s.videoGalleryRepo.FindByCategory(ctx, category, params)
 
 	if err != nil {
 		return []models.VideoGallery{}, 0, err
@@ -72,30 +92,43 @@ func (s *videoGalleryService) FindByCategory(category string, params utils.Pagin
 }
 
 // Update implements VideoGalleryService.
-func (s *videoGalleryService) Update(videoGallery models.VideoGallery) (models.VideoGallery, error) {
-	data, err := s.videoGalleryRepo.Update(videoGallery)
+func (s *videoGalleryService) Update(ctx context.Context, videoGallery models.VideoGallery) (models.VideoGallery, error) {
+	ctx, span := tracing.Start(ctx, "VideoGalleryService.Update")
+	defer span.End()
+
+	data, err := s.videoGalleryRepo.Update(ctx, videoGallery)
 
 	if err != nil {
 		return models.VideoGallery{}, err
 	}
 
+	dispatchContentChange(s.webhookService, ContentEntityVideoGallery, ContentActionUpdated, data.ID)
+
 	return data, nil
 }
 
 // Delete implements VideoGalleryService.
-func (s *videoGalleryService) Delete(id uint) error {
-	err := s.videoGalleryRepo.Delete(id)
+func (s *videoGalleryService) Delete(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "VideoGalleryService.Delete")
+	defer span.End()
+
+	err := s.videoGalleryRepo.Delete(ctx, id)
 
 	if err != nil {
 		return err
 	}
 
+	dispatchContentChange(s.webhookService, ContentEntityVideoGallery, ContentActionDeleted, id)
+
 	return nil
 }
 
 // FindAllActive implements VideoGalleryService.
-func (s *videoGalleryService) FindAllActive() ([]models.VideoGallery, error) {
-	data, err := s.videoGalleryRepo.FindAllActive()
+func (s *videoGalleryService) FindAllActive(ctx context.Context) ([]models.VideoGallery, error) {
+	ctx, span := tracing.Start(ctx, "VideoGalleryService.FindAllActive")
+	defer span.End()
+
+	data, err := s.videoGalleryRepo.FindAllActive(ctx)
 
 	if err != nil {
 		return []models.VideoGallery{}, err
@@ -105,12 +138,31 @@ func (s *videoGalleryService) FindAllActive() ([]models.VideoGallery, error) {
 }
 
 // FindAllCategories implements VideoGalleryService.
-func (s *videoGalleryService) FindAllCategories() ([]string, error) {
-	data, err := s.videoGalleryRepo.FindAllCategories()
+func (s *videoGalleryService) FindAllCategories(ctx context.Context) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "VideoGalleryService.FindAllCategories")
+	defer span.End()
+
+	data, err := s.videoGalleryRepo.FindAllCategories(ctx)
 
 	if err != nil {
 		return []string{}, err
 	}
 
 	return data, nil
-}
\ No newline at end of file
+}
+
+// Reorder implements VideoGalleryService.
+func (s *videoGalleryService) Reorder(ctx context.Context, ids []uint) error {
+	ctx, span := tracing.Start(ctx, "VideoGalleryService.Reorder")
+	defer span.End()
+
+	err := s.videoGalleryRepo.Reorder(ctx, ids)
+
+	if err != nil {
+		return err
+	}
+
+	dispatchContentChange(s.webhookService, ContentEntityVideoGallery, ContentActionReordered, 0)
+
+	return nil
+}
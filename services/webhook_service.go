@@ -0,0 +1,571 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/jobs"
+	"github.com/tech-azim/be-learnova/models"
+	"github.com/tech-azim/be-learnova/repositories"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+// Event webhook yang bisa di-subscribe
+const (
+	WebhookEventRegistrationCreated       = "registration.created"
+	WebhookEventRegistrationUpdated       = "registration.updated"
+	WebhookEventRegistrationStatusChanged = "registration.status_changed"
+	WebhookEventRegistrationDeleted       = "registration.deleted"
+	WebhookEventProgramCreated            = "program.created"
+	WebhookEventProgramUpdated            = "program.updated"
+	WebhookEventProgramDeleted            = "program.deleted"
+	WebhookEventServiceCreated            = "service.created"
+	WebhookEventServiceUpdated            = "service.updated"
+	WebhookEventServiceDeleted            = "service.deleted"
+	WebhookEventContentUpdated            = "content.updated"
+)
+
+// WebhookEvents daftar event yang tersedia. Subscription juga boleh memakai
+// wildcard "*" (semua event) atau "<resource>.*" (contoh: program.*)
+var WebhookEvents = []string{
+	WebhookEventRegistrationCreated,
+	WebhookEventRegistrationUpdated,
+	WebhookEventRegistrationStatusChanged,
+	WebhookEventRegistrationDeleted,
+	WebhookEventProgramCreated,
+	WebhookEventProgramUpdated,
+	WebhookEventProgramDeleted,
+	WebhookEventServiceCreated,
+	WebhookEventServiceUpdated,
+	WebhookEventServiceDeleted,
+	WebhookEventContentUpdated,
+}
+
+// Entity & aksi di payload content.updated. Nama entity sama dengan nama resource route
+const (
+	ContentEntityHero             = "heros"
+	ContentEntityProgram          = "programs"
+	ContentEntityService          = "services"
+	ContentEntityFeature          = "features"
+	ContentEntityPortfolio        = "portfolios"
+	ContentEntityGallery          = "galleries"
+	ContentEntityVideoGallery     = "video-galleries"
+	ContentEntityFlyerGallery     = "flyer-galleries"
+	ContentEntityGalleryAlbum     = "gallery-albums"
+	ContentEntityCurriculumModule = "curriculum-modules"
+	ContentEntityCurriculumLesson = "curriculum-lessons"
+	ContentEntityProgramFAQ       = "program-faqs"
+	ContentEntityInstructor       = "instructors"
+
+	ContentActionCreated    = "created"
+	ContentActionUpdated    = "updated"
+	ContentActionDeleted    = "deleted"
+	ContentActionReordered  = "reordered"
+	ContentActionTranslated = "translated"
+)
+
+// Header request webhook. Signature: "t=<unix>,v1=<hex HMAC-SHA256 dari "<unix>.<body>">"
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookIDHeader        = "X-Webhook-ID"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// JobTypeWebhookDelivery job pengiriman satu WebhookDelivery
+const JobTypeWebhookDelivery = "webhook.deliver"
+
+// JobQueueWebhooks queue khusus webhook supaya endpoint lambat tidak menahan job lain
+const JobQueueWebhooks = "webhooks"
+
+// webhookMaxAttempts percobaan pengiriman sebelum delivery dinyatakan gagal
+const webhookMaxAttempts = 8
+
+var (
+	ErrWebhookURL   = apperrors.Validation("invalid_webhook_url", "webhook url must be an absolute http(s) url", nil)
+	ErrWebhookHost  = apperrors.Validation("invalid_webhook_host", "webhook url must point to a public host", nil)
+	ErrWebhookEvent = apperrors.Validation("invalid_webhook_event", "unknown webhook event", nil)
+
+	// errWebhookAddress alamat hasil resolve DNS bukan alamat publik (ditolak saat dial)
+	errWebhookAddress = errors.New("webhook host resolves to a non-public address")
+)
+
+// webhookBlockedPrefixes range yang tidak tercakup netip.Addr.IsPrivate/IsLinkLocalUnicast:
+// "this network" (0.0.0.0/8) dan shared address space / CGNAT (100.64.0.0/10)
+var webhookBlockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// WebhookEvent body JSON yang dikirim ke subscriber
+type WebhookEvent struct {
+	ID        string    `json:"id"`
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// ContentChange payload content.updated, dikirim untuk setiap perubahan konten yang tampil
+// di halaman publik (contoh untuk build ulang situs statis). ID kosong untuk reorder.
+type ContentChange struct {
+	Entity string `json:"entity"`
+	Action string `json:"action"`
+	ID     uint   `json:"id,omitempty"`
+}
+
+// dispatchContentChange kirim event content.updated untuk perubahan konten publik
+func dispatchContentChange(webhookService WebhookService, entity, action string, id uint) {
+	webhookService.Dispatch(WebhookEventContentUpdated, ContentChange{Entity: entity, Action: action, ID: id})
+}
+
+// webhookDeliveryJob payload job JobTypeWebhookDelivery
+type webhookDeliveryJob struct {
+	DeliveryID uint `json:"delivery_id"`
+}
+
+type WebhookService interface {
+	Dispatch(event string, data any)
+	FindAll(params utils.PaginationParams) ([]models.WebhookSubscription, int64, error)
+	FindByID(id uint) (models.WebhookSubscription, error)
+	Create(subscription models.WebhookSubscription) (models.WebhookSubscription, error)
+	Update(subscription models.WebhookSubscription) (models.WebhookSubscription, error)
+	Delete(id uint) error
+	RotateSecret(id uint) (models.WebhookSubscription, error)
+	FindDeliveries(subscriptionID uint, params utils.PaginationParams, status string) ([]models.WebhookDelivery, int64, error)
+	FindDeliveryByID(subscriptionID uint, id uint) (models.WebhookDelivery, error)
+	Redeliver(subscriptionID uint, deliveryID uint) (models.WebhookDelivery, error)
+	Deliver(ctx context.Context, deliveryID uint, finalAttempt bool) error
+}
+
+type webhookService struct {
+	webhookRepo repositories.WebhookRepository
+	jobService  JobService
+	client      *http.Client
+	logger      *slog.Logger
+}
+
+func NewWebhookService(webhookRepo repositories.WebhookRepository, jobService JobService, logger *slog.Logger) WebhookService {
+	// Alamat tujuan dicek saat dial (setelah resolve DNS) supaya hostname yang mengarah
+	// ke jaringan internal juga ditolak. Proxy dimatikan karena dial ke proxy melewati cek ini.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   webhookDialControl,
+	}).DialContext
+
+	return &webhookService{
+		webhookRepo,
+		jobService,
+		&http.Client{
+			Timeout:   10 * time.Second,
+			Transport: transport,
+			// Redirect tidak diikuti, subscriber harus mendaftarkan URL final
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger,
+	}
+}
+
+// publicWebhookAddr alamat boleh menjadi tujuan webhook: bukan loopback, private,
+// link-local (termasuk metadata cloud 169.254.169.254), unspecified atau multicast
+func publicWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range webhookBlockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// webhookDialControl tolak koneksi ke alamat non-publik, dipanggil untuk setiap alamat hasil resolve
+func webhookDialControl(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !publicWebhookAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", errWebhookAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// SignWebhookPayload hitung signature HMAC-SHA256 untuk header X-Webhook-Signature.
+// Subscriber memverifikasi dengan menghitung ulang dari timestamp dan raw body.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// matchesWebhookEvent cek apakah pattern subscription cocok dengan event
+func matchesWebhookEvent(pattern, event string) bool {
+	if pattern == "*" || pattern == event {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, ".*"); ok {
+		return strings.HasPrefix(event, prefix+".")
+	}
+	return false
+}
+
+// validWebhookEvent event harus terdaftar di WebhookEvents atau wildcard yang cocok minimal satu event
+func validWebhookEvent(pattern string) bool {
+	for _, event := range WebhookEvents {
+		if matchesWebhookEvent(pattern, event) {
+			return true
+		}
+	}
+	return false
+}
+
+func generateWebhookSecret() (string, error) {
+	random := make([]byte, 24)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(random), nil
+}
+
+// validate normalisasi & validasi URL dan event subscription
+func (s *webhookService) validate(subscription *models.WebhookSubscription) error {
+	parsed, err := url.Parse(strings.TrimSpace(subscription.URL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrWebhookURL
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrWebhookHost
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !publicWebhookAddr(addr) {
+		return ErrWebhookHost
+	}
+	subscription.URL = parsed.String()
+
+	events := make([]string, 0, len(subscription.Events))
+	seen := make(map[string]bool, len(subscription.Events))
+	for _, event := range subscription.Events {
+		event = strings.TrimSpace(event)
+		if !validWebhookEvent(event) {
+			return fmt.Errorf("%w: %s", ErrWebhookEvent, event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+	subscription.Events = events
+
+	return nil
+}
+
+// Dispatch implements WebhookService.
+// Satu delivery dibuat per subscription yang cocok lalu dikirim lewat job queue.
+// Error hanya di-log supaya request utama tidak gagal karena webhook.
+func (s *webhookService) Dispatch(event string, data any) {
+	subscriptions, err := s.webhookRepo.FindActive()
+	if err != nil {
+		s.logger.Error("failed to load webhook subscriptions", "event", event, "error", err)
+		return
+	}
+
+	var matched []models.WebhookSubscription
+	for _, subscription := range subscriptions {
+		for _, pattern := range subscription.Events {
+			if matchesWebhookEvent(pattern, event) {
+				matched = append(matched, subscription)
+				break
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return
+	}
+
+	payload := WebhookEvent{
+		ID:        uuid.NewString(),
+		Event:     event,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		s.logger.Error("failed to encode webhook payload", "event", event, "error", err)
+		return
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(matched))
+	for _, subscription := range matched {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        payload.ID,
+			Event:          event,
+			Payload:        body,
+			Status:         models.WebhookDeliveryPending,
+		})
+	}
+
+	deliveries, err = s.webhookRepo.CreateDeliveries(deliveries)
+	if err != nil {
+		s.logger.Error("failed to create webhook deliveries", "event", event, "error", err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		s.enqueue(delivery)
+	}
+}
+
+func (s *webhookService) enqueue(delivery models.WebhookDelivery) {
+	_, err := s.jobService.Enqueue(context.Background(), JobTypeWebhookDelivery, webhookDeliveryJob{DeliveryID: delivery.ID}, JobOptions{
+		Queue:       JobQueueWebhooks,
+		MaxAttempts: webhookMaxAttempts,
+	})
+	if err != nil {
+		s.logger.Error("failed to enqueue webhook delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// FindAll implements WebhookService.
+func (s *webhookService) FindAll(params utils.PaginationParams) ([]models.WebhookSubscription, int64, error) {
+	data, total, err := s.webhookRepo.FindAll(params)
+
+	if err != nil {
+		return []models.WebhookSubscription{}, 0, err
+	}
+
+	return data, total, nil
+}
+
+// FindByID implements WebhookService.
+func (s *webhookService) FindByID(id uint) (models.WebhookSubscription, error) {
+	data, err := s.webhookRepo.FindByID(id)
+
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+
+	return data, nil
+}
+
+// Create implements WebhookService.
+// Secret dibuat otomatis jika kosong
+func (s *webhookService) Create(subscription models.WebhookSubscription) (models.WebhookSubscription, error) {
+	if err := s.validate(&subscription); err != nil {
+		return models.WebhookSubscription{}, err
+	}
+
+	if subscription.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return models.WebhookSubscription{}, err
+		}
+		subscription.Secret = secret
+	}
+
+	data, err := s.webhookRepo.Create(subscription)
+
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+
+	return data, nil
+}
+
+// Update implements WebhookService.
+func (s *webhookService) Update(subscription models.WebhookSubscription) (models.WebhookSubscription, error) {
+	if err := s.validate(&subscription); err != nil {
+		return models.WebhookSubscription{}, err
+	}
+
+	data, err := s.webhookRepo.Update(subscription)
+
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+
+	return data, nil
+}
+
+// Delete implements WebhookService.
+func (s *webhookService) Delete(id uint) error {
+	return s.webhookRepo.Delete(id)
+}
+
+// RotateSecret implements WebhookService.
+func (s *webhookService) RotateSecret(id uint) (models.WebhookSubscription, error) {
+	subscription, err := s.webhookRepo.FindByID(id)
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return models.WebhookSubscription{}, err
+	}
+	subscription.Secret = secret
+
+	return s.webhookRepo.Update(subscription)
+}
+
+// FindDeliveries implements WebhookService.
+func (s *webhookService) FindDeliveries(subscriptionID uint, params utils.PaginationParams, status string) ([]models.WebhookDelivery, int64, error) {
+	data, total, err := s.webhookRepo.FindDeliveries(subscriptionID, params, status)
+
+	if err != nil {
+		return []models.WebhookDelivery{}, 0, err
+	}
+
+	return data, total, nil
+}
+
+// FindDeliveryByID implements WebhookService.
+func (s *webhookService) FindDeliveryByID(subscriptionID uint, id uint) (models.WebhookDelivery, error) {
+	data, err := s.webhookRepo.FindDeliveryByID(subscriptionID, id)
+
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+
+	return data, nil
+}
+
+// Redeliver implements WebhookService.
+// Delivery baru dibuat dengan event ID & payload yang sama, log delivery lama tidak diubah
+func (s *webhookService) Redeliver(subscriptionID uint, deliveryID uint) (models.WebhookDelivery, error) {
+	original, err := s.webhookRepo.FindDeliveryByID(subscriptionID, deliveryID)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+
+	deliveries, err := s.webhookRepo.CreateDeliveries([]models.WebhookDelivery{{
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		Event:          original.Event,
+		Payload:        original.Payload,
+		Status:         models.WebhookDeliveryPending,
+	}})
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+
+	s.enqueue(deliveries[0])
+
+	return deliveries[0], nil
+}
+
+// Deliver implements WebhookService.
+// Kirim delivery ke URL subscription dan catat hasilnya. Return error jika perlu di-retry.
+func (s *webhookService) Deliver(ctx context.Context, deliveryID uint, finalAttempt bool) error {
+	delivery, err := s.webhookRepo.FindDeliveryByID(0, deliveryID)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := s.webhookRepo.FindByID(delivery.SubscriptionID)
+	if err != nil || !subscription.IsActive {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.Error = "subscription is inactive or deleted"
+		if err := s.webhookRepo.UpdateDelivery(delivery); err != nil {
+			return err
+		}
+		return jobs.Permanent(errors.New(delivery.Error))
+	}
+
+	sendErr := s.send(ctx, subscription, &delivery)
+
+	delivery.Attempts++
+	switch {
+	case sendErr == nil:
+		now := time.Now()
+		delivery.Status = models.WebhookDeliverySuccess
+		delivery.Error = ""
+		delivery.DeliveredAt = &now
+	case finalAttempt || jobs.IsPermanent(sendErr):
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.Error = sendErr.Error()
+	default:
+		delivery.Status = models.WebhookDeliveryRetrying
+		delivery.Error = sendErr.Error()
+	}
+
+	if err := s.webhookRepo.UpdateDelivery(delivery); err != nil {
+		s.logger.Error("failed to update webhook delivery", "delivery_id", delivery.ID, "error", err)
+	}
+
+	return sendErr
+}
+
+// send POST payload bertanda tangan, response non-2xx dianggap gagal.
+// Response body tidak dibaca maupun disimpan, hanya status code yang dicatat.
+func (s *webhookService) send(ctx context.Context, subscription models.WebhookSubscription, delivery *models.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return jobs.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Learnova-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookIDHeader, delivery.EventID)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(subscription.Secret, time.Now().Unix(), delivery.Payload))
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		delivery.ResponseStatus = 0
+		if errors.Is(err, errWebhookAddress) {
+			return jobs.Permanent(err)
+		}
+		return err
+	}
+	resp.Body.Close()
+
+	delivery.ResponseStatus = resp.StatusCode
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// WebhookDeliveryJobHandler handler job JobTypeWebhookDelivery
+func WebhookDeliveryJobHandler(webhookService WebhookService) jobs.Handler {
+	return func(ctx context.Context, job models.Job) error {
+		var payload webhookDeliveryJob
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return jobs.Permanent(fmt.Errorf("invalid webhook payload: %w", err))
+		}
+
+		return webhookService.Deliver(ctx, payload.DeliveryID, job.Attempts >= job.MaxAttempts)
+	}
+}
@@ -0,0 +1,143 @@
+package spam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrCaptchaUnavailable provider CAPTCHA tidak bisa dihubungi / response tidak valid
+var ErrCaptchaUnavailable = errors.New("captcha provider unavailable")
+
+// CaptchaVerifier verifikasi token CAPTCHA dari client
+type CaptchaVerifier interface {
+	// Enabled false berarti CAPTCHA tidak diwajibkan
+	Enabled() bool
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// Endpoint siteverify provider yang didukung, semuanya memakai format request/response yang sama
+var captchaEndpoints = map[string]string{
+	"recaptcha": "https://www.google.com/recaptcha/api/siteverify",
+	"hcaptcha":  "https://api.hcaptcha.com/siteverify",
+	"turnstile": "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+}
+
+// Provider CAPTCHA yang didukung selain endpoint siteverify di atas
+const (
+	CaptchaProviderNone = "none"
+	CaptchaProviderFake = "fake"
+)
+
+// NewCaptchaVerifier provider recaptcha|hcaptcha|turnstile|fake|none.
+// Provider kosong atau none mematikan verifikasi. Provider fake menerima secret sebagai token valid.
+func NewCaptchaVerifier(provider string, secret string) (CaptchaVerifier, error) {
+	provider = strings.ToLower(provider)
+	if provider == "" || provider == CaptchaProviderNone {
+		return DisabledVerifier{}, nil
+	}
+
+	if provider == CaptchaProviderFake {
+		return FakeVerifier{ValidToken: secret}, nil
+	}
+
+	endpoint, ok := captchaEndpoints[provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown captcha provider %q", provider)
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("captcha secret is required for provider %s", provider)
+	}
+
+	return NewSiteVerifier(endpoint, secret), nil
+}
+
+// DisabledVerifier dipakai jika CAPTCHA tidak dikonfigurasi
+type DisabledVerifier struct{}
+
+// Enabled implements CaptchaVerifier.
+func (DisabledVerifier) Enabled() bool { return false }
+
+// Verify implements CaptchaVerifier.
+func (DisabledVerifier) Verify(context.Context, string, string) (bool, error) { return true, nil }
+
+// SiteVerifier verifikasi lewat endpoint siteverify (reCAPTCHA, hCaptcha, Turnstile)
+type SiteVerifier struct {
+	endpoint string
+	secret   string
+	client   *http.Client
+}
+
+func NewSiteVerifier(endpoint, secret string) *SiteVerifier {
+	return &SiteVerifier{
+		endpoint: endpoint,
+		secret:   secret,
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Enabled implements CaptchaVerifier.
+func (v *SiteVerifier) Enabled() bool { return true }
+
+// Verify implements CaptchaVerifier.
+func (v *SiteVerifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {token},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrCaptchaUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("%w: status %d", ErrCaptchaUnavailable, resp.StatusCode)
+	}
+
+	var body struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return false, fmt.Errorf("%w: %v", ErrCaptchaUnavailable, err)
+	}
+
+	return body.Success, nil
+}
+
+// FakeVerifier verifier untuk test/development: token sama dengan ValidToken dianggap valid.
+// Err diisi untuk mensimulasikan provider yang sedang down.
+type FakeVerifier struct {
+	ValidToken string
+	Err        error
+}
+
+// Enabled implements CaptchaVerifier.
+func (f FakeVerifier) Enabled() bool { return true }
+
+// Verify implements CaptchaVerifier.
+func (f FakeVerifier) Verify(_ context.Context, token, _ string) (bool, error) {
+	if f.Err != nil {
+		return false, f.Err
+	}
+	return token != "" && token == f.ValidToken, nil
+}
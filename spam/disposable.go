@@ -0,0 +1,57 @@
+package spam
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+)
+
+//go:embed disposable_domains.txt
+var disposableDomainsFile string
+
+// DomainBlocklist daftar domain email sekali pakai (disposable)
+type DomainBlocklist struct {
+	domains map[string]bool
+}
+
+// NewDomainBlocklist daftar bawaan ditambah extra (contoh dari env DISPOSABLE_EMAIL_DOMAINS)
+func NewDomainBlocklist(extra []string) *DomainBlocklist {
+	domains := make(map[string]bool)
+
+	scanner := bufio.NewScanner(strings.NewReader(disposableDomainsFile))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains[strings.ToLower(line)] = true
+	}
+	for _, domain := range extra {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			domains[domain] = true
+		}
+	}
+
+	return &DomainBlocklist{domains: domains}
+}
+
+// IsDisposable cek domain email termasuk subdomain dari domain yang diblokir
+func (b *DomainBlocklist) IsDisposable(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	domain := strings.ToLower(strings.TrimSpace(email[at+1:]))
+	for domain != "" {
+		if b.domains[domain] {
+			return true
+		}
+		dot := strings.Index(domain, ".")
+		if dot < 0 {
+			break
+		}
+		domain = domain[dot+1:]
+	}
+	return false
+}
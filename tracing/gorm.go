@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// spanKey key instance statement untuk span query yang sedang berjalan
+const spanKey = "tracing:span"
+
+// GormPlugin buat span untuk setiap query GORM. Span menjadi child dari request
+// hanya jika repository memanggil db.WithContext(ctx).
+type GormPlugin struct{}
+
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{}
+}
+
+// Name implements gorm.Plugin.
+func (p *GormPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize implements gorm.Plugin.
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	registrations := []error{
+		cb.Create().Before("*").Register("tracing:before_create", startQuery("INSERT")),
+		cb.Create().After("*").Register("tracing:after_create", endQuery),
+		cb.Query().Before("*").Register("tracing:before_query", startQuery("SELECT")),
+		cb.Query().After("*").Register("tracing:after_query", endQuery),
+		cb.Update().Before("*").Register("tracing:before_update", startQuery("UPDATE")),
+		cb.Update().After("*").Register("tracing:after_update", endQuery),
+		cb.Delete().Before("*").Register("tracing:before_delete", startQuery("DELETE")),
+		cb.Delete().After("*").Register("tracing:after_delete", endQuery),
+		cb.Row().Before("*").Register("tracing:before_row", startQuery("ROW")),
+		cb.Row().After("*").Register("tracing:after_row", endQuery),
+		cb.Raw().Before("*").Register("tracing:before_raw", startQuery("RAW")),
+		cb.Raw().After("*").Register("tracing:after_raw", endQuery),
+	}
+	for _, err := range registrations {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startQuery(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		name := operation
+		if db.Statement.Table != "" {
+			name += " " + db.Statement.Table
+		}
+
+		_, span := Start(db.Statement.Context, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemNamePostgreSQL,
+				semconv.DBOperationName(operation),
+			),
+		)
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func endQuery(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	// SQL dengan placeholder, nilai parameter tidak ikut direkam
+	attrs := []attribute.KeyValue{
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		semconv.DBResponseReturnedRows(int(db.Statement.RowsAffected)),
+	}
+	if db.Statement.Table != "" {
+		attrs = append(attrs, semconv.DBCollectionName(db.Statement.Table))
+	}
+	span.SetAttributes(attrs...)
+
+	// Record not found adalah hasil normal (404), bukan error query
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName nama instrumentation scope untuk semua span aplikasi
+const TracerName = "github.com/tech-azim/be-learnova"
+
+// Exporter yang didukung
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Options konfigurasi tracer provider
+type Options struct {
+	// Exporter none|stdout|otlp, none tetap memasang propagator tapi tidak merekam span
+	Exporter string
+	// Endpoint URL collector OTLP/HTTP (contoh http://localhost:4318), kosong berarti
+	// memakai OTEL_EXPORTER_OTLP_ENDPOINT / default exporter
+	Endpoint    string
+	ServiceName string
+	// SampleRatio porsi trace baru yang direkam (0-1), trace dari upstream mengikuti keputusan parent
+	SampleRatio float64
+}
+
+// Setup pasang tracer provider & propagator global.
+// Fungsi shutdown yang dikembalikan mengirim sisa span, panggil saat aplikasi berhenti.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	// W3C traceparent supaya trace dari frontend / proxy bisa diteruskan
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch strings.ToLower(opts.Exporter) {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		var exporterOpts []otlptracehttp.Option
+		if opts.Endpoint != "" {
+			exporterOpts = append(exporterOpts, otlptracehttp.WithEndpointURL(opts.Endpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, exporterOpts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create %s trace exporter: %w", opts.Exporter, err)
+	}
+
+	resource, err := sdkresource.Merge(
+		sdkresource.Default(),
+		sdkresource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(opts.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start mulai span baru sebagai child dari span di ctx (jika ada)
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// End tandai span error jika err tidak nil, lalu akhiri span.
+// Dipakai dengan named return: defer func() { tracing.End(span, err) }()
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceID trace ID dari span di ctx, kosong jika tidak ada span yang valid
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
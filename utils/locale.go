@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultLocale bahasa konten asli yang disimpan di tabel entity
+const DefaultLocale = "id"
+
+// SupportedLocales daftar locale yang bisa diminta client
+var SupportedLocales = []string{"id", "en"}
+
+// IsSupportedLocale cek apakah locale didukung
+func IsSupportedLocale(locale string) bool {
+	for _, supported := range SupportedLocales {
+		if supported == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// QueryLocale locale dari query ?lang=, ok false jika tidak diisi atau tidak didukung
+func QueryLocale(c *gin.Context) (string, bool) {
+	lang := normalizeLocale(c.Query("lang"))
+	return lang, IsSupportedLocale(lang)
+}
+
+// GetLocale ambil locale dari query ?lang=, lalu header Accept-Language,
+// fallback ke DefaultLocale jika tidak ada yang didukung
+func GetLocale(c *gin.Context) string {
+	if lang, ok := QueryLocale(c); ok {
+		return lang
+	}
+
+	if locale := ParseAcceptLanguage(c.GetHeader("Accept-Language")); locale != "" {
+		return locale
+	}
+
+	return DefaultLocale
+}
+
+// ParseAcceptLanguage pilih locale didukung dengan q-value tertinggi dari header Accept-Language
+// Contoh: "en-US,en;q=0.9,id;q=0.8" -> "en"
+func ParseAcceptLanguage(header string) string {
+	type candidate struct {
+		locale string
+		q      float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		locale := normalizeLocale(tag)
+		if q > 0 && IsSupportedLocale(locale) {
+			candidates = append(candidates, candidate{locale, q})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].locale
+}
+
+// normalizeLocale ambil primary subtag: "en-US" -> "en"
+func normalizeLocale(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if primary, _, found := strings.Cut(tag, "-"); found {
+		return primary
+	}
+	if primary, _, found := strings.Cut(tag, "_"); found {
+		return primary
+	}
+	return tag
+}
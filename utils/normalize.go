@@ -0,0 +1,30 @@
+package utils
+
+import "strings"
+
+// NormalizeEmail email lowercase tanpa spasi supaya pencocokan konsisten
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone ambil digit saja dan samakan prefix Indonesia ke 62
+// Contoh: "0812-3456-789" dan "+62 812 3456 789" -> "628123456789"
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+
+	digits := b.String()
+	if strings.HasPrefix(digits, "0") {
+		digits = "62" + digits[1:]
+	}
+	return digits
+}
+
+// NormalizeName lowercase dengan spasi berulang digabung, untuk pencocokan nama organisasi
+func NormalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
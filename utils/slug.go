@@ -0,0 +1,27 @@
+package utils
+
+import "strings"
+
+// Slugify mengubah teks menjadi slug URL (huruf kecil, angka, dan tanda hubung)
+// Contoh: "Pelatihan K3 & Safety 2025" -> "pelatihan-k3-safety-2025"
+func Slugify(text string) string {
+	var b strings.Builder
+	dash := false
+
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case b.Len() > 0 && !dash:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	slug := strings.TrimSuffix(b.String(), "-")
+	if len(slug) > 200 {
+		slug = strings.TrimSuffix(slug[:200], "-")
+	}
+	return slug
+}
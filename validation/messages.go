@@ -0,0 +1,138 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+// summaries pesan utama response validasi per locale
+var summaries = map[string]string{
+	"id": "Data yang dikirim tidak valid",
+	"en": "The submitted data is invalid",
+}
+
+// messages template pesan per locale dan aturan. {field} diganti nama field, {param} parameter aturan.
+// Aturan min/max/len punya varian _items (array) dan _number (angka).
+var messages = map[string]map[string]string{
+	"id": {
+		"required":         "{field} wajib diisi",
+		"required_if":      "{field} wajib diisi",
+		"required_with":    "{field} wajib diisi",
+		"required_without": "{field} wajib diisi",
+		"email":            "{field} harus berupa alamat email yang valid",
+		"url":              "{field} harus berupa URL yang valid",
+		"http_url":         "{field} harus berupa URL http/https yang valid",
+		"min":              "{field} minimal {param} karakter",
+		"min_items":        "{field} minimal berisi {param} item",
+		"min_number":       "{field} minimal {param}",
+		"max":              "{field} maksimal {param} karakter",
+		"max_items":        "{field} maksimal berisi {param} item",
+		"max_number":       "{field} maksimal {param}",
+		"len":              "{field} harus {param} karakter",
+		"len_items":        "{field} harus berisi {param} item",
+		"len_number":       "{field} harus bernilai {param}",
+		"gte":              "{field} minimal {param}",
+		"lte":              "{field} maksimal {param}",
+		"gt":               "{field} harus lebih dari {param}",
+		"lt":               "{field} harus kurang dari {param}",
+		"oneof":            "{field} harus salah satu dari: {param}",
+		"numeric":          "{field} harus berupa angka",
+		"number":           "{field} harus berupa angka",
+		"boolean":          "{field} harus berupa true atau false",
+		"datetime":         "{field} harus berformat {param}",
+		"unique":           "{field} tidak boleh berisi nilai duplikat",
+		"type":             "{field} harus bertipe {param}",
+		"json":             "Body request bukan JSON yang valid",
+		"future":           "{field} tidak boleh di masa lalu",
+		"file_type":        "{field} harus berupa file dengan tipe: {param}",
+		"file_size":        "Ukuran {field} maksimal {param}",
+		"invalid":          "{field} tidak valid",
+	},
+	"en": {
+		"required":         "{field} is required",
+		"required_if":      "{field} is required",
+		"required_with":    "{field} is required",
+		"required_without": "{field} is required",
+		"email":            "{field} must be a valid email address",
+		"url":              "{field} must be a valid URL",
+		"http_url":         "{field} must be a valid http/https URL",
+		"min":              "{field} must be at least {param} characters",
+		"min_items":        "{field} must contain at least {param} items",
+		"min_number":       "{field} must be at least {param}",
+		"max":              "{field} must be at most {param} characters",
+		"max_items":        "{field} must contain at most {param} items",
+		"max_number":       "{field} must be at most {param}",
+		"len":              "{field} must be exactly {param} characters",
+		"len_items":        "{field} must contain exactly {param} items",
+		"len_number":       "{field} must be {param}",
+		"gte":              "{field} must be at least {param}",
+		"lte":              "{field} must be at most {param}",
+		"gt":               "{field} must be greater than {param}",
+		"lt":               "{field} must be less than {param}",
+		"oneof":            "{field} must be one of: {param}",
+		"numeric":          "{field} must be a number",
+		"number":           "{field} must be a number",
+		"boolean":          "{field} must be true or false",
+		"datetime":         "{field} must use the format {param}",
+		"unique":           "{field} must not contain duplicate values",
+		"type":             "{field} must be of type {param}",
+		"json":             "Request body is not valid JSON",
+		"future":           "{field} cannot be in the past",
+		"file_type":        "{field} must be a file of type: {param}",
+		"file_size":        "{field} must not exceed {param}",
+		"invalid":          "{field} is invalid",
+	},
+}
+
+// layoutReplacer tampilkan layout waktu Go dalam bentuk yang dikenal pengguna: 2006-01-02 -> YYYY-MM-DD
+var layoutReplacer = strings.NewReplacer("2006", "YYYY", "01", "MM", "02", "DD", "15", "HH", "04", "mm", "05", "ss")
+
+// message pesan untuk satu kesalahan, fallback ke DefaultLocale lalu aturan "invalid"
+func message(locale, field, rule, param string, kind reflect.Kind) string {
+	templates, ok := messages[locale]
+	if !ok {
+		templates = messages[utils.DefaultLocale]
+	}
+
+	template, ok := templates[messageKey(rule, kind)]
+	if !ok {
+		template = templates["invalid"]
+	}
+
+	switch rule {
+	case "oneof":
+		// Parameter oneof dipisah spasi, tampilkan sebagai daftar
+		param = strings.Join(strings.Fields(param), ", ")
+	case "datetime":
+		param = layoutReplacer.Replace(param)
+	}
+	return strings.NewReplacer("{field}", field, "{param}", param).Replace(template)
+}
+
+// messageKey varian aturan panjang sesuai jenis field
+func messageKey(rule string, kind reflect.Kind) string {
+	switch rule {
+	case "min", "max", "len":
+	default:
+		return rule
+	}
+
+	switch kind {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rule + "_items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return rule + "_number"
+	}
+	return rule
+}
+
+func summary(locale string) string {
+	if text, ok := summaries[locale]; ok {
+		return text
+	}
+	return summaries[utils.DefaultLocale]
+}
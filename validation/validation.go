@@ -0,0 +1,222 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/tech-azim/be-learnova/apperrors"
+	"github.com/tech-azim/be-learnova/utils"
+)
+
+// Code kode error validasi yang dikirim ke client
+const Code = "validation_failed"
+
+// BodyField nama field untuk kesalahan yang menyangkut seluruh body (JSON rusak, body kosong)
+const BodyField = "body"
+
+// Nama field di pesan error memakai tag json/form, bukan nama field Go. Didaftarkan saat init
+// karena validator meng-cache metadata struct pada validasi pertama.
+func init() {
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterTagNameFunc(fieldName)
+	}
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// Errors kumpulan kesalahan validasi satu request, pesannya memakai locale request (?lang= / Accept-Language)
+type Errors struct {
+	locale string
+	fields []apperrors.FieldError
+}
+
+func New(c *gin.Context) *Errors {
+	return &Errors{locale: utils.GetLocale(c)}
+}
+
+// MappingError kesalahan mapping query/form ke struct untuk satu parameter (contoh ?page=abc ke field int)
+type MappingError struct {
+	Field string
+	Err   error
+}
+
+func (e *MappingError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *MappingError) Unwrap() error {
+	return e.Err
+}
+
+// Bind kumpulkan kesalahan dari hasil ShouldBind*. Error yang bukan kesalahan input
+// (contoh body melebihi batas) dikembalikan apa adanya.
+func (e *Errors) Bind(err error) error {
+	var validationErrs validator.ValidationErrors
+	var mappingErr *MappingError
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case err == nil:
+	case errors.As(err, &maxBytesErr):
+		return err
+	case errors.As(err, &validationErrs):
+		for _, fe := range validationErrs {
+			e.add(namespace(fe), fe.Tag(), fe.Param(), fe.Kind())
+		}
+	case errors.As(err, &mappingErr):
+		e.Add(mappingErr.Field, "invalid", "")
+	case errors.As(err, &typeErr):
+		e.Add(typeErr.Field, "type", jsonType(typeErr.Type))
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		e.Add(BodyField, "json", "")
+	case errors.Is(err, io.EOF):
+		e.Add(BodyField, "required", "")
+	default:
+		// Kesalahan mapping yang parameternya tidak bisa ditemukan (lihat ShouldBindForm)
+		e.Add(BodyField, "invalid", "")
+	}
+	return nil
+}
+
+// Add tambah kesalahan untuk field dengan aturan rule (lihat messages.go)
+func (e *Errors) Add(field, rule, param string) {
+	e.add(field, rule, param, reflect.String)
+}
+
+func (e *Errors) add(field, rule, param string, kind reflect.Kind) {
+	e.fields = append(e.fields, apperrors.FieldError{
+		Field:   field,
+		Rule:    rule,
+		Param:   param,
+		Message: message(e.locale, field, rule, param, kind),
+	})
+}
+
+// Has true jika field sudah punya kesalahan, untuk melewati pemeriksaan lanjutan
+func (e *Errors) Has(field string) bool {
+	for _, fe := range e.fields {
+		if fe.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Err error validasi berisi semua kesalahan, nil jika tidak ada
+func (e *Errors) Err() error {
+	if len(e.fields) == 0 {
+		return nil
+	}
+	return apperrors.Validation(Code, summary(e.locale), e.fields)
+}
+
+// BindJSON bind body JSON ke obj dan validasi tag binding
+func BindJSON(c *gin.Context, obj any) error {
+	return bindWith(c, c.ShouldBindJSON(obj))
+}
+
+// BindForm bind form (multipart atau urlencoded, termasuk field *multipart.FileHeader) ke obj
+func BindForm(c *gin.Context, obj any) error {
+	return bindWith(c, ShouldBindForm(c, obj))
+}
+
+// BindQuery bind query string ke obj
+func BindQuery(c *gin.Context, obj any) error {
+	err := c.ShouldBindQuery(obj)
+	return bindWith(c, mappingError(obj, c.Request.URL.Query(), err))
+}
+
+// ShouldBindForm seperti c.ShouldBind, kesalahan mapping dibungkus MappingError berisi nama field
+func ShouldBindForm(c *gin.Context, obj any) error {
+	// Form dibaca setelah ShouldBind karena baru diisi saat body di-parse
+	err := c.ShouldBind(obj)
+	return mappingError(obj, c.Request.Form, err)
+}
+
+// mappingError cari parameter yang gagal di-mapping dengan memetakan tiap parameter satu per satu
+// ke salinan kosong obj, karena error mapping dari gin tidak menyebut nama field
+func mappingError(obj any, values map[string][]string, err error) error {
+	var validationErrs validator.ValidationErrors
+	if err == nil || errors.As(err, &validationErrs) {
+		return err
+	}
+
+	typ := reflect.TypeOf(obj)
+	if typ == nil || typ.Kind() != reflect.Pointer {
+		return err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		probe := reflect.New(typ.Elem()).Interface()
+		if binding.MapFormWithTag(probe, map[string][]string{key: values[key]}, "form") != nil {
+			return &MappingError{Field: key, Err: err}
+		}
+	}
+	return err
+}
+
+func bindWith(c *gin.Context, bindErr error) error {
+	errs := New(c)
+	if err := errs.Bind(bindErr); err != nil {
+		return err
+	}
+	return errs.Err()
+}
+
+// namespace path field tanpa nama struct root: "RegistrationRequest.attendees[0].name" -> "attendees[0].name"
+func namespace(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+// jsonType nama tipe JSON yang diharapkan untuk pesan error
+func jsonType(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return t.String()
+}